@@ -0,0 +1,106 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/user"
+	"strings"
+	"time"
+
+	"eni-project/internal/ec2"
+)
+
+// cliCaller identifies the local user in audit records
+func cliCaller() string {
+	u, err := user.Current()
+	if err != nil {
+		return "cli:unknown"
+	}
+	return "cli:" + u.Username
+}
+
+// runAudit implements `audit verify` and `audit query` against the log at
+// path, or the one named by -file
+func runAudit(args []string, path string) {
+	if len(args) == 0 {
+		slog.Error("Missing audit subcommand (want verify or query)")
+		exit(2)
+	}
+
+	switch args[0] {
+	case "verify":
+		runAuditVerify(args[1:], path)
+	case "query":
+		runAuditQuery(args[1:], path)
+	default:
+		slog.Error("Unknown audit subcommand (want verify or query)", "subcommand", args[0])
+		exit(2)
+	}
+}
+
+func runAuditVerify(args []string, path string) {
+	fs := flag.NewFlagSet("audit verify", flag.ExitOnError)
+	file := fs.String("file", path, "audit log to verify")
+	fs.Parse(args)
+
+	f, err := os.Open(*file)
+	if err != nil {
+		fatal("Failed to open audit log", err)
+	}
+	defer f.Close()
+
+	count, lastHash, err := ec2.VerifyAudit(f)
+	if err != nil {
+		slog.Error("Audit log failed verification", "file", *file, "verified_records", count, "error", err)
+		exit(1)
+	}
+	fmt.Printf("%s: %d records verified, last hash %s\n", *file, count, lastHash)
+}
+
+func runAuditQuery(args []string, path string) {
+	fs := flag.NewFlagSet("audit query", flag.ExitOnError)
+	file := fs.String("file", path, "audit log to query")
+	eniID := fs.String("eni", "", "only records for this ENI ID")
+	operations := fs.String("operation", "", "only these operations (comma-separated, e.g. CreateENI,DeleteENI)")
+	caller := fs.String("caller", "", "only records from this caller")
+	since := fs.String("since", "", "only records at or after this RFC 3339 time")
+	until := fs.String("until", "", "only records before this RFC 3339 time")
+	failed := fs.Bool("failed", false, "only failed or rejected operations")
+	fs.Parse(args)
+
+	query := ec2.AuditQuery{ENIID: *eniID, Caller: *caller, FailedOnly: *failed}
+	if *operations != "" {
+		query.Operations = strings.Split(*operations, ",")
+	}
+	for _, t := range []struct {
+		value string
+		dst   *time.Time
+	}{{*since, &query.Since}, {*until, &query.Until}} {
+		if t.value == "" {
+			continue
+		}
+		parsed, err := time.Parse(time.RFC3339, t.value)
+		if err != nil {
+			fatal("Invalid time", err)
+		}
+		*t.dst = parsed
+	}
+
+	f, err := os.Open(*file)
+	if err != nil {
+		fatal("Failed to open audit log", err)
+	}
+	defer f.Close()
+
+	records, err := ec2.QueryAudit(f, query)
+	if err != nil {
+		fatal("Failed to read audit log", err)
+	}
+	enc := json.NewEncoder(os.Stdout)
+	for _, rec := range records {
+		enc.Encode(rec)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"eni-project/internal/ec2"
+)
+
+// runCards implements the cards subcommands: status, which shows the
+// network cards of an instance, and attach, which spreads a batch of ENIs
+// across them
+func runCards(args []string, eniManager *ec2.ENIManager, cfg settings) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: cards status|attach")
+		exit(2)
+	}
+	command, args := args[0], args[1:]
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	switch command {
+	case "status":
+		fs := flag.NewFlagSet("cards status", flag.ExitOnError)
+		instanceID := fs.String("instance", cfg.InstanceID, "instance to report on")
+		fs.Parse(args)
+
+		cards, err := eniManager.NetworkCards(ctx, *instanceID)
+		if err != nil {
+			fatal("Failed to read network cards", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "CARD\tUSED\tMAX\tDEVICES")
+		for _, card := range cards.Cards {
+			fmt.Fprintf(w, "%d\t%d\t%d\t%v\n", card.Index, len(card.DeviceIndexes), card.MaxInterfaces, card.DeviceIndexes)
+		}
+		w.Flush()
+
+	case "attach":
+		fs := flag.NewFlagSet("cards attach", flag.ExitOnError)
+		instanceID := fs.String("instance", cfg.InstanceID, "instance to attach to")
+		strategy := fs.String("strategy", cfg.AttachStrategy, "spread or pack (default spread)")
+		planOnly := fs.Bool("plan", false, "print the plan without attaching")
+		fs.Parse(args)
+		if fs.NArg() == 0 {
+			fmt.Fprintln(os.Stderr, "usage: cards attach [-instance ID] [-strategy spread|pack] [-plan] ENI...")
+			exit(2)
+		}
+		attachStrategy, err := ec2.ParseAttachStrategy(*strategy)
+		if err != nil {
+			fatal("Invalid attach strategy", err)
+		}
+
+		var plan []ec2.PlannedAttachment
+		if *planOnly {
+			cards, err := eniManager.NetworkCards(ctx, *instanceID)
+			if err != nil {
+				fatal("Failed to read network cards", err)
+			}
+			plan, err = ec2.PlanAttachments(cards.Cards, fs.Args(), attachStrategy)
+		} else {
+			plan, err = eniManager.AttachENIs(ctx, *instanceID, fs.Args(), attachStrategy)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "ENI\tCARD\tDEVICE\tATTACHMENT")
+		for _, p := range plan {
+			fmt.Fprintf(w, "%s\t%d\t%d\t%s\n", p.ENIID, p.NetworkCardIndex, p.DeviceIndex, p.AttachmentID)
+		}
+		w.Flush()
+		if err != nil {
+			fatal("Failed to attach ENIs", err)
+		}
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown cards command %q (want status or attach)\n", command)
+		exit(2)
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"eni-project/internal/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+)
+
+// account is one way of reaching an AWS account: a shared config profile,
+// a role assumed from the default credentials, or the default credentials
+type account struct {
+	label   string
+	profile string
+	roleARN string
+}
+
+// accountsFor expands the --profile and --role-arn flags; with neither set
+// the default credential chain is used
+func accountsFor(profiles, roleARNs []string) []account {
+	var accounts []account
+	for _, profile := range profiles {
+		accounts = append(accounts, account{label: "profile:" + profile, profile: profile})
+	}
+	for _, arn := range roleARNs {
+		accounts = append(accounts, account{label: arn, roleARN: arn})
+	}
+	if len(accounts) == 0 {
+		accounts = append(accounts, account{label: "default"})
+	}
+	return accounts
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// stackConfig configures the client stack built for every account and region
+type stackConfig struct {
+	// record keeps a cassette of the calls that reach EC2
+	record    bool
+	rateLimit ec2.RateLimitConfig
+}
+
+// clientStack is the decorated EC2 client behind one registered manager,
+// kept so its limiter and cache stats can be reported and its recording
+// saved
+type clientStack struct {
+	origin   ec2.Origin
+	recorder *ec2.RecordingClient
+	limiter  *ec2.RateLimitedClient
+	cache    *ec2.CachedClient
+}
+
+// newClientStack builds the EC2 client for cfg: traced per API call and
+// throttled client-side so we stay clear of RequestLimitExceeded when other
+// tooling shares the account, with slow-changing describe results cached in
+// front of the limiter so cache hits don't spend tokens. When recording, the
+// recorder sits directly on the SDK client so the cassette holds exactly the
+// calls EC2 saw.
+func newClientStack(origin ec2.Origin, cfg aws.Config, sc stackConfig) *clientStack {
+	stack := &clientStack{origin: origin}
+	var client ec2.EC2ClientAPI = awsec2.NewFromConfig(cfg)
+	if sc.record {
+		stack.recorder = ec2.NewRecordingClient(client)
+		client = stack.recorder
+	}
+	stack.limiter = ec2.NewRateLimitedClient(ec2.NewTracingClient(client, nil), sc.rateLimit)
+	stack.cache = ec2.NewCachedClient(stack.limiter, ec2.DefaultCacheConfig())
+	return stack
+}
+
+func (s *clientStack) logStats() {
+	for bucket, stats := range s.limiter.Stats() {
+		slog.Debug("Rate limiter stats", "origin", s.origin, "bucket", bucket, "calls", stats.Calls,
+			"throttled", stats.Throttled, "total_wait", stats.TotalWait, "max_wait", stats.MaxWait)
+	}
+	for operation, stats := range s.cache.Stats() {
+		slog.Debug("Cache stats", "origin", s.origin, "operation", operation, "hits", stats.Hits,
+			"misses", stats.Misses, "invalidations", stats.Invalidations)
+	}
+}
+
+// loadRegistry registers a manager for every account and region. With no
+// regions given, each account uses the region from its own configuration.
+func loadRegistry(ctx context.Context, accounts []account, regions []string, sc stackConfig, opts []ec2.Option) (*ec2.Registry, []*clientStack, error) {
+	registry := ec2.NewRegistry()
+	var stacks []*clientStack
+
+	for _, acct := range accounts {
+		var loadOpts []func(*config.LoadOptions) error
+		if acct.profile != "" {
+			loadOpts = append(loadOpts, config.WithSharedConfigProfile(acct.profile))
+		}
+		cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", acct.label, err)
+		}
+		if acct.roleARN != "" {
+			provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), acct.roleARN, func(o *stscreds.AssumeRoleOptions) {
+				o.RoleSessionName = "eni-manager"
+			})
+			cfg.Credentials = aws.NewCredentialsCache(provider)
+		}
+
+		acctRegions := regions
+		if len(acctRegions) == 0 {
+			acctRegions = []string{cfg.Region}
+		}
+		for _, region := range acctRegions {
+			regionCfg := cfg.Copy()
+			regionCfg.Region = region
+
+			origin := ec2.Origin{Account: acct.label, Region: region}
+			stack := newClientStack(origin, regionCfg, sc)
+			stacks = append(stacks, stack)
+			managerOpts := append(slices.Clip(opts), ec2.WithLogger(slog.Default().With("origin", origin.String())))
+			registry.Register(origin, ec2.NewENIManager(stack.cache, managerOpts...))
+		}
+	}
+	return registry, stacks, nil
+}
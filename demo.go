@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"eni-project/internal/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"go.opentelemetry.io/otel"
+)
+
+// runDemo walks an ENI through its full lifecycle: create, attach, assign
+// IPs, describe, modify, detach and delete.
+func runDemo(eniManager *ec2.ENIManager, cfg settings) {
+	// Create context with timeout
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	// Trace the whole run so each operation nests under one root span,
+	// ended at exit so a failed step still exports it
+	ctx, span := otel.Tracer("eni-project").Start(ctx, "demo")
+	atExit(func() { span.End() })
+
+	// Nothing changes in a dry run, so there is nothing to wait for
+	delay := cfg.Wait
+	if eniManager.DryRun() != ec2.DryRunOff {
+		delay = 0
+	}
+
+	subnetID := cfg.SubnetID
+	instanceID := cfg.InstanceID
+
+	tags := map[string]string{ec2.ManagedByTagKey: ec2.ManagedByTagValue}
+	for k, v := range cfg.Tags {
+		tags[k] = v
+	}
+
+	// Example: Create an ENI
+	eniConfig := ec2.ENIConfig{
+		SubnetID:         subnetID,
+		Description:      "Example ENI",
+		SecurityGroupIDs: cfg.SecurityGroupIDs,
+		PrivateIPCount:   cfg.PrivateIPCount,
+		IPv6AddressCount: cfg.IPv6AddressCount,
+		Tags:             tags,
+	}
+
+	slog.Info("Creating ENI", "subnet_id", subnetID)
+	eni, err := eniManager.CreateENI(ctx, eniConfig)
+	if err != nil {
+		fatal("Failed to create ENI", err)
+	}
+	slog.Info("Created ENI", "eni_id", *eni.NetworkInterface.NetworkInterfaceId)
+
+	// Example: Attach ENI to an instance
+	slog.Info("Attaching ENI", "eni_id", *eni.NetworkInterface.NetworkInterfaceId, "instance_id", instanceID)
+	attachID, err := eniManager.AttachENI(ctx, *eni.NetworkInterface.NetworkInterfaceId, instanceID, 1)
+	if err != nil {
+		fatal("Failed to attach ENI", err)
+	}
+	slog.Info("Attached ENI", "attachment_id", *attachID)
+
+	// Example: Assign additional private IPs
+	slog.Info("Assigning additional private IPs")
+	err = eniManager.AssignPrivateIPs(ctx, *eni.NetworkInterface.NetworkInterfaceId, 2, nil)
+	if err != nil {
+		slog.Error("Failed to assign private IPs", "error", err)
+	}
+
+	// Example: Describe ENIs in the subnet
+	slog.Info("Describing ENIs")
+	filters := []types.Filter{
+		{
+			Name:   aws.String("subnet-id"),
+			Values: []string{eniConfig.SubnetID},
+		},
+	}
+
+	enis, err := eniManager.DescribeENIs(ctx, filters)
+	if err != nil {
+		slog.Error("Failed to describe ENIs", "error", err)
+	} else {
+		for _, eni := range enis.NetworkInterfaces {
+			slog.Info("Found ENI", "eni_id", *eni.NetworkInterfaceId, "status", eni.Status)
+		}
+	}
+
+	// Example: Modify ENI attributes
+	slog.Info("Modifying ENI attributes")
+	modifyConfig := ec2.ENIModifyConfig{
+		Description: aws.String("Updated description"),
+	}
+	err = eniManager.ModifyENIAttribute(ctx, *eni.NetworkInterface.NetworkInterfaceId, modifyConfig)
+	if err != nil {
+		slog.Error("Failed to modify ENI", "error", err)
+	}
+
+	// Wait before cleanup
+	slog.Info("Waiting before cleanup", "delay", delay)
+	time.Sleep(delay)
+
+	// Example: Detach ENI
+	slog.Info("Detaching ENI", "attachment_id", *attachID)
+	err = eniManager.DetachENI(ctx, *attachID, true)
+	if err != nil {
+		fatal("Failed to detach ENI", err)
+	}
+
+	// Wait for detachment to complete
+	slog.Info("Waiting for detachment to complete", "delay", delay)
+	time.Sleep(delay)
+
+	// Example: Delete ENI
+	slog.Info("Deleting ENI", "eni_id", *eni.NetworkInterface.NetworkInterfaceId)
+	err = eniManager.DeleteENI(ctx, *eni.NetworkInterface.NetworkInterfaceId)
+	if err != nil {
+		fatal("Failed to delete ENI", err)
+	}
+
+	slog.Info("ENI management operations completed successfully")
+}
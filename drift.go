@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"eni-project/internal/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// runDrift implements the drift subcommands: record, which writes the
+// current configuration of ENIs as a spec file, and check, which compares
+// live ENIs with a spec file and optionally remediates the drift
+func runDrift(args []string, eniManager *ec2.ENIManager, timeout time.Duration) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: drift record|check")
+		exit(2)
+	}
+	command, args := args[0], args[1:]
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	switch command {
+	case "record":
+		fs := flag.NewFlagSet("drift record", flag.ExitOnError)
+		managed := fs.Bool("managed", false, "record every ENI managed by this tool")
+		fs.Parse(args)
+		if !*managed && fs.NArg() == 0 {
+			fmt.Fprintln(os.Stderr, "usage: drift record -managed | ENI_ID...")
+			exit(2)
+		}
+
+		var filters []types.Filter
+		if *managed {
+			filters = ec2.ManagedENIFilters()
+		} else {
+			filters = []types.Filter{{Name: aws.String("network-interface-id"), Values: fs.Args()}}
+		}
+		output, err := eniManager.DescribeENIs(ctx, filters)
+		if err != nil {
+			fatal("Failed to describe ENIs", err)
+		}
+		var specs []ec2.ENISpec
+		for _, eni := range output.NetworkInterfaces {
+			specs = append(specs, ec2.RecordENISpec(eni))
+		}
+		if err := ec2.WriteENISpecs(os.Stdout, specs); err != nil {
+			fatal("Failed to write ENI specs", err)
+		}
+
+	case "check":
+		fs := flag.NewFlagSet("drift check", flag.ExitOnError)
+		asJSON := fs.Bool("json", false, "print the drift report as JSON")
+		remediate := fs.Bool("remediate", false, "bring drifted ENIs back to their spec")
+		fs.Parse(args)
+		if fs.NArg() != 1 {
+			fmt.Fprintln(os.Stderr, "usage: drift check [-json] [-remediate] FILE")
+			exit(2)
+		}
+
+		specs, err := ec2.LoadENISpecs(fs.Arg(0))
+		if err != nil {
+			fatal("Failed to load ENI specs", err)
+		}
+		report, err := eniManager.DetectDrift(ctx, specs)
+		if err != nil {
+			fatal("Failed to detect drift", err)
+		}
+		if *asJSON {
+			json.NewEncoder(os.Stdout).Encode(report)
+		} else {
+			printDrift(report)
+		}
+
+		if *remediate && len(report.Drifts) > 0 {
+			fixed, err := eniManager.RemediateDrift(ctx, report)
+			slog.Info("Remediated drift", "fixed", len(fixed), "remaining", len(report.Drifts)-len(fixed))
+			if err != nil {
+				fatal("Failed to remediate some drift", err)
+			}
+			if len(fixed) == len(report.Drifts) {
+				return
+			}
+		}
+		if len(report.Drifts) > 0 {
+			exit(1)
+		}
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown drift command %q (want record or check)\n", command)
+		exit(2)
+	}
+}
+
+func printDrift(report *ec2.DriftReport) {
+	if len(report.Drifts) == 0 {
+		fmt.Printf("No drift in %d ENIs\n", report.Checked)
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ENI\tFIELD\tEXPECTED\tACTUAL\tREMEDIABLE")
+	for _, d := range report.Drifts {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", d.ENIID, d.Field, d.Expected, d.Actual, d.Remediable)
+	}
+	w.Flush()
+}
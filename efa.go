@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"eni-project/internal/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// runEFA implements the efa subcommands: status, attach and provision
+func runEFA(args []string, eniManager *ec2.ENIManager, cfg settings) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: efa status|attach|provision")
+		exit(2)
+	}
+	command, args := args[0], args[1:]
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	switch command {
+	case "status":
+		fs := flag.NewFlagSet("efa status", flag.ExitOnError)
+		instanceID := fs.String("instance", cfg.InstanceID, "instance to report on")
+		fs.Parse(args)
+
+		capacity, err := eniManager.EFACapacity(ctx, *instanceID)
+		if err != nil {
+			fatal("Failed to determine EFA capacity", err)
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "CARD\tEFA")
+		for card := int32(0); card < capacity.NetworkCards; card++ {
+			fmt.Fprintf(w, "%d\t%s\n", card, strings.Join(capacity.EFAs[card], ","))
+		}
+		w.Flush()
+		slog.Info("EFA capacity", "instance_id", capacity.InstanceID, "instance_type", capacity.InstanceType,
+			"network_cards", capacity.NetworkCards, "used", capacity.Used(), "limit", capacity.Limit)
+
+	case "attach":
+		fs := flag.NewFlagSet("efa attach", flag.ExitOnError)
+		eniID := fs.String("eni", "", "EFA ENI to attach (required)")
+		instanceID := fs.String("instance", cfg.InstanceID, "instance to attach to")
+		deviceIndex := fs.Int("device-index", 1, "device index on the network card")
+		card := fs.Int("card", 0, "network card index")
+		fs.Parse(args)
+		if *eniID == "" {
+			fmt.Fprintln(os.Stderr, "efa attach: -eni is required")
+			exit(2)
+		}
+
+		attachmentID, err := eniManager.AttachEFA(ctx, *eniID, *instanceID, int32(*deviceIndex), int32(*card))
+		if err != nil {
+			fatal("Failed to attach EFA", err)
+		}
+		fmt.Println(aws.ToString(attachmentID))
+
+	case "provision":
+		fs := flag.NewFlagSet("efa provision", flag.ExitOnError)
+		instanceID := fs.String("instance", cfg.InstanceID, "instance to provision EFAs on")
+		subnetID := fs.String("subnet", cfg.SubnetID, "subnet to create the EFAs in; must be in the instance's AZ")
+		securityGroups := fs.String("security-groups", strings.Join(cfg.SecurityGroupIDs, ","), "comma-separated security groups; must allow all traffic between members")
+		interfaceType := fs.String("type", string(types.NetworkInterfaceCreationTypeEfa), "efa or efa-only")
+		deviceIndex := fs.Int("device-index", 1, "device index to attach each EFA at on its card")
+		fs.Parse(args)
+
+		config := ec2.ENIConfig{
+			SubnetID:         *subnetID,
+			Description:      *interfaceType + " ENI",
+			SecurityGroupIDs: splitList(*securityGroups),
+			Tags:             map[string]string{ec2.ManagedByTagKey: ec2.ManagedByTagValue},
+			InterfaceType:    types.NetworkInterfaceCreationType(*interfaceType),
+		}
+		if err := config.Validate(); err != nil {
+			fatal("Invalid EFA configuration", err)
+		}
+
+		attachments, err := eniManager.ProvisionEFAs(ctx, *instanceID, config, int32(*deviceIndex))
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "CARD\tENI\tATTACHMENT")
+		for _, a := range attachments {
+			fmt.Fprintf(w, "%d\t%s\t%s\n", a.NetworkCardIndex, a.ENIID, a.AttachmentID)
+		}
+		w.Flush()
+		if err != nil {
+			fatal("Failed to provision EFAs", err)
+		}
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown efa command %q (want status, attach or provision)\n", command)
+		exit(2)
+	}
+}
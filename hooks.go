@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"eni-project/internal/ec2"
+	"gopkg.in/yaml.v3"
+)
+
+// hookFile is the format of the --hooks file
+type hookFile struct {
+	Hooks []hookSpec `yaml:"hooks"`
+}
+
+// hookSpec configures one hook in the --hooks file
+type hookSpec struct {
+	Name       string        `yaml:"name"`
+	Phase      ec2.HookPhase `yaml:"phase"`
+	Operations []string      `yaml:"operations"`
+	// Exactly one of URL or Command is set
+	URL string `yaml:"url"`
+	// SecretEnv names the environment variable holding the HMAC secret
+	SecretEnv  string   `yaml:"secret_env"`
+	MaxRetries *int     `yaml:"max_retries"`
+	Command    []string `yaml:"command"`
+	Timeout    string   `yaml:"timeout"`
+}
+
+// loadHooks reads the hook rules configured in path
+func loadHooks(path string) ([]ec2.HookRule, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file hookFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	// An empty file configures no hooks
+	if err := dec.Decode(&file); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid hooks file %s: %w", path, err)
+	}
+
+	var rules []ec2.HookRule
+	for i, h := range file.Hooks {
+		name := h.Name
+		if name == "" {
+			name = fmt.Sprintf("hook-%d", i)
+		}
+		if h.Phase != "" && h.Phase != ec2.HookPre && h.Phase != ec2.HookPost {
+			return nil, fmt.Errorf("hook %s: invalid phase %q (want pre or post)", name, h.Phase)
+		}
+
+		var timeout time.Duration
+		if h.Timeout != "" {
+			if timeout, err = time.ParseDuration(h.Timeout); err != nil {
+				return nil, fmt.Errorf("hook %s: invalid timeout: %w", name, err)
+			}
+		}
+
+		var hook ec2.Hook
+		switch {
+		case h.URL != "" && len(h.Command) == 0:
+			webhook := ec2.NewWebhookHook(h.URL, os.Getenv(h.SecretEnv))
+			if h.MaxRetries != nil {
+				webhook.MaxRetries = *h.MaxRetries
+			}
+			if timeout > 0 {
+				webhook.Client.Timeout = timeout
+			}
+			hook = webhook
+		case len(h.Command) > 0 && h.URL == "":
+			hook = &ec2.CommandHook{Path: h.Command[0], Args: h.Command[1:], Timeout: timeout}
+		default:
+			return nil, fmt.Errorf("hook %s: exactly one of url or command is required", name)
+		}
+
+		rules = append(rules, ec2.HookRule{Name: name, Phase: h.Phase, Operations: h.Operations, Hook: hook})
+	}
+	return rules, nil
+}
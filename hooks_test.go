@@ -0,0 +1,36 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"eni-project/internal/ec2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadHooks(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+
+	rules, err := loadHooks(write("hooks.yaml", "hooks:\n  - name: check\n    phase: pre\n    command: [/bin/true]\n    timeout: 5s\n"))
+	assert.NoError(t, err)
+	if assert.Len(t, rules, 1) {
+		assert.Equal(t, "check", rules[0].Name)
+		assert.Equal(t, ec2.HookPre, rules[0].Phase)
+	}
+
+	// Empty and comment-only files configure no hooks
+	for _, content := range []string{"", "# no hooks yet\n"} {
+		rules, err = loadHooks(write("empty.yaml", content))
+		assert.NoError(t, err)
+		assert.Empty(t, rules)
+	}
+
+	_, err = loadHooks(write("typo.yaml", "hooks:\n  - comand: [/bin/true]\n"))
+	assert.ErrorContains(t, err, "field comand not found")
+}
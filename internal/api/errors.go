@@ -0,0 +1,72 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"eni-project/internal/ec2"
+)
+
+// CodeValidation is the error code for requests rejected before reaching AWS
+const CodeValidation = "ValidationError"
+
+// validationError marks a request the API rejected itself
+type validationError struct {
+	err error
+}
+
+func (e validationError) Error() string { return e.err.Error() }
+
+// statusFor maps an AWS error code to the HTTP status returned to callers
+func statusFor(code string) int {
+	switch {
+	case code == CodeValidation:
+		return http.StatusBadRequest
+	case strings.HasSuffix(code, ".NotFound"):
+		return http.StatusNotFound
+	case strings.HasSuffix(code, ".Malformed"),
+		strings.HasPrefix(code, "InvalidParameter"),
+		strings.HasPrefix(code, "MissingParameter"):
+		return http.StatusBadRequest
+	case code == "UnauthorizedOperation", code == "AuthFailure", code == ec2.CodeHookVetoed,
+		code == ec2.CodePolicyViolation:
+		return http.StatusForbidden
+	case code == "RequestLimitExceeded", code == "Throttling":
+		return http.StatusTooManyRequests
+	case code == "DependencyViolation",
+		code == "IncorrectState",
+		code == "InvalidNetworkInterface.InUse",
+		strings.HasSuffix(code, "LimitExceeded"),
+		code == "InsufficientFreeAddressesInSubnet":
+		return http.StatusConflict
+	case code == "DeadlineExceeded":
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusBadGateway
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	detail := ErrorDetail{
+		Code:      ec2.ErrorCode(err),
+		Message:   err.Error(),
+		RequestID: ec2.RequestID(nil, err),
+	}
+	var verr validationError
+	if errors.As(err, &verr) {
+		detail.Code = CodeValidation
+	}
+	writeJSON(w, statusFor(detail.Code), ErrorResponse{Error: detail})
+}
+
+func notFound(w http.ResponseWriter, code, message string) {
+	writeJSON(w, http.StatusNotFound, ErrorResponse{Error: ErrorDetail{Code: code, Message: message}})
+}
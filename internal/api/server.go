@@ -0,0 +1,422 @@
+// Package api exposes ENIManager over a versioned REST/JSON API so callers
+// can manage ENIs without AWS credentials of their own.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"eni-project/internal/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// maxBodyBytes bounds request bodies; ours are all small
+const maxBodyBytes = 1 << 20
+
+// Server serves the v1 API backed by an ENIManager
+type Server struct {
+	manager *ec2.ENIManager
+	logger  *slog.Logger
+	mux     *http.ServeMux
+}
+
+func NewServer(manager *ec2.ENIManager, logger *slog.Logger) *Server {
+	s := &Server{manager: manager, logger: logger, mux: http.NewServeMux()}
+	for _, rt := range s.routes() {
+		s.mux.HandleFunc(rt.method+" "+rt.path, rt.handler)
+	}
+	s.mux.HandleFunc("GET /v1/openapi.json", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, s.OpenAPI())
+	})
+	s.mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	return s
+}
+
+// Handle mounts an additional handler, e.g. /metrics, on the server
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+	s.mux.ServeHTTP(rec, r.WithContext(ec2.WithCaller(r.Context(), "rest:"+remoteHost(r))))
+	s.logger.Info("request served",
+		"method", r.Method,
+		"path", r.URL.Path,
+		"status", rec.status,
+		"duration", time.Since(start))
+}
+
+// remoteHost returns the client address without its port
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// route describes one endpoint; the request and response fields are zero
+// values of the body types and feed the generated OpenAPI document
+type route struct {
+	method   string
+	path     string
+	summary  string
+	query    []string
+	request  any
+	response any
+	status   int
+	handler  http.HandlerFunc
+}
+
+func (s *Server) routes() []route {
+	return []route{
+		{
+			method: "POST", path: "/v1/enis", summary: "Create an ENI",
+			request: CreateENIRequest{}, response: ENI{}, status: http.StatusCreated,
+			handler: s.createENI,
+		},
+		{
+			method: "GET", path: "/v1/enis", summary: "List ENIs; each query parameter is an EC2 filter (e.g. subnet-id, tag:Name) with comma-separated values",
+			query: []string{"subnet-id", "vpc-id", "status", "attachment.instance-id"}, response: ENIList{}, status: http.StatusOK,
+			handler: s.listENIs,
+		},
+		{
+			method: "GET", path: "/v1/enis/{eni_id}", summary: "Describe an ENI",
+			response: ENI{}, status: http.StatusOK,
+			handler: s.getENI,
+		},
+		{
+			method: "PATCH", path: "/v1/enis/{eni_id}", summary: "Modify an ENI's description or security groups",
+			request: ModifyENIRequest{}, status: http.StatusNoContent,
+			handler: s.modifyENI,
+		},
+		{
+			method: "DELETE", path: "/v1/enis/{eni_id}", summary: "Delete an ENI",
+			status:  http.StatusNoContent,
+			handler: s.deleteENI,
+		},
+		{
+			method: "POST", path: "/v1/enis/{eni_id}/attachments", summary: "Attach an ENI to an instance",
+			request: AttachENIRequest{}, response: AttachENIResponse{}, status: http.StatusCreated,
+			handler: s.attachENI,
+		},
+		{
+			method: "DELETE", path: "/v1/attachments/{attachment_id}", summary: "Detach an ENI; pass force=true to force detachment",
+			query: []string{"force"}, status: http.StatusNoContent,
+			handler: s.detachENI,
+		},
+		{
+			method: "POST", path: "/v1/enis/{eni_id}/private-ips", summary: "Assign secondary private IPv4 addresses",
+			request: AssignIPsRequest{}, status: http.StatusNoContent,
+			handler: s.assignPrivateIPs,
+		},
+		{
+			method: "POST", path: "/v1/enis/{eni_id}/private-ips/unassign", summary: "Unassign secondary private IPv4 addresses",
+			request: UnassignIPsRequest{}, status: http.StatusNoContent,
+			handler: s.unassignPrivateIPs,
+		},
+		{
+			method: "POST", path: "/v1/enis/{eni_id}/ipv6-addresses", summary: "Assign IPv6 addresses",
+			request: AssignIPsRequest{}, status: http.StatusNoContent,
+			handler: s.assignIPv6Addresses,
+		},
+		{
+			method: "POST", path: "/v1/enis/{eni_id}/ipv6-addresses/unassign", summary: "Unassign IPv6 addresses",
+			request: UnassignIPsRequest{}, status: http.StatusNoContent,
+			handler: s.unassignIPv6Addresses,
+		},
+		{
+			method: "GET", path: "/v1/subnets/{subnet_id}", summary: "Describe a subnet",
+			response: Subnet{}, status: http.StatusOK,
+			handler: s.getSubnet,
+		},
+	}
+}
+
+// decode reads a JSON body into v, rejecting unknown fields
+func decode(r *http.Request, v any) error {
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return validationError{fmt.Errorf("invalid request body: %w", err)}
+	}
+	return nil
+}
+
+// pathID returns the named path parameter after checking its prefix
+func pathID(r *http.Request, name, prefix string) (string, error) {
+	id := r.PathValue(name)
+	if err := ec2.ValidateID(name, id, prefix); err != nil {
+		return "", validationError{err}
+	}
+	return id, nil
+}
+
+func (s *Server) createENI(w http.ResponseWriter, r *http.Request) {
+	var req CreateENIRequest
+	if err := decode(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := req.config().Validate(); err != nil {
+		writeError(w, validationError{err})
+		return
+	}
+
+	output, err := s.manager.CreateENI(r.Context(), req.config())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, newENI(*output.NetworkInterface))
+}
+
+func (s *Server) listENIs(w http.ResponseWriter, r *http.Request) {
+	var filters []types.Filter
+	for name, values := range r.URL.Query() {
+		var split []string
+		for _, v := range values {
+			split = append(split, strings.Split(v, ",")...)
+		}
+		filters = append(filters, types.Filter{Name: aws.String(name), Values: split})
+	}
+
+	output, err := s.manager.DescribeENIs(r.Context(), filters)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	list := ENIList{ENIs: []ENI{}}
+	for _, eni := range output.NetworkInterfaces {
+		list.ENIs = append(list.ENIs, newENI(eni))
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func (s *Server) getENI(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "eni_id", "eni-")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	output, err := s.manager.DescribeENIs(r.Context(), []types.Filter{
+		{Name: aws.String("network-interface-id"), Values: []string{id}},
+	})
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if len(output.NetworkInterfaces) == 0 {
+		notFound(w, "InvalidNetworkInterfaceID.NotFound", fmt.Sprintf("ENI %s does not exist", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, newENI(output.NetworkInterfaces[0]))
+}
+
+func (s *Server) modifyENI(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "eni_id", "eni-")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req ModifyENIRequest
+	if err := decode(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := req.config().Validate(); err != nil {
+		writeError(w, validationError{err})
+		return
+	}
+
+	err = s.manager.ModifyENIAttribute(r.Context(), id, req.config())
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) deleteENI(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "eni_id", "eni-")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	if err := s.manager.DeleteENI(r.Context(), id); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) attachENI(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "eni_id", "eni-")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req AttachENIRequest
+	if err := decode(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := req.validate(); err != nil {
+		writeError(w, validationError{err})
+		return
+	}
+
+	var attachmentID *string
+	if req.NetworkCardIndex != nil {
+		attachmentID, err = s.manager.AttachENIToCard(r.Context(), id, req.InstanceID, req.DeviceIndex, *req.NetworkCardIndex)
+	} else {
+		attachmentID, err = s.manager.AttachENI(r.Context(), id, req.InstanceID, req.DeviceIndex)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, AttachENIResponse{AttachmentID: aws.ToString(attachmentID)})
+}
+
+func (s *Server) detachENI(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "attachment_id", "eni-attach-")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	force := false
+	if v := r.URL.Query().Get("force"); v != "" {
+		if force, err = strconv.ParseBool(v); err != nil {
+			writeError(w, validationError{errors.New("force must be true or false")})
+			return
+		}
+	}
+
+	if err := s.manager.DetachENI(r.Context(), id, force); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) assignPrivateIPs(w http.ResponseWriter, r *http.Request) {
+	s.assignIPs(w, r, false)
+}
+
+func (s *Server) assignIPv6Addresses(w http.ResponseWriter, r *http.Request) {
+	s.assignIPs(w, r, true)
+}
+
+func (s *Server) assignIPs(w http.ResponseWriter, r *http.Request, ipv6 bool) {
+	id, err := pathID(r, "eni_id", "eni-")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req AssignIPsRequest
+	if err := decode(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := req.validate(ipv6); err != nil {
+		writeError(w, validationError{err})
+		return
+	}
+
+	if ipv6 {
+		var count *int32
+		if req.Count > 0 {
+			count = aws.Int32(req.Count)
+		}
+		err = s.manager.AssignIPv6Addresses(r.Context(), id, req.Addresses, count)
+	} else {
+		err = s.manager.AssignPrivateIPs(r.Context(), id, req.Count, req.Addresses)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) unassignPrivateIPs(w http.ResponseWriter, r *http.Request) {
+	s.unassignIPs(w, r, false)
+}
+
+func (s *Server) unassignIPv6Addresses(w http.ResponseWriter, r *http.Request) {
+	s.unassignIPs(w, r, true)
+}
+
+func (s *Server) unassignIPs(w http.ResponseWriter, r *http.Request, ipv6 bool) {
+	id, err := pathID(r, "eni_id", "eni-")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var req UnassignIPsRequest
+	if err := decode(r, &req); err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := req.validate(ipv6); err != nil {
+		writeError(w, validationError{err})
+		return
+	}
+
+	if ipv6 {
+		err = s.manager.UnassignIPv6Addresses(r.Context(), id, req.Addresses)
+	} else {
+		err = s.manager.UnassignPrivateIPs(r.Context(), id, req.Addresses)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) getSubnet(w http.ResponseWriter, r *http.Request) {
+	id, err := pathID(r, "subnet_id", "subnet-")
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	output, err := s.manager.DescribeSubnet(r.Context(), id)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if len(output.Subnets) == 0 {
+		notFound(w, "InvalidSubnetID.NotFound", fmt.Sprintf("subnet %s does not exist", id))
+		return
+	}
+	writeJSON(w, http.StatusOK, newSubnet(output.Subnets[0]))
+}
@@ -0,0 +1,178 @@
+// internal/api/server_test.go
+package api
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"eni-project/internal/ec2"
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T) (*mocks.MockEC2ClientAPI, *httptest.Server) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	server := httptest.NewServer(NewServer(ec2.NewENIManager(mockClient), logger))
+	t.Cleanup(server.Close)
+	return mockClient, server
+}
+
+func do(t *testing.T, method, url, body string) (*http.Response, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	assert.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	return resp, data
+}
+
+func TestServer_CreateENI(t *testing.T) {
+	mockClient, server := newTestServer(t)
+
+	mockClient.EXPECT().
+		CreateNetworkInterface(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ any, input *awsec2.CreateNetworkInterfaceInput, _ ...func(*awsec2.Options)) (*awsec2.CreateNetworkInterfaceOutput, error) {
+			assert.Equal(t, "subnet-12345678", *input.SubnetId)
+			assert.Equal(t, []string{"sg-12345678"}, input.Groups)
+			return &awsec2.CreateNetworkInterfaceOutput{
+				NetworkInterface: &types.NetworkInterface{
+					NetworkInterfaceId: aws.String("eni-12345678"),
+					SubnetId:           input.SubnetId,
+					Status:             types.NetworkInterfaceStatusAvailable,
+					TagSet:             []types.Tag{{Key: aws.String("Name"), Value: aws.String("api")}},
+				},
+			}, nil
+		})
+
+	resp, body := do(t, "POST", server.URL+"/v1/enis",
+		`{"subnet_id":"subnet-12345678","security_group_ids":["sg-12345678"],"tags":{"Name":"api"}}`)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var eni ENI
+	assert.NoError(t, json.Unmarshal(body, &eni))
+	assert.Equal(t, "eni-12345678", eni.ID)
+	assert.Equal(t, "available", eni.Status)
+	assert.Equal(t, map[string]string{"Name": "api"}, eni.Tags)
+}
+
+func TestServer_ValidationError(t *testing.T) {
+	_, server := newTestServer(t)
+
+	tests := []struct {
+		name, method, path, body string
+	}{
+		{"missing subnet", "POST", "/v1/enis", `{"description":"x"}`},
+		{"unknown field", "POST", "/v1/enis", `{"subnet_id":"subnet-1","bogus":true}`},
+		{"bad eni id", "DELETE", "/v1/enis/i-12345678", ``},
+		{"primary device index", "POST", "/v1/enis/eni-12345678/attachments", `{"instance_id":"i-12345678","device_index":0}`},
+		{"negative network card", "POST", "/v1/enis/eni-12345678/attachments", `{"instance_id":"i-12345678","device_index":1,"network_card_index":-1}`},
+		{"ipv4 on ipv6 endpoint", "POST", "/v1/enis/eni-12345678/ipv6-addresses", `{"addresses":["10.0.0.1"]}`},
+		{"count and addresses", "POST", "/v1/enis/eni-12345678/private-ips", `{"count":1,"addresses":["10.0.0.1"]}`},
+		{"bad force", "DELETE", "/v1/attachments/eni-attach-12345678?force=maybe", ``},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, body := do(t, tt.method, server.URL+tt.path, tt.body)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+			var errResp ErrorResponse
+			assert.NoError(t, json.Unmarshal(body, &errResp))
+			assert.Equal(t, CodeValidation, errResp.Error.Code)
+		})
+	}
+}
+
+func TestServer_AWSErrorCodes(t *testing.T) {
+	mockClient, server := newTestServer(t)
+
+	mockClient.EXPECT().
+		DeleteNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(nil, &smithy.GenericAPIError{Code: "InvalidNetworkInterfaceID.NotFound", Message: "not found"})
+	mockClient.EXPECT().
+		DetachNetworkInterface(gomock.Any(), gomock.Eq(&awsec2.DetachNetworkInterfaceInput{
+			AttachmentId: aws.String("eni-attach-12345678"),
+			Force:        aws.Bool(true),
+		})).
+		Return(nil, &smithy.GenericAPIError{Code: "UnauthorizedOperation"})
+
+	resp, body := do(t, "DELETE", server.URL+"/v1/enis/eni-12345678", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	var errResp ErrorResponse
+	assert.NoError(t, json.Unmarshal(body, &errResp))
+	assert.Equal(t, "InvalidNetworkInterfaceID.NotFound", errResp.Error.Code)
+
+	resp, _ = do(t, "DELETE", server.URL+"/v1/attachments/eni-attach-12345678?force=true", "")
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestServer_ListENIs(t *testing.T) {
+	mockClient, server := newTestServer(t)
+
+	mockClient.EXPECT().
+		DescribeNetworkInterfaces(gomock.Any(), gomock.Eq(&awsec2.DescribeNetworkInterfacesInput{
+			Filters: []types.Filter{
+				{Name: aws.String("subnet-id"), Values: []string{"subnet-1", "subnet-2"}},
+			},
+		})).
+		Return(&awsec2.DescribeNetworkInterfacesOutput{
+			NetworkInterfaces: []types.NetworkInterface{
+				{NetworkInterfaceId: aws.String("eni-12345678")},
+			},
+		}, nil)
+
+	resp, body := do(t, "GET", server.URL+"/v1/enis?subnet-id=subnet-1,subnet-2", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var list ENIList
+	assert.NoError(t, json.Unmarshal(body, &list))
+	assert.Len(t, list.ENIs, 1)
+	assert.Equal(t, "eni-12345678", list.ENIs[0].ID)
+}
+
+func TestServer_GetSubnetNotFound(t *testing.T) {
+	mockClient, server := newTestServer(t)
+
+	mockClient.EXPECT().
+		DescribeSubnets(gomock.Any(), gomock.Any()).
+		Return(&awsec2.DescribeSubnetsOutput{}, nil)
+
+	resp, _ := do(t, "GET", server.URL+"/v1/subnets/subnet-12345678", "")
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestServer_OpenAPI(t *testing.T) {
+	_, server := newTestServer(t)
+
+	resp, body := do(t, "GET", server.URL+"/v1/openapi.json", "")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var doc struct {
+		OpenAPI string                               `json:"openapi"`
+		Paths   map[string]map[string]map[string]any `json:"paths"`
+	}
+	assert.NoError(t, json.Unmarshal(body, &doc))
+	assert.Equal(t, "3.0.3", doc.OpenAPI)
+	assert.Equal(t, "createENI", doc.Paths["/v1/enis"]["post"]["operationId"])
+	assert.Contains(t, doc.Paths["/v1/enis/{eni_id}"], "patch")
+	assert.Contains(t, doc.Paths["/v1/attachments/{attachment_id}"], "delete")
+}
@@ -0,0 +1,203 @@
+package api
+
+import (
+	"fmt"
+
+	"eni-project/internal/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// CreateENIRequest is the body of POST /v1/enis
+type CreateENIRequest struct {
+	SubnetID         string            `json:"subnet_id"`
+	Description      string            `json:"description,omitempty"`
+	SecurityGroupIDs []string          `json:"security_group_ids,omitempty"`
+	PrivateIPCount   int32             `json:"private_ip_count,omitempty"`
+	IPv6AddressCount int32             `json:"ipv6_address_count,omitempty"`
+	Tags             map[string]string `json:"tags,omitempty"`
+	// InterfaceType is efa, efa-only, trunk or branch; empty for a standard ENI
+	InterfaceType string `json:"interface_type,omitempty"`
+}
+
+func (r CreateENIRequest) config() ec2.ENIConfig {
+	return ec2.ENIConfig{
+		SubnetID:         r.SubnetID,
+		Description:      r.Description,
+		SecurityGroupIDs: r.SecurityGroupIDs,
+		PrivateIPCount:   r.PrivateIPCount,
+		IPv6AddressCount: r.IPv6AddressCount,
+		Tags:             r.Tags,
+		InterfaceType:    types.NetworkInterfaceCreationType(r.InterfaceType),
+	}
+}
+
+// ModifyENIRequest is the body of PATCH /v1/enis/{eni_id}
+type ModifyENIRequest struct {
+	Description      *string  `json:"description,omitempty"`
+	SecurityGroupIDs []string `json:"security_group_ids,omitempty"`
+}
+
+func (r ModifyENIRequest) config() ec2.ENIModifyConfig {
+	return ec2.ENIModifyConfig{
+		Description:      r.Description,
+		SecurityGroupIDs: r.SecurityGroupIDs,
+	}
+}
+
+// AttachENIRequest is the body of POST /v1/enis/{eni_id}/attachments
+type AttachENIRequest struct {
+	InstanceID  string `json:"instance_id"`
+	DeviceIndex int32  `json:"device_index"`
+	// NetworkCardIndex selects the network card on multi-card instances
+	NetworkCardIndex *int32 `json:"network_card_index,omitempty"`
+}
+
+func (r AttachENIRequest) validate() error {
+	if err := ec2.ValidateID("instance_id", r.InstanceID, "i-"); err != nil {
+		return err
+	}
+	// Device 0 is always the instance's primary interface
+	if r.DeviceIndex < 1 {
+		return fmt.Errorf("device_index must be at least 1")
+	}
+	if r.NetworkCardIndex != nil && *r.NetworkCardIndex < 0 {
+		return fmt.Errorf("network_card_index must not be negative")
+	}
+	return nil
+}
+
+// AttachENIResponse is returned when an ENI is attached
+type AttachENIResponse struct {
+	AttachmentID string `json:"attachment_id"`
+}
+
+// AssignIPsRequest is the body of the private-ips and ipv6-addresses assign
+// endpoints. Exactly one of Count or Addresses must be set.
+type AssignIPsRequest struct {
+	Count     int32    `json:"count,omitempty"`
+	Addresses []string `json:"addresses,omitempty"`
+}
+
+func (r AssignIPsRequest) validate(ipv6 bool) error {
+	if (r.Count > 0) == (len(r.Addresses) > 0) {
+		return fmt.Errorf("exactly one of count or addresses is required")
+	}
+	if r.Count < 0 {
+		return fmt.Errorf("count must not be negative")
+	}
+	return ec2.ValidateAddresses(r.Addresses, ipv6)
+}
+
+// UnassignIPsRequest is the body of the private-ips and ipv6-addresses
+// unassign endpoints
+type UnassignIPsRequest struct {
+	Addresses []string `json:"addresses"`
+}
+
+func (r UnassignIPsRequest) validate(ipv6 bool) error {
+	if len(r.Addresses) == 0 {
+		return fmt.Errorf("addresses is required")
+	}
+	return ec2.ValidateAddresses(r.Addresses, ipv6)
+}
+
+// Attachment describes where an ENI is attached
+type Attachment struct {
+	ID          string `json:"id"`
+	InstanceID  string `json:"instance_id,omitempty"`
+	DeviceIndex int32  `json:"device_index"`
+	Status      string `json:"status"`
+}
+
+// ENI is the API representation of a network interface
+type ENI struct {
+	ID               string            `json:"id"`
+	SubnetID         string            `json:"subnet_id"`
+	VpcID            string            `json:"vpc_id"`
+	AvailabilityZone string            `json:"availability_zone"`
+	Description      string            `json:"description,omitempty"`
+	Status           string            `json:"status"`
+	InterfaceType    string            `json:"interface_type"`
+	PrivateIP        string            `json:"private_ip"`
+	PrivateIPs       []string          `json:"private_ips"`
+	IPv6Addresses    []string          `json:"ipv6_addresses"`
+	SecurityGroupIDs []string          `json:"security_group_ids"`
+	Tags             map[string]string `json:"tags"`
+	Attachment       *Attachment       `json:"attachment,omitempty"`
+}
+
+func newENI(eni types.NetworkInterface) ENI {
+	out := ENI{
+		ID:               aws.ToString(eni.NetworkInterfaceId),
+		SubnetID:         aws.ToString(eni.SubnetId),
+		VpcID:            aws.ToString(eni.VpcId),
+		AvailabilityZone: aws.ToString(eni.AvailabilityZone),
+		Description:      aws.ToString(eni.Description),
+		Status:           string(eni.Status),
+		InterfaceType:    string(eni.InterfaceType),
+		PrivateIP:        aws.ToString(eni.PrivateIpAddress),
+		PrivateIPs:       []string{},
+		IPv6Addresses:    []string{},
+		SecurityGroupIDs: []string{},
+		Tags:             map[string]string{},
+	}
+	for _, ip := range eni.PrivateIpAddresses {
+		out.PrivateIPs = append(out.PrivateIPs, aws.ToString(ip.PrivateIpAddress))
+	}
+	for _, ip := range eni.Ipv6Addresses {
+		out.IPv6Addresses = append(out.IPv6Addresses, aws.ToString(ip.Ipv6Address))
+	}
+	for _, group := range eni.Groups {
+		out.SecurityGroupIDs = append(out.SecurityGroupIDs, aws.ToString(group.GroupId))
+	}
+	for _, tag := range eni.TagSet {
+		out.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	if eni.Attachment != nil {
+		out.Attachment = &Attachment{
+			ID:          aws.ToString(eni.Attachment.AttachmentId),
+			InstanceID:  aws.ToString(eni.Attachment.InstanceId),
+			DeviceIndex: aws.ToInt32(eni.Attachment.DeviceIndex),
+			Status:      string(eni.Attachment.Status),
+		}
+	}
+	return out
+}
+
+// ENIList is returned by GET /v1/enis
+type ENIList struct {
+	ENIs []ENI `json:"enis"`
+}
+
+// Subnet is the API representation of a subnet
+type Subnet struct {
+	ID                      string `json:"id"`
+	VpcID                   string `json:"vpc_id"`
+	AvailabilityZone        string `json:"availability_zone"`
+	CidrBlock               string `json:"cidr_block"`
+	AvailableIPAddressCount int32  `json:"available_ip_address_count"`
+}
+
+func newSubnet(subnet types.Subnet) Subnet {
+	return Subnet{
+		ID:                      aws.ToString(subnet.SubnetId),
+		VpcID:                   aws.ToString(subnet.VpcId),
+		AvailabilityZone:        aws.ToString(subnet.AvailabilityZone),
+		CidrBlock:               aws.ToString(subnet.CidrBlock),
+		AvailableIPAddressCount: aws.ToInt32(subnet.AvailableIpAddressCount),
+	}
+}
+
+// ErrorResponse is the body of every non-2xx response
+type ErrorResponse struct {
+	Error ErrorDetail `json:"error"`
+}
+
+// ErrorDetail carries the AWS error code, or ValidationError for requests
+// rejected before reaching AWS
+type ErrorDetail struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	RequestID string `json:"request_id,omitempty"`
+}
@@ -0,0 +1,243 @@
+package ec2
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Audit outcomes
+const (
+	AuditSuccess = "success"
+	AuditFailure = "failure"
+)
+
+// AuditRecord is one line of the audit log. Hash is the SHA-256 of the
+// record's JSON with Hash empty, and PrevHash the Hash of the line before,
+// so editing, removing or reordering any line breaks the chain from there on.
+type AuditRecord struct {
+	Seq        int64             `json:"seq"`
+	Time       time.Time         `json:"time"`
+	Caller     string            `json:"caller"`
+	Operation  string            `json:"operation"`
+	Resources  map[string]string `json:"resources,omitempty"`
+	Input      json.RawMessage   `json:"input,omitempty"`
+	Outcome    string            `json:"outcome"`
+	ErrorCode  string            `json:"error_code,omitempty"`
+	Error      string            `json:"error,omitempty"`
+	RequestID  string            `json:"request_id,omitempty"`
+	DurationMS int64             `json:"duration_ms"`
+	PrevHash   string            `json:"prev_hash"`
+	Hash       string            `json:"hash"`
+}
+
+func (r AuditRecord) computeHash() (string, error) {
+	r.Hash = ""
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+type callerKey struct{}
+
+// WithCaller returns a context whose mutations are audited as caller
+func WithCaller(ctx context.Context, caller string) context.Context {
+	return context.WithValue(ctx, callerKey{}, caller)
+}
+
+// CallerFromContext returns the caller set by WithCaller, or ""
+func CallerFromContext(ctx context.Context) string {
+	caller, _ := ctx.Value(callerKey{}).(string)
+	return caller
+}
+
+// AuditLog appends hash-chained records to a JSON lines file
+type AuditLog struct {
+	// Caller is recorded for operations whose context carries no caller
+	Caller string
+
+	mu       sync.Mutex
+	file     *os.File
+	seq      int64
+	lastHash string
+}
+
+// OpenAuditLog opens path for appending, creating it if needed, and resumes
+// the hash chain from its last record
+func OpenAuditLog(path string) (*AuditLog, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	log := &AuditLog{file: file}
+	err = scanAudit(file, func(_ int, rec AuditRecord) error {
+		log.seq, log.lastHash = rec.Seq, rec.Hash
+		return nil
+	})
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to read audit log %s: %w", path, err)
+	}
+	return log, nil
+}
+
+// WithAuditLog records every mutating operation, including failed and
+// rejected ones, in log
+func WithAuditLog(log *AuditLog) Option {
+	return func(m *ENIManager) {
+		m.audit = log
+	}
+}
+
+// Append chains rec to the log and writes it, filling in Seq, PrevHash and Hash
+func (l *AuditLog) Append(rec AuditRecord) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if rec.Caller == "" {
+		rec.Caller = l.Caller
+	}
+	rec.Seq = l.seq + 1
+	rec.PrevHash = l.lastHash
+	hash, err := rec.computeHash()
+	if err != nil {
+		return err
+	}
+	rec.Hash = hash
+
+	data, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if _, err := l.file.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	l.seq, l.lastHash = rec.Seq, rec.Hash
+	return nil
+}
+
+func (l *AuditLog) Close() error {
+	return l.file.Close()
+}
+
+// scanAudit calls fn for every record in r with its 1-based line number
+func scanAudit(r io.Reader, fn func(line int, rec AuditRecord) error) error {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var rec AuditRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if err := fn(line, rec); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// VerifyAudit checks the hash chain of the log in r and returns the number of
+// records and the hash of the last one. Comparing that hash with a copy kept
+// elsewhere also detects records removed from the end.
+func VerifyAudit(r io.Reader) (count int64, lastHash string, err error) {
+	err = scanAudit(r, func(line int, rec AuditRecord) error {
+		if rec.Seq != count+1 {
+			return fmt.Errorf("line %d: sequence %d, want %d", line, rec.Seq, count+1)
+		}
+		if rec.PrevHash != lastHash {
+			return fmt.Errorf("line %d: prev_hash does not match the previous record", line)
+		}
+		hash, err := rec.computeHash()
+		if err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+		if hash != rec.Hash {
+			return fmt.Errorf("line %d: hash mismatch, record was modified", line)
+		}
+		count, lastHash = rec.Seq, rec.Hash
+		return nil
+	})
+	return count, lastHash, err
+}
+
+// AuditQuery selects audit records; zero fields match everything
+type AuditQuery struct {
+	ENIID      string
+	Operations []string
+	Caller     string
+	Since      time.Time
+	Until      time.Time
+	FailedOnly bool
+}
+
+func (q AuditQuery) matches(rec AuditRecord) bool {
+	switch {
+	case q.ENIID != "" && rec.Resources["eni_id"] != q.ENIID,
+		len(q.Operations) > 0 && !slices.Contains(q.Operations, rec.Operation),
+		q.Caller != "" && rec.Caller != q.Caller,
+		!q.Since.IsZero() && rec.Time.Before(q.Since),
+		!q.Until.IsZero() && !rec.Time.Before(q.Until),
+		q.FailedOnly && rec.Outcome != AuditFailure:
+		return false
+	}
+	return true
+}
+
+// QueryAudit returns the records in r matching q, oldest first
+func QueryAudit(r io.Reader, q AuditQuery) ([]AuditRecord, error) {
+	var records []AuditRecord
+	err := scanAudit(r, func(_ int, rec AuditRecord) error {
+		if q.matches(rec) {
+			records = append(records, rec)
+		}
+		return nil
+	})
+	return records, err
+}
+
+// audit writes the operation's record; the operation has already happened,
+// so a failed write is logged rather than returned
+func (op *operation) audit(duration time.Duration, err error) {
+	rec := AuditRecord{
+		Time:       op.start.UTC(),
+		Caller:     CallerFromContext(op.ctx),
+		Operation:  op.name,
+		Resources:  op.resources(),
+		Outcome:    AuditSuccess,
+		RequestID:  op.requestID,
+		DurationMS: duration.Milliseconds(),
+	}
+	if op.input != nil {
+		if data, jsonErr := json.Marshal(op.input); jsonErr == nil {
+			rec.Input = data
+		}
+	}
+	if err != nil {
+		rec.Outcome = AuditFailure
+		rec.ErrorCode = ErrorCode(err)
+		rec.Error = err.Error()
+		if id := RequestID(nil, err); id != "" {
+			rec.RequestID = id
+		}
+	}
+
+	if appendErr := op.m.audit.Append(rec); appendErr != nil && op.m.logger != nil {
+		op.m.logger.ErrorContext(op.ctx, "failed to write audit record", "operation", op.name, "error", appendErr)
+	}
+}
@@ -0,0 +1,109 @@
+// internal/ec2/audit_test.go
+package ec2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestENIManager_AuditLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	auditLog, err := OpenAuditLog(path)
+	assert.NoError(t, err)
+	auditLog.Caller = "cli:alice"
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient, WithAuditLog(auditLog))
+
+	mockClient.EXPECT().
+		AttachNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(&ec2.AttachNetworkInterfaceOutput{AttachmentId: aws.String("eni-attach-12345678")}, nil)
+	mockClient.EXPECT().
+		DescribeSubnets(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeSubnetsOutput{}, nil)
+	mockClient.EXPECT().
+		DeleteNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(nil, &smithy.GenericAPIError{Code: "InvalidNetworkInterfaceID.NotFound"})
+
+	ctx := context.Background()
+	_, err = manager.AttachENI(ctx, "eni-12345678", "i-12345678", 1)
+	assert.NoError(t, err)
+	// Reads are not audited
+	_, err = manager.DescribeSubnet(ctx, "subnet-12345678")
+	assert.NoError(t, err)
+	assert.Error(t, manager.DeleteENI(WithCaller(ctx, "rest:10.0.0.5"), "eni-87654321"))
+	assert.NoError(t, auditLog.Close())
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	records, err := QueryAudit(bytes.NewReader(data), AuditQuery{})
+	assert.NoError(t, err)
+	assert.Len(t, records, 2)
+
+	attach := records[0]
+	assert.Equal(t, int64(1), attach.Seq)
+	assert.Equal(t, "cli:alice", attach.Caller)
+	assert.Equal(t, "AttachENI", attach.Operation)
+	assert.Equal(t, AuditSuccess, attach.Outcome)
+	assert.Equal(t, "eni-attach-12345678", attach.Resources["attachment_id"])
+	var input map[string]any
+	assert.NoError(t, json.Unmarshal(attach.Input, &input))
+	assert.Equal(t, "i-12345678", input["InstanceId"])
+
+	del := records[1]
+	assert.Equal(t, "rest:10.0.0.5", del.Caller)
+	assert.Equal(t, AuditFailure, del.Outcome)
+	assert.Equal(t, "InvalidNetworkInterfaceID.NotFound", del.ErrorCode)
+	assert.Equal(t, attach.Hash, del.PrevHash)
+
+	count, _, err := VerifyAudit(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	failed, err := QueryAudit(bytes.NewReader(data), AuditQuery{FailedOnly: true})
+	assert.NoError(t, err)
+	assert.Len(t, failed, 1)
+	byENI, err := QueryAudit(bytes.NewReader(data), AuditQuery{ENIID: "eni-12345678"})
+	assert.NoError(t, err)
+	assert.Len(t, byENI, 1)
+}
+
+func TestAuditLog_ResumeAndTamper(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+
+	for i := 0; i < 2; i++ {
+		auditLog, err := OpenAuditLog(path)
+		assert.NoError(t, err)
+		assert.NoError(t, auditLog.Append(AuditRecord{Operation: "DeleteENI", Outcome: AuditSuccess}))
+		assert.NoError(t, auditLog.Close())
+	}
+
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	count, _, err := VerifyAudit(bytes.NewReader(data))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+
+	tampered := strings.Replace(string(data), `"outcome":"success"`, `"outcome":"failure"`, 1)
+	_, _, err = VerifyAudit(strings.NewReader(tampered))
+	assert.ErrorContains(t, err, "line 1: hash mismatch")
+
+	lines := strings.SplitAfter(string(data), "\n")
+	_, _, err = VerifyAudit(strings.NewReader(lines[1]))
+	assert.ErrorContains(t, err, "sequence 2, want 1")
+}
@@ -0,0 +1,364 @@
+package ec2
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+)
+
+// CacheConfig represents per-operation TTLs for CachedClient. A zero TTL
+// disables caching for that operation.
+type CacheConfig struct {
+	InstanceTypeTTL time.Duration
+	SubnetTTL       time.Duration
+	ENITTL          time.Duration
+}
+
+// DefaultCacheConfig caches instance types for hours and subnets for a
+// minute. ENIs change too often to cache by default.
+func DefaultCacheConfig() CacheConfig {
+	return CacheConfig{
+		InstanceTypeTTL: 6 * time.Hour,
+		SubnetTTL:       time.Minute,
+	}
+}
+
+// CacheStats holds hit/miss statistics for a single cached operation
+type CacheStats struct {
+	Hits          int64
+	Misses        int64
+	Invalidations int64
+}
+
+type cacheEntry struct {
+	value     any
+	expires   time.Time
+	resources []string
+}
+
+type cacheTable struct {
+	ttl     time.Duration
+	entries map[string]cacheEntry
+	stats   CacheStats
+}
+
+func newCacheTable(ttl time.Duration) *cacheTable {
+	return &cacheTable{ttl: ttl, entries: make(map[string]cacheEntry)}
+}
+
+func (t *cacheTable) invalidateAll() {
+	if len(t.entries) == 0 {
+		return
+	}
+	t.entries = make(map[string]cacheEntry)
+	t.stats.Invalidations++
+}
+
+func (t *cacheTable) invalidate(resourceIDs ...string) {
+	for key, entry := range t.entries {
+		for _, r := range entry.resources {
+			if slices.Contains(resourceIDs, r) {
+				delete(t.entries, key)
+				t.stats.Invalidations++
+				break
+			}
+		}
+	}
+}
+
+// CachedClient is a read-through caching EC2ClientAPI. Describe calls for
+// instance types, subnets and (optionally) ENIs are served from memory until
+// their TTL expires; mutating calls pass straight through and invalidate the
+// cached entries they affect. Cached outputs are shared between callers and
+// must be treated as read-only.
+type CachedClient struct {
+	client EC2ClientAPI
+	now    func() time.Time
+
+	mu            sync.Mutex
+	instanceTypes *cacheTable
+	subnets       *cacheTable
+	enis          *cacheTable
+	// eniSubnets remembers which subnet each ENI we've seen lives in, so that
+	// IP changes on the ENI only invalidate that subnet
+	eniSubnets map[string]string
+}
+
+var _ EC2ClientAPI = (*CachedClient)(nil)
+
+func NewCachedClient(client EC2ClientAPI, config CacheConfig) *CachedClient {
+	return &CachedClient{
+		client:        client,
+		now:           time.Now,
+		instanceTypes: newCacheTable(config.InstanceTypeTTL),
+		subnets:       newCacheTable(config.SubnetTTL),
+		enis:          newCacheTable(config.ENITTL),
+		eniSubnets:    make(map[string]string),
+	}
+}
+
+// Stats returns a snapshot of cache statistics keyed by EC2 operation name
+func (c *CachedClient) Stats() map[string]CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return map[string]CacheStats{
+		"DescribeInstanceTypes":     c.instanceTypes.stats,
+		"DescribeSubnets":           c.subnets.stats,
+		"DescribeNetworkInterfaces": c.enis.stats,
+	}
+}
+
+// Invalidate drops every cached entry
+func (c *CachedClient) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.instanceTypes.invalidateAll()
+	c.subnets.invalidateAll()
+	c.enis.invalidateAll()
+}
+
+func cacheKey(input any) string {
+	// Input structs are plain data, so their JSON form is a stable key
+	b, err := json.Marshal(input)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
+func (c *CachedClient) lookup(t *cacheTable, key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t.ttl <= 0 || key == "" {
+		return nil, false
+	}
+	entry, ok := t.entries[key]
+	if !ok || c.now().After(entry.expires) {
+		delete(t.entries, key)
+		t.stats.Misses++
+		return nil, false
+	}
+	t.stats.Hits++
+	return entry.value, true
+}
+
+func (c *CachedClient) store(t *cacheTable, key string, value any, resources []string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if t.ttl <= 0 || key == "" {
+		return
+	}
+	t.entries[key] = cacheEntry{
+		value:     value,
+		expires:   c.now().Add(t.ttl),
+		resources: resources,
+	}
+}
+
+// invalidateENI drops all cached ENI listings, since any filtered listing may
+// include the ENI, along with the cached subnet the ENI lives in
+func (c *CachedClient) invalidateENI(networkInterfaceID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.enis.invalidateAll()
+	if subnetID, ok := c.eniSubnets[networkInterfaceID]; ok {
+		c.subnets.invalidate(subnetID)
+	} else {
+		c.subnets.invalidateAll()
+	}
+}
+
+func (c *CachedClient) DescribeInstanceTypes(ctx context.Context, input *ec2.DescribeInstanceTypesInput, opts ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+	key := cacheKey(input)
+	if v, ok := c.lookup(c.instanceTypes, key); ok {
+		return v.(*ec2.DescribeInstanceTypesOutput), nil
+	}
+
+	output, err := c.client.DescribeInstanceTypes(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	c.store(c.instanceTypes, key, output, nil)
+	return output, nil
+}
+
+func (c *CachedClient) DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput, opts ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	key := cacheKey(input)
+	if v, ok := c.lookup(c.subnets, key); ok {
+		return v.(*ec2.DescribeSubnetsOutput), nil
+	}
+
+	output, err := c.client.DescribeSubnets(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var resources []string
+	for _, subnet := range output.Subnets {
+		resources = append(resources, aws.ToString(subnet.SubnetId))
+	}
+	c.store(c.subnets, key, output, resources)
+	return output, nil
+}
+
+func (c *CachedClient) DescribeNetworkInterfaces(ctx context.Context, input *ec2.DescribeNetworkInterfacesInput, opts ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	key := cacheKey(input)
+	if v, ok := c.lookup(c.enis, key); ok {
+		return v.(*ec2.DescribeNetworkInterfacesOutput), nil
+	}
+
+	output, err := c.client.DescribeNetworkInterfaces(ctx, input, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var resources []string
+	c.mu.Lock()
+	for _, eni := range output.NetworkInterfaces {
+		id := aws.ToString(eni.NetworkInterfaceId)
+		resources = append(resources, id)
+		if eni.SubnetId != nil {
+			c.eniSubnets[id] = *eni.SubnetId
+		}
+	}
+	c.mu.Unlock()
+	c.store(c.enis, key, output, resources)
+	return output, nil
+}
+
+func (c *CachedClient) DescribeInstances(ctx context.Context, input *ec2.DescribeInstancesInput, opts ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	return c.client.DescribeInstances(ctx, input, opts...)
+}
+
+func (c *CachedClient) CreateNetworkInterface(ctx context.Context, input *ec2.CreateNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.CreateNetworkInterfaceOutput, error) {
+	output, err := c.client.CreateNetworkInterface(ctx, input, opts...)
+
+	c.mu.Lock()
+	c.enis.invalidateAll()
+	c.subnets.invalidate(aws.ToString(input.SubnetId))
+	if err == nil && output.NetworkInterface != nil {
+		c.eniSubnets[aws.ToString(output.NetworkInterface.NetworkInterfaceId)] = aws.ToString(input.SubnetId)
+	}
+	c.mu.Unlock()
+
+	return output, err
+}
+
+func (c *CachedClient) AttachNetworkInterface(ctx context.Context, input *ec2.AttachNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.AttachNetworkInterfaceOutput, error) {
+	output, err := c.client.AttachNetworkInterface(ctx, input, opts...)
+	c.mu.Lock()
+	c.enis.invalidateAll()
+	c.mu.Unlock()
+	return output, err
+}
+
+func (c *CachedClient) DeleteNetworkInterface(ctx context.Context, input *ec2.DeleteNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DeleteNetworkInterfaceOutput, error) {
+	output, err := c.client.DeleteNetworkInterface(ctx, input, opts...)
+	c.invalidateENI(aws.ToString(input.NetworkInterfaceId))
+	return output, err
+}
+
+func (c *CachedClient) DetachNetworkInterface(ctx context.Context, input *ec2.DetachNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DetachNetworkInterfaceOutput, error) {
+	output, err := c.client.DetachNetworkInterface(ctx, input, opts...)
+	c.mu.Lock()
+	c.enis.invalidateAll()
+	c.mu.Unlock()
+	return output, err
+}
+
+func (c *CachedClient) AssignPrivateIpAddresses(ctx context.Context, input *ec2.AssignPrivateIpAddressesInput, opts ...func(*ec2.Options)) (*ec2.AssignPrivateIpAddressesOutput, error) {
+	output, err := c.client.AssignPrivateIpAddresses(ctx, input, opts...)
+	c.invalidateENI(aws.ToString(input.NetworkInterfaceId))
+	return output, err
+}
+
+func (c *CachedClient) UnassignPrivateIpAddresses(ctx context.Context, input *ec2.UnassignPrivateIpAddressesInput, opts ...func(*ec2.Options)) (*ec2.UnassignPrivateIpAddressesOutput, error) {
+	output, err := c.client.UnassignPrivateIpAddresses(ctx, input, opts...)
+	c.invalidateENI(aws.ToString(input.NetworkInterfaceId))
+	return output, err
+}
+
+func (c *CachedClient) AssignIpv6Addresses(ctx context.Context, input *ec2.AssignIpv6AddressesInput, opts ...func(*ec2.Options)) (*ec2.AssignIpv6AddressesOutput, error) {
+	output, err := c.client.AssignIpv6Addresses(ctx, input, opts...)
+	c.invalidateENI(aws.ToString(input.NetworkInterfaceId))
+	return output, err
+}
+
+func (c *CachedClient) UnassignIpv6Addresses(ctx context.Context, input *ec2.UnassignIpv6AddressesInput, opts ...func(*ec2.Options)) (*ec2.UnassignIpv6AddressesOutput, error) {
+	output, err := c.client.UnassignIpv6Addresses(ctx, input, opts...)
+	c.invalidateENI(aws.ToString(input.NetworkInterfaceId))
+	return output, err
+}
+
+func (c *CachedClient) ModifyNetworkInterfaceAttribute(ctx context.Context, input *ec2.ModifyNetworkInterfaceAttributeInput, opts ...func(*ec2.Options)) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+	output, err := c.client.ModifyNetworkInterfaceAttribute(ctx, input, opts...)
+	c.mu.Lock()
+	c.enis.invalidateAll()
+	c.mu.Unlock()
+	return output, err
+}
+
+func (c *CachedClient) CreateTags(ctx context.Context, input *ec2.CreateTagsInput, opts ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+	output, err := c.client.CreateTags(ctx, input, opts...)
+	c.mu.Lock()
+	c.enis.invalidateAll()
+	c.subnets.invalidate(input.Resources...)
+	c.mu.Unlock()
+	return output, err
+}
+
+func (c *CachedClient) DeleteTags(ctx context.Context, input *ec2.DeleteTagsInput, opts ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error) {
+	output, err := c.client.DeleteTags(ctx, input, opts...)
+	c.mu.Lock()
+	c.enis.invalidateAll()
+	c.subnets.invalidate(input.Resources...)
+	c.mu.Unlock()
+	return output, err
+}
+
+// Security group calls pass straight through: ENI listings carry group IDs,
+// which these calls never change
+func (c *CachedClient) CreateSecurityGroup(ctx context.Context, input *ec2.CreateSecurityGroupInput, opts ...func(*ec2.Options)) (*ec2.CreateSecurityGroupOutput, error) {
+	return c.client.CreateSecurityGroup(ctx, input, opts...)
+}
+
+func (c *CachedClient) DescribeSecurityGroups(ctx context.Context, input *ec2.DescribeSecurityGroupsInput, opts ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	return c.client.DescribeSecurityGroups(ctx, input, opts...)
+}
+
+func (c *CachedClient) AuthorizeSecurityGroupIngress(ctx context.Context, input *ec2.AuthorizeSecurityGroupIngressInput, opts ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	return c.client.AuthorizeSecurityGroupIngress(ctx, input, opts...)
+}
+
+func (c *CachedClient) AuthorizeSecurityGroupEgress(ctx context.Context, input *ec2.AuthorizeSecurityGroupEgressInput, opts ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+	return c.client.AuthorizeSecurityGroupEgress(ctx, input, opts...)
+}
+
+func (c *CachedClient) RevokeSecurityGroupIngress(ctx context.Context, input *ec2.RevokeSecurityGroupIngressInput, opts ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	return c.client.RevokeSecurityGroupIngress(ctx, input, opts...)
+}
+
+func (c *CachedClient) RevokeSecurityGroupEgress(ctx context.Context, input *ec2.RevokeSecurityGroupEgressInput, opts ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	return c.client.RevokeSecurityGroupEgress(ctx, input, opts...)
+}
+
+func (c *CachedClient) DeleteSecurityGroup(ctx context.Context, input *ec2.DeleteSecurityGroupInput, opts ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error) {
+	return c.client.DeleteSecurityGroup(ctx, input, opts...)
+}
+
+// Trunk associations aren't part of ENI listings, so these pass straight
+// through too
+func (c *CachedClient) AssociateTrunkInterface(ctx context.Context, input *ec2.AssociateTrunkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.AssociateTrunkInterfaceOutput, error) {
+	return c.client.AssociateTrunkInterface(ctx, input, opts...)
+}
+
+func (c *CachedClient) DisassociateTrunkInterface(ctx context.Context, input *ec2.DisassociateTrunkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DisassociateTrunkInterfaceOutput, error) {
+	return c.client.DisassociateTrunkInterface(ctx, input, opts...)
+}
+
+func (c *CachedClient) DescribeTrunkInterfaceAssociations(ctx context.Context, input *ec2.DescribeTrunkInterfaceAssociationsInput, opts ...func(*ec2.Options)) (*ec2.DescribeTrunkInterfaceAssociationsOutput, error) {
+	return c.client.DescribeTrunkInterfaceAssociations(ctx, input, opts...)
+}
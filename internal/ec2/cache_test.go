@@ -0,0 +1,129 @@
+// internal/ec2/cache_test.go
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCachedClient_SubnetTTL(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	client := NewCachedClient(mockClient, DefaultCacheConfig())
+	now := time.Now()
+	client.now = func() time.Time { return now }
+	manager := NewENIManager(client)
+
+	mockClient.EXPECT().
+		DescribeSubnets(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []types.Subnet{{SubnetId: aws.String("subnet-12345678")}},
+		}, nil).
+		Times(2)
+
+	for i := 0; i < 3; i++ {
+		result, err := manager.DescribeSubnet(context.Background(), "subnet-12345678")
+		assert.NoError(t, err)
+		assert.Equal(t, "subnet-12345678", *result.Subnets[0].SubnetId)
+	}
+
+	now = now.Add(2 * time.Minute)
+	_, err := manager.DescribeSubnet(context.Background(), "subnet-12345678")
+	assert.NoError(t, err)
+
+	stats := client.Stats()["DescribeSubnets"]
+	assert.Equal(t, int64(2), stats.Hits)
+	assert.Equal(t, int64(2), stats.Misses)
+}
+
+func TestCachedClient_InvalidatesOnMutation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	client := NewCachedClient(mockClient, CacheConfig{
+		SubnetTTL: time.Minute,
+		ENITTL:    10 * time.Second,
+	})
+	manager := NewENIManager(client)
+
+	filters := []types.Filter{
+		{
+			Name:   aws.String("subnet-id"),
+			Values: []string{"subnet-12345678"},
+		},
+	}
+
+	mockClient.EXPECT().
+		DescribeSubnets(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []types.Subnet{{SubnetId: aws.String("subnet-12345678")}},
+		}, nil).
+		Times(2)
+	mockClient.EXPECT().
+		DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{
+			NetworkInterfaces: []types.NetworkInterface{
+				{
+					NetworkInterfaceId: aws.String("eni-12345678"),
+					SubnetId:           aws.String("subnet-12345678"),
+				},
+			},
+		}, nil).
+		Times(2)
+	mockClient.EXPECT().
+		AssignPrivateIpAddresses(gomock.Any(), gomock.Any()).
+		Return(&ec2.AssignPrivateIpAddressesOutput{}, nil)
+
+	ctx := context.Background()
+	_, err := manager.DescribeSubnet(ctx, "subnet-12345678")
+	assert.NoError(t, err)
+	_, err = manager.DescribeENIs(ctx, filters)
+	assert.NoError(t, err)
+	_, err = manager.DescribeENIs(ctx, filters)
+	assert.NoError(t, err)
+
+	assert.NoError(t, manager.AssignPrivateIPs(ctx, "eni-12345678", 1, nil))
+
+	// Both the ENI listing and the subnet's free IP count are now stale
+	_, err = manager.DescribeSubnet(ctx, "subnet-12345678")
+	assert.NoError(t, err)
+	_, err = manager.DescribeENIs(ctx, filters)
+	assert.NoError(t, err)
+
+	stats := client.Stats()
+	assert.Equal(t, int64(1), stats["DescribeNetworkInterfaces"].Hits)
+	assert.Equal(t, int64(1), stats["DescribeNetworkInterfaces"].Invalidations)
+	assert.Equal(t, int64(1), stats["DescribeSubnets"].Invalidations)
+}
+
+func TestCachedClient_DisabledTTLPassesThrough(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	client := NewCachedClient(mockClient, DefaultCacheConfig())
+	manager := NewENIManager(client)
+
+	mockClient.EXPECT().
+		DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{}, nil).
+		Times(2)
+
+	_, err := manager.DescribeENIs(context.Background(), nil)
+	assert.NoError(t, err)
+	_, err = manager.DescribeENIs(context.Background(), nil)
+	assert.NoError(t, err)
+
+	assert.Zero(t, client.Stats()["DescribeNetworkInterfaces"].Hits)
+}
@@ -0,0 +1,476 @@
+package ec2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go"
+)
+
+// Cassette is a recorded sequence of EC2 API calls, saved as JSON so that
+// tests can replay calls recorded once against a real account
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is one recorded call: its request and either its response or
+// its error
+type Interaction struct {
+	Action   string          `json:"action"`
+	Request  json.RawMessage `json:"request"`
+	Response json.RawMessage `json:"response,omitempty"`
+	Error    *CassetteError  `json:"error,omitempty"`
+}
+
+// CassetteError is a recorded error. Code is set for errors from the EC2
+// API, which replay as a smithy.APIError.
+type CassetteError struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message"`
+}
+
+func (e *CassetteError) err() error {
+	if e.Code == "" {
+		return errors.New(e.Message)
+	}
+	return &smithy.GenericAPIError{Code: e.Code, Message: e.Message}
+}
+
+// LoadCassette reads a cassette saved by RecordingClient.Save
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("invalid cassette %s: %w", path, err)
+	}
+	return &cassette, nil
+}
+
+// resourceIDPattern matches the EC2 resource IDs scrubbed from cassettes,
+// in both their 8 and 17 hex digit forms
+var resourceIDPattern = regexp.MustCompile(`\b(eni-attach|trunk-assoc|eipalloc|eipassoc|subnet|sgr|sg|vpc|eni|ami|igw|rtb|acl|vol|i|r)-([0-9a-f]{17}|[0-9a-f]{8})\b`)
+
+// accountIDPattern matches AWS account IDs, including those inside ARNs
+var accountIDPattern = regexp.MustCompile(`\b[0-9]{12}\b`)
+
+// scrubber replaces resource and account IDs with sequential fakes of the
+// same form. The same real ID always gets the same fake, so calls that
+// refer to each other's resources still do after scrubbing.
+type scrubber struct {
+	fakes map[string]string
+	next  map[string]int
+}
+
+func newScrubber() *scrubber {
+	return &scrubber{fakes: make(map[string]string), next: make(map[string]int)}
+}
+
+func (s *scrubber) scrub(data []byte) []byte {
+	data = resourceIDPattern.ReplaceAllFunc(data, func(id []byte) []byte {
+		// The prefix itself may contain a dash, as in eni-attach
+		i := bytes.LastIndexByte(id, '-')
+		prefix, digits := string(id[:i]), string(id[i+1:])
+		return []byte(s.fake(string(id), prefix, func(n int) string {
+			return fmt.Sprintf("%s-%0*x", prefix, len(digits), n)
+		}))
+	})
+	return accountIDPattern.ReplaceAllFunc(data, func(id []byte) []byte {
+		return []byte(s.fake(string(id), "account", func(n int) string {
+			return fmt.Sprintf("%012d", n)
+		}))
+	})
+}
+
+func (s *scrubber) fake(real, kind string, format func(n int) string) string {
+	if fake, ok := s.fakes[real]; ok {
+		return fake
+	}
+	s.next[kind]++
+	fake := format(s.next[kind])
+	s.fakes[real] = fake
+	return fake
+}
+
+// RecordingClient is an EC2ClientAPI that passes every call through to
+// client and records it in a cassette. Resource and account IDs are
+// scrubbed from the recording, not from what callers get back.
+type RecordingClient struct {
+	client EC2ClientAPI
+
+	mu       sync.Mutex
+	cassette Cassette
+	scrubber *scrubber
+}
+
+var _ EC2ClientAPI = (*RecordingClient)(nil)
+
+func NewRecordingClient(client EC2ClientAPI) *RecordingClient {
+	return &RecordingClient{client: client, scrubber: newScrubber()}
+}
+
+// Cassette returns a copy of what has been recorded so far
+func (c *RecordingClient) Cassette() *Cassette {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return &Cassette{Interactions: append([]Interaction(nil), c.cassette.Interactions...)}
+}
+
+// Save writes the recording to path for LoadCassette
+func (c *RecordingClient) Save(path string) error {
+	data, err := json.MarshalIndent(c.Cassette(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// record makes one call and appends it to the cassette
+func record[In, Out any](c *RecordingClient, action string, input *In, call func() (*Out, error)) (*Out, error) {
+	// Marshal the request first, since the SDK fills in fields such as
+	// ClientToken on the input during the call
+	request, jsonErr := json.Marshal(input)
+	output, err := call()
+	if jsonErr != nil {
+		return output, err
+	}
+	var response []byte
+	if err == nil {
+		if response, jsonErr = json.Marshal(output); jsonErr != nil {
+			return output, err
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	interaction := Interaction{Action: action, Request: c.scrubber.scrub(request)}
+	if err == nil {
+		interaction.Response = c.scrubber.scrub(response)
+	} else {
+		interaction.Error = &CassetteError{Message: string(c.scrubber.scrub([]byte(err.Error())))}
+		var apiErr smithy.APIError
+		if errors.As(err, &apiErr) {
+			interaction.Error.Code = apiErr.ErrorCode()
+			interaction.Error.Message = string(c.scrubber.scrub([]byte(apiErr.ErrorMessage())))
+		}
+	}
+	c.cassette.Interactions = append(c.cassette.Interactions, interaction)
+	return output, err
+}
+
+// ReplayClient is an EC2ClientAPI that serves the calls of a cassette in
+// the order they were recorded, without contacting AWS. Each call must
+// match the next recorded one exactly, so requests carry the scrubbed IDs
+// found in the cassette rather than real ones.
+type ReplayClient struct {
+	mu       sync.Mutex
+	cassette *Cassette
+	next     int
+}
+
+var _ EC2ClientAPI = (*ReplayClient)(nil)
+
+func NewReplayClient(cassette *Cassette) *ReplayClient {
+	return &ReplayClient{cassette: cassette}
+}
+
+// Remaining returns the number of recorded calls not yet replayed
+func (c *ReplayClient) Remaining() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.cassette.Interactions) - c.next
+}
+
+// replay serves the next recorded call if action and input match it
+func replay[In, Out any](c *ReplayClient, action string, input *In) (*Out, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.next == len(c.cassette.Interactions) {
+		return nil, fmt.Errorf("replay: unexpected %s after all %d recorded calls", action, c.next)
+	}
+	interaction := c.cassette.Interactions[c.next]
+	if interaction.Action != action {
+		return nil, fmt.Errorf("replay: call %d is %s, want recorded %s", c.next, action, interaction.Action)
+	}
+
+	request, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	var recorded bytes.Buffer
+	if err := json.Compact(&recorded, interaction.Request); err != nil {
+		return nil, fmt.Errorf("replay: call %d: %w", c.next, err)
+	}
+	if !bytes.Equal(request, recorded.Bytes()) {
+		return nil, fmt.Errorf("replay: call %d %s has request %s, want recorded %s", c.next, action, request, recorded.Bytes())
+	}
+	c.next++
+
+	if interaction.Error != nil {
+		return nil, interaction.Error.err()
+	}
+	output := new(Out)
+	if err := json.Unmarshal(interaction.Response, output); err != nil {
+		return nil, fmt.Errorf("replay: call %d %s: %w", c.next-1, action, err)
+	}
+	return output, nil
+}
+
+func (c *RecordingClient) CreateNetworkInterface(ctx context.Context, input *ec2.CreateNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.CreateNetworkInterfaceOutput, error) {
+	return record(c, "CreateNetworkInterface", input, func() (*ec2.CreateNetworkInterfaceOutput, error) {
+		return c.client.CreateNetworkInterface(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) DescribeInstances(ctx context.Context, input *ec2.DescribeInstancesInput, opts ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	return record(c, "DescribeInstances", input, func() (*ec2.DescribeInstancesOutput, error) {
+		return c.client.DescribeInstances(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) DescribeInstanceTypes(ctx context.Context, input *ec2.DescribeInstanceTypesInput, opts ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+	return record(c, "DescribeInstanceTypes", input, func() (*ec2.DescribeInstanceTypesOutput, error) {
+		return c.client.DescribeInstanceTypes(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) AttachNetworkInterface(ctx context.Context, input *ec2.AttachNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.AttachNetworkInterfaceOutput, error) {
+	return record(c, "AttachNetworkInterface", input, func() (*ec2.AttachNetworkInterfaceOutput, error) {
+		return c.client.AttachNetworkInterface(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) DeleteNetworkInterface(ctx context.Context, input *ec2.DeleteNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DeleteNetworkInterfaceOutput, error) {
+	return record(c, "DeleteNetworkInterface", input, func() (*ec2.DeleteNetworkInterfaceOutput, error) {
+		return c.client.DeleteNetworkInterface(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) DetachNetworkInterface(ctx context.Context, input *ec2.DetachNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DetachNetworkInterfaceOutput, error) {
+	return record(c, "DetachNetworkInterface", input, func() (*ec2.DetachNetworkInterfaceOutput, error) {
+		return c.client.DetachNetworkInterface(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) AssignPrivateIpAddresses(ctx context.Context, input *ec2.AssignPrivateIpAddressesInput, opts ...func(*ec2.Options)) (*ec2.AssignPrivateIpAddressesOutput, error) {
+	return record(c, "AssignPrivateIpAddresses", input, func() (*ec2.AssignPrivateIpAddressesOutput, error) {
+		return c.client.AssignPrivateIpAddresses(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) UnassignPrivateIpAddresses(ctx context.Context, input *ec2.UnassignPrivateIpAddressesInput, opts ...func(*ec2.Options)) (*ec2.UnassignPrivateIpAddressesOutput, error) {
+	return record(c, "UnassignPrivateIpAddresses", input, func() (*ec2.UnassignPrivateIpAddressesOutput, error) {
+		return c.client.UnassignPrivateIpAddresses(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) AssignIpv6Addresses(ctx context.Context, input *ec2.AssignIpv6AddressesInput, opts ...func(*ec2.Options)) (*ec2.AssignIpv6AddressesOutput, error) {
+	return record(c, "AssignIpv6Addresses", input, func() (*ec2.AssignIpv6AddressesOutput, error) {
+		return c.client.AssignIpv6Addresses(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) UnassignIpv6Addresses(ctx context.Context, input *ec2.UnassignIpv6AddressesInput, opts ...func(*ec2.Options)) (*ec2.UnassignIpv6AddressesOutput, error) {
+	return record(c, "UnassignIpv6Addresses", input, func() (*ec2.UnassignIpv6AddressesOutput, error) {
+		return c.client.UnassignIpv6Addresses(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) DescribeNetworkInterfaces(ctx context.Context, input *ec2.DescribeNetworkInterfacesInput, opts ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	return record(c, "DescribeNetworkInterfaces", input, func() (*ec2.DescribeNetworkInterfacesOutput, error) {
+		return c.client.DescribeNetworkInterfaces(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) ModifyNetworkInterfaceAttribute(ctx context.Context, input *ec2.ModifyNetworkInterfaceAttributeInput, opts ...func(*ec2.Options)) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+	return record(c, "ModifyNetworkInterfaceAttribute", input, func() (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+		return c.client.ModifyNetworkInterfaceAttribute(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) CreateTags(ctx context.Context, input *ec2.CreateTagsInput, opts ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+	return record(c, "CreateTags", input, func() (*ec2.CreateTagsOutput, error) {
+		return c.client.CreateTags(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) DeleteTags(ctx context.Context, input *ec2.DeleteTagsInput, opts ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error) {
+	return record(c, "DeleteTags", input, func() (*ec2.DeleteTagsOutput, error) {
+		return c.client.DeleteTags(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput, opts ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	return record(c, "DescribeSubnets", input, func() (*ec2.DescribeSubnetsOutput, error) {
+		return c.client.DescribeSubnets(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) CreateSecurityGroup(ctx context.Context, input *ec2.CreateSecurityGroupInput, opts ...func(*ec2.Options)) (*ec2.CreateSecurityGroupOutput, error) {
+	return record(c, "CreateSecurityGroup", input, func() (*ec2.CreateSecurityGroupOutput, error) {
+		return c.client.CreateSecurityGroup(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) DescribeSecurityGroups(ctx context.Context, input *ec2.DescribeSecurityGroupsInput, opts ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	return record(c, "DescribeSecurityGroups", input, func() (*ec2.DescribeSecurityGroupsOutput, error) {
+		return c.client.DescribeSecurityGroups(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) AuthorizeSecurityGroupIngress(ctx context.Context, input *ec2.AuthorizeSecurityGroupIngressInput, opts ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	return record(c, "AuthorizeSecurityGroupIngress", input, func() (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+		return c.client.AuthorizeSecurityGroupIngress(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) AuthorizeSecurityGroupEgress(ctx context.Context, input *ec2.AuthorizeSecurityGroupEgressInput, opts ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+	return record(c, "AuthorizeSecurityGroupEgress", input, func() (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+		return c.client.AuthorizeSecurityGroupEgress(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) RevokeSecurityGroupIngress(ctx context.Context, input *ec2.RevokeSecurityGroupIngressInput, opts ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	return record(c, "RevokeSecurityGroupIngress", input, func() (*ec2.RevokeSecurityGroupIngressOutput, error) {
+		return c.client.RevokeSecurityGroupIngress(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) RevokeSecurityGroupEgress(ctx context.Context, input *ec2.RevokeSecurityGroupEgressInput, opts ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	return record(c, "RevokeSecurityGroupEgress", input, func() (*ec2.RevokeSecurityGroupEgressOutput, error) {
+		return c.client.RevokeSecurityGroupEgress(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) DeleteSecurityGroup(ctx context.Context, input *ec2.DeleteSecurityGroupInput, opts ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error) {
+	return record(c, "DeleteSecurityGroup", input, func() (*ec2.DeleteSecurityGroupOutput, error) {
+		return c.client.DeleteSecurityGroup(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) AssociateTrunkInterface(ctx context.Context, input *ec2.AssociateTrunkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.AssociateTrunkInterfaceOutput, error) {
+	return record(c, "AssociateTrunkInterface", input, func() (*ec2.AssociateTrunkInterfaceOutput, error) {
+		return c.client.AssociateTrunkInterface(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) DisassociateTrunkInterface(ctx context.Context, input *ec2.DisassociateTrunkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DisassociateTrunkInterfaceOutput, error) {
+	return record(c, "DisassociateTrunkInterface", input, func() (*ec2.DisassociateTrunkInterfaceOutput, error) {
+		return c.client.DisassociateTrunkInterface(ctx, input, opts...)
+	})
+}
+
+func (c *RecordingClient) DescribeTrunkInterfaceAssociations(ctx context.Context, input *ec2.DescribeTrunkInterfaceAssociationsInput, opts ...func(*ec2.Options)) (*ec2.DescribeTrunkInterfaceAssociationsOutput, error) {
+	return record(c, "DescribeTrunkInterfaceAssociations", input, func() (*ec2.DescribeTrunkInterfaceAssociationsOutput, error) {
+		return c.client.DescribeTrunkInterfaceAssociations(ctx, input, opts...)
+	})
+}
+
+func (c *ReplayClient) CreateNetworkInterface(ctx context.Context, input *ec2.CreateNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.CreateNetworkInterfaceOutput, error) {
+	return replay[ec2.CreateNetworkInterfaceInput, ec2.CreateNetworkInterfaceOutput](c, "CreateNetworkInterface", input)
+}
+
+func (c *ReplayClient) DescribeInstances(ctx context.Context, input *ec2.DescribeInstancesInput, opts ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	return replay[ec2.DescribeInstancesInput, ec2.DescribeInstancesOutput](c, "DescribeInstances", input)
+}
+
+func (c *ReplayClient) DescribeInstanceTypes(ctx context.Context, input *ec2.DescribeInstanceTypesInput, opts ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+	return replay[ec2.DescribeInstanceTypesInput, ec2.DescribeInstanceTypesOutput](c, "DescribeInstanceTypes", input)
+}
+
+func (c *ReplayClient) AttachNetworkInterface(ctx context.Context, input *ec2.AttachNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.AttachNetworkInterfaceOutput, error) {
+	return replay[ec2.AttachNetworkInterfaceInput, ec2.AttachNetworkInterfaceOutput](c, "AttachNetworkInterface", input)
+}
+
+func (c *ReplayClient) DeleteNetworkInterface(ctx context.Context, input *ec2.DeleteNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DeleteNetworkInterfaceOutput, error) {
+	return replay[ec2.DeleteNetworkInterfaceInput, ec2.DeleteNetworkInterfaceOutput](c, "DeleteNetworkInterface", input)
+}
+
+func (c *ReplayClient) DetachNetworkInterface(ctx context.Context, input *ec2.DetachNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DetachNetworkInterfaceOutput, error) {
+	return replay[ec2.DetachNetworkInterfaceInput, ec2.DetachNetworkInterfaceOutput](c, "DetachNetworkInterface", input)
+}
+
+func (c *ReplayClient) AssignPrivateIpAddresses(ctx context.Context, input *ec2.AssignPrivateIpAddressesInput, opts ...func(*ec2.Options)) (*ec2.AssignPrivateIpAddressesOutput, error) {
+	return replay[ec2.AssignPrivateIpAddressesInput, ec2.AssignPrivateIpAddressesOutput](c, "AssignPrivateIpAddresses", input)
+}
+
+func (c *ReplayClient) UnassignPrivateIpAddresses(ctx context.Context, input *ec2.UnassignPrivateIpAddressesInput, opts ...func(*ec2.Options)) (*ec2.UnassignPrivateIpAddressesOutput, error) {
+	return replay[ec2.UnassignPrivateIpAddressesInput, ec2.UnassignPrivateIpAddressesOutput](c, "UnassignPrivateIpAddresses", input)
+}
+
+func (c *ReplayClient) AssignIpv6Addresses(ctx context.Context, input *ec2.AssignIpv6AddressesInput, opts ...func(*ec2.Options)) (*ec2.AssignIpv6AddressesOutput, error) {
+	return replay[ec2.AssignIpv6AddressesInput, ec2.AssignIpv6AddressesOutput](c, "AssignIpv6Addresses", input)
+}
+
+func (c *ReplayClient) UnassignIpv6Addresses(ctx context.Context, input *ec2.UnassignIpv6AddressesInput, opts ...func(*ec2.Options)) (*ec2.UnassignIpv6AddressesOutput, error) {
+	return replay[ec2.UnassignIpv6AddressesInput, ec2.UnassignIpv6AddressesOutput](c, "UnassignIpv6Addresses", input)
+}
+
+func (c *ReplayClient) DescribeNetworkInterfaces(ctx context.Context, input *ec2.DescribeNetworkInterfacesInput, opts ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	return replay[ec2.DescribeNetworkInterfacesInput, ec2.DescribeNetworkInterfacesOutput](c, "DescribeNetworkInterfaces", input)
+}
+
+func (c *ReplayClient) ModifyNetworkInterfaceAttribute(ctx context.Context, input *ec2.ModifyNetworkInterfaceAttributeInput, opts ...func(*ec2.Options)) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+	return replay[ec2.ModifyNetworkInterfaceAttributeInput, ec2.ModifyNetworkInterfaceAttributeOutput](c, "ModifyNetworkInterfaceAttribute", input)
+}
+
+func (c *ReplayClient) CreateTags(ctx context.Context, input *ec2.CreateTagsInput, opts ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+	return replay[ec2.CreateTagsInput, ec2.CreateTagsOutput](c, "CreateTags", input)
+}
+
+func (c *ReplayClient) DeleteTags(ctx context.Context, input *ec2.DeleteTagsInput, opts ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error) {
+	return replay[ec2.DeleteTagsInput, ec2.DeleteTagsOutput](c, "DeleteTags", input)
+}
+
+func (c *ReplayClient) DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput, opts ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	return replay[ec2.DescribeSubnetsInput, ec2.DescribeSubnetsOutput](c, "DescribeSubnets", input)
+}
+
+func (c *ReplayClient) CreateSecurityGroup(ctx context.Context, input *ec2.CreateSecurityGroupInput, opts ...func(*ec2.Options)) (*ec2.CreateSecurityGroupOutput, error) {
+	return replay[ec2.CreateSecurityGroupInput, ec2.CreateSecurityGroupOutput](c, "CreateSecurityGroup", input)
+}
+
+func (c *ReplayClient) DescribeSecurityGroups(ctx context.Context, input *ec2.DescribeSecurityGroupsInput, opts ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	return replay[ec2.DescribeSecurityGroupsInput, ec2.DescribeSecurityGroupsOutput](c, "DescribeSecurityGroups", input)
+}
+
+func (c *ReplayClient) AuthorizeSecurityGroupIngress(ctx context.Context, input *ec2.AuthorizeSecurityGroupIngressInput, opts ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	return replay[ec2.AuthorizeSecurityGroupIngressInput, ec2.AuthorizeSecurityGroupIngressOutput](c, "AuthorizeSecurityGroupIngress", input)
+}
+
+func (c *ReplayClient) AuthorizeSecurityGroupEgress(ctx context.Context, input *ec2.AuthorizeSecurityGroupEgressInput, opts ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+	return replay[ec2.AuthorizeSecurityGroupEgressInput, ec2.AuthorizeSecurityGroupEgressOutput](c, "AuthorizeSecurityGroupEgress", input)
+}
+
+func (c *ReplayClient) RevokeSecurityGroupIngress(ctx context.Context, input *ec2.RevokeSecurityGroupIngressInput, opts ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	return replay[ec2.RevokeSecurityGroupIngressInput, ec2.RevokeSecurityGroupIngressOutput](c, "RevokeSecurityGroupIngress", input)
+}
+
+func (c *ReplayClient) RevokeSecurityGroupEgress(ctx context.Context, input *ec2.RevokeSecurityGroupEgressInput, opts ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	return replay[ec2.RevokeSecurityGroupEgressInput, ec2.RevokeSecurityGroupEgressOutput](c, "RevokeSecurityGroupEgress", input)
+}
+
+func (c *ReplayClient) DeleteSecurityGroup(ctx context.Context, input *ec2.DeleteSecurityGroupInput, opts ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error) {
+	return replay[ec2.DeleteSecurityGroupInput, ec2.DeleteSecurityGroupOutput](c, "DeleteSecurityGroup", input)
+}
+
+func (c *ReplayClient) AssociateTrunkInterface(ctx context.Context, input *ec2.AssociateTrunkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.AssociateTrunkInterfaceOutput, error) {
+	return replay[ec2.AssociateTrunkInterfaceInput, ec2.AssociateTrunkInterfaceOutput](c, "AssociateTrunkInterface", input)
+}
+
+func (c *ReplayClient) DisassociateTrunkInterface(ctx context.Context, input *ec2.DisassociateTrunkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DisassociateTrunkInterfaceOutput, error) {
+	return replay[ec2.DisassociateTrunkInterfaceInput, ec2.DisassociateTrunkInterfaceOutput](c, "DisassociateTrunkInterface", input)
+}
+
+func (c *ReplayClient) DescribeTrunkInterfaceAssociations(ctx context.Context, input *ec2.DescribeTrunkInterfaceAssociationsInput, opts ...func(*ec2.Options)) (*ec2.DescribeTrunkInterfaceAssociationsOutput, error) {
+	return replay[ec2.DescribeTrunkInterfaceAssociationsInput, ec2.DescribeTrunkInterfaceAssociationsOutput](c, "DescribeTrunkInterfaceAssociations", input)
+}
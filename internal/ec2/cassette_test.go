@@ -0,0 +1,126 @@
+package ec2
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	recorder := NewRecordingClient(mockClient)
+	manager := NewENIManager(recorder)
+
+	mockClient.EXPECT().CreateNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(&ec2.CreateNetworkInterfaceOutput{NetworkInterface: &types.NetworkInterface{
+			NetworkInterfaceId: aws.String("eni-0abcdef1234567890"),
+			SubnetId:           aws.String("subnet-1a2b3c4d"),
+			OwnerId:            aws.String("111122223333"),
+		}}, nil)
+	mockClient.EXPECT().AttachNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(nil, &smithy.GenericAPIError{
+			Code:    "InvalidInstanceID.NotFound",
+			Message: "The instance ID 'i-0fedcba9876543210' does not exist",
+		})
+
+	ctx := context.Background()
+	created, err := manager.CreateENI(ctx, ENIConfig{SubnetID: "subnet-1a2b3c4d", SecurityGroupIDs: []string{"sg-1a2b3c4d"}})
+	assert.NoError(t, err)
+	// Callers see the real IDs while recording
+	assert.Equal(t, "eni-0abcdef1234567890", aws.ToString(created.NetworkInterface.NetworkInterfaceId))
+	_, err = manager.AttachENI(ctx, "eni-0abcdef1234567890", "i-0fedcba9876543210", 1)
+	assert.Error(t, err)
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	assert.NoError(t, recorder.Save(path))
+	data, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	for _, real := range []string{"eni-0abcdef1234567890", "subnet-1a2b3c4d", "sg-1a2b3c4d", "i-0fedcba9876543210", "111122223333"} {
+		assert.NotContains(t, string(data), real)
+	}
+
+	cassette, err := LoadCassette(path)
+	if !assert.NoError(t, err) || !assert.Len(t, cassette.Interactions, 2) {
+		return
+	}
+	assert.Equal(t, "CreateNetworkInterface", cassette.Interactions[0].Action)
+	assert.Equal(t, &CassetteError{
+		Code:    "InvalidInstanceID.NotFound",
+		Message: "The instance ID 'i-00000000000000001' does not exist",
+	}, cassette.Interactions[1].Error)
+
+	// Replays take the scrubbed IDs, which keep the form of the real ones
+	player := NewReplayClient(cassette)
+	manager = NewENIManager(player)
+	created, err = manager.CreateENI(ctx, ENIConfig{SubnetID: "subnet-00000001", SecurityGroupIDs: []string{"sg-00000001"}})
+	assert.NoError(t, err)
+	assert.Equal(t, "eni-00000000000000001", aws.ToString(created.NetworkInterface.NetworkInterfaceId))
+	assert.Equal(t, "000000000001", aws.ToString(created.NetworkInterface.OwnerId))
+	_, err = manager.AttachENI(ctx, "eni-00000000000000001", "i-00000000000000001", 1)
+	assert.Equal(t, "InvalidInstanceID.NotFound", ErrorCode(err))
+	assert.Equal(t, 0, player.Remaining())
+
+	err = manager.DeleteENI(ctx, "eni-00000000000000001")
+	assert.ErrorContains(t, err, "unexpected DeleteNetworkInterface after all 2 recorded calls")
+}
+
+func TestReplayClient_Mismatch(t *testing.T) {
+	cassette := &Cassette{Interactions: []Interaction{{
+		Action:   "DeleteNetworkInterface",
+		Request:  []byte(`{"NetworkInterfaceId": "eni-00000001", "DryRun": null}`),
+		Response: []byte(`{}`),
+	}}}
+	manager := NewENIManager(NewReplayClient(cassette))
+
+	_, err := manager.DescribeSubnet(context.Background(), "subnet-00000001")
+	assert.ErrorContains(t, err, "call 0 is DescribeSubnets, want recorded DeleteNetworkInterface")
+	err = manager.DeleteENI(context.Background(), "eni-00000002")
+	assert.ErrorContains(t, err, `"NetworkInterfaceId":"eni-00000002"`)
+	assert.NoError(t, manager.DeleteENI(context.Background(), "eni-00000001"))
+}
+
+// testdata/sg-apply-create.json was recorded with --record from "sg apply"
+// creating a group, so this replays the calls as EC2 answered them
+func TestReplayCassette_ApplySecurityGroup(t *testing.T) {
+	cassette, err := LoadCassette(filepath.Join("testdata", "sg-apply-create.json"))
+	if !assert.NoError(t, err) {
+		return
+	}
+	player := NewReplayClient(cassette)
+	manager := NewENIManager(player)
+
+	changes, err := manager.ApplySecurityGroup(context.Background(), SecurityGroupSpec{
+		SecurityGroupConfig: SecurityGroupConfig{
+			Name:        "eni-web",
+			Description: "Web traffic for ENI workloads",
+			VPCID:       "vpc-00000000000000001",
+		},
+		Ingress: []SecurityGroupRule{{Protocol: "tcp", FromPort: 443, ToPort: 443, CIDR: "0.0.0.0/0"}},
+		Egress:  []SecurityGroupRule{{Protocol: "tcp", FromPort: 443, ToPort: 443, CIDR: "10.0.0.0/16"}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, &SecurityGroupChanges{
+		GroupID: "sg-00000000000000001",
+		Created: true,
+		Authorized: map[RuleDirection][]SecurityGroupRule{
+			Ingress: {{Protocol: "tcp", FromPort: 443, ToPort: 443, CIDR: "0.0.0.0/0"}},
+			Egress:  {{Protocol: "tcp", FromPort: 443, ToPort: 443, CIDR: "10.0.0.0/16"}},
+		},
+		Revoked: map[RuleDirection][]SecurityGroupRule{
+			Egress: {{Protocol: "all", CIDR: "0.0.0.0/0"}},
+		},
+	}, changes)
+	assert.Equal(t, 0, player.Remaining())
+}
@@ -0,0 +1,362 @@
+package ec2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"gopkg.in/yaml.v3"
+)
+
+// ENISpec is the expected configuration of an existing ENI. Nil fields are
+// not checked. Tags are compared exactly, except for the aws: tags EC2
+// manages itself.
+type ENISpec struct {
+	ENIID            string            `yaml:"eni_id"`
+	Description      *string           `yaml:"description,omitempty"`
+	SecurityGroupIDs []string          `yaml:"security_group_ids,omitempty"`
+	Tags             map[string]string `yaml:"tags,omitempty"`
+	// PrivateIPCount is the number of secondary private IPs
+	PrivateIPCount   *int32 `yaml:"private_ip_count,omitempty"`
+	IPv6AddressCount *int32 `yaml:"ipv6_address_count,omitempty"`
+	// InstanceID is the instance the ENI should be attached to; "" means
+	// it should be detached
+	InstanceID  *string `yaml:"instance_id,omitempty"`
+	DeviceIndex *int32  `yaml:"device_index,omitempty"`
+}
+
+// Validate checks the spec for mistakes EC2 would reject anyway
+func (s ENISpec) Validate() error {
+	if err := ValidateID("eni_id", s.ENIID, "eni-"); err != nil {
+		return err
+	}
+	if s.SecurityGroupIDs != nil && len(s.SecurityGroupIDs) == 0 {
+		return fmt.Errorf("security_group_ids: an ENI needs at least one security group")
+	}
+	if err := ValidateIDs("security_group_ids", s.SecurityGroupIDs, "sg-"); err != nil {
+		return err
+	}
+	if s.PrivateIPCount != nil && *s.PrivateIPCount < 0 {
+		return fmt.Errorf("private_ip_count must not be negative")
+	}
+	if s.IPv6AddressCount != nil && *s.IPv6AddressCount < 0 {
+		return fmt.Errorf("ipv6_address_count must not be negative")
+	}
+	if s.InstanceID != nil && *s.InstanceID != "" {
+		if err := ValidateID("instance_id", *s.InstanceID, "i-"); err != nil {
+			return err
+		}
+	}
+	if s.DeviceIndex != nil && (s.InstanceID == nil || *s.InstanceID == "") {
+		return fmt.Errorf("device_index needs an instance_id")
+	}
+	return nil
+}
+
+// LoadENISpecs reads the ENI specs in a YAML file under a top-level enis key
+func LoadENISpecs(path string) ([]ENISpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		ENIs []ENISpec `yaml:"enis"`
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("ENI spec file %s is empty", path)
+	} else if err != nil {
+		return nil, fmt.Errorf("invalid ENI spec file %s: %w", path, err)
+	}
+	for _, spec := range file.ENIs {
+		if err := spec.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: ENI %s: %w", path, spec.ENIID, err)
+		}
+	}
+	return file.ENIs, nil
+}
+
+// WriteENISpecs writes specs in the format LoadENISpecs reads
+func WriteENISpecs(w io.Writer, specs []ENISpec) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(map[string][]ENISpec{"enis": specs}); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// RecordENISpec captures the current configuration of an ENI as a spec that
+// checks every field
+func RecordENISpec(eni types.NetworkInterface) ENISpec {
+	spec := ENISpec{
+		ENIID:            aws.ToString(eni.NetworkInterfaceId),
+		Description:      aws.String(aws.ToString(eni.Description)),
+		SecurityGroupIDs: sortedGroups(eni),
+		Tags:             userTags(eni),
+		PrivateIPCount:   aws.Int32(secondaryIPCount(eni)),
+		IPv6AddressCount: aws.Int32(int32(len(eni.Ipv6Addresses))),
+		InstanceID:       aws.String(""),
+	}
+	if eni.Attachment != nil && eni.Attachment.InstanceId != nil {
+		spec.InstanceID = eni.Attachment.InstanceId
+		spec.DeviceIndex = eni.Attachment.DeviceIndex
+	}
+	return spec
+}
+
+// DriftField names the part of an ENI that drifted
+type DriftField string
+
+const (
+	DriftMissing        DriftField = "missing"
+	DriftDescription    DriftField = "description"
+	DriftSecurityGroups DriftField = "security_groups"
+	DriftTags           DriftField = "tags"
+	DriftPrivateIPs     DriftField = "private_ip_count"
+	DriftIPv6Addresses  DriftField = "ipv6_address_count"
+	DriftAttachment     DriftField = "attachment"
+)
+
+// Drift is one difference between an ENI and its spec
+type Drift struct {
+	ENIID    string     `json:"eni_id"`
+	Field    DriftField `json:"field"`
+	Expected string     `json:"expected"`
+	Actual   string     `json:"actual"`
+	// Remediable is false for drift RemediateDrift can't fix: a missing
+	// ENI or a different attachment
+	Remediable bool `json:"remediable"`
+}
+
+func (d Drift) String() string {
+	return fmt.Sprintf("%s %s: expected %q, found %q", d.ENIID, d.Field, d.Expected, d.Actual)
+}
+
+// DriftReport is the result of DetectDrift
+type DriftReport struct {
+	Checked int     `json:"checked"`
+	Drifts  []Drift `json:"drifts"`
+
+	specs map[string]ENISpec
+	live  map[string]types.NetworkInterface
+}
+
+// CompareENI returns the drift of eni from spec, in field order
+func CompareENI(spec ENISpec, eni types.NetworkInterface) []Drift {
+	var drifts []Drift
+	add := func(field DriftField, expected, actual string, remediable bool) {
+		if expected != actual {
+			drifts = append(drifts, Drift{ENIID: spec.ENIID, Field: field, Expected: expected, Actual: actual, Remediable: remediable})
+		}
+	}
+
+	if spec.Description != nil {
+		add(DriftDescription, *spec.Description, aws.ToString(eni.Description), true)
+	}
+	if spec.SecurityGroupIDs != nil {
+		expected := slices.Clone(spec.SecurityGroupIDs)
+		sort.Strings(expected)
+		add(DriftSecurityGroups, strings.Join(expected, ","), strings.Join(sortedGroups(eni), ","), true)
+	}
+	if spec.Tags != nil {
+		add(DriftTags, formatTags(spec.Tags), formatTags(userTags(eni)), true)
+	}
+	if spec.PrivateIPCount != nil {
+		add(DriftPrivateIPs, fmt.Sprint(*spec.PrivateIPCount), fmt.Sprint(secondaryIPCount(eni)), true)
+	}
+	if spec.IPv6AddressCount != nil {
+		add(DriftIPv6Addresses, fmt.Sprint(*spec.IPv6AddressCount), fmt.Sprint(len(eni.Ipv6Addresses)), true)
+	}
+	if spec.InstanceID != nil {
+		expected, actual := *spec.InstanceID, ""
+		if eni.Attachment != nil && eni.Attachment.InstanceId != nil {
+			actual = aws.ToString(eni.Attachment.InstanceId)
+			if spec.DeviceIndex != nil {
+				expected = fmt.Sprintf("%s/%d", expected, *spec.DeviceIndex)
+				actual = fmt.Sprintf("%s/%d", actual, aws.ToInt32(eni.Attachment.DeviceIndex))
+			}
+		} else if spec.DeviceIndex != nil {
+			expected = fmt.Sprintf("%s/%d", expected, *spec.DeviceIndex)
+		}
+		add(DriftAttachment, expected, actual, false)
+	}
+	return drifts
+}
+
+// DetectDrift compares the live ENIs from DescribeENIs with specs
+func (m *ENIManager) DetectDrift(ctx context.Context, specs []ENISpec) (*DriftReport, error) {
+	report := &DriftReport{
+		Checked: len(specs),
+		specs:   make(map[string]ENISpec, len(specs)),
+		live:    make(map[string]types.NetworkInterface, len(specs)),
+	}
+	if len(specs) == 0 {
+		return report, nil
+	}
+
+	ids := make([]string, 0, len(specs))
+	for _, spec := range specs {
+		ids = append(ids, spec.ENIID)
+		report.specs[spec.ENIID] = spec
+	}
+	output, err := m.DescribeENIs(ctx, []types.Filter{
+		{Name: aws.String("network-interface-id"), Values: ids},
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, eni := range output.NetworkInterfaces {
+		report.live[aws.ToString(eni.NetworkInterfaceId)] = eni
+	}
+
+	for _, spec := range specs {
+		eni, ok := report.live[spec.ENIID]
+		if !ok {
+			report.Drifts = append(report.Drifts, Drift{ENIID: spec.ENIID, Field: DriftMissing, Expected: "exists", Actual: "not found"})
+			continue
+		}
+		report.Drifts = append(report.Drifts, CompareENI(spec, eni)...)
+	}
+	return report, nil
+}
+
+// RemediateDrift brings the ENIs in report back to their specs with
+// ModifyENIAttribute, tag calls and private and IPv6 address assignment.
+// The numerically lowest addresses are kept and any surplus above them is
+// unassigned, whatever order EC2 lists them in. It keeps going past
+// failures and returns the drift it fixed with all the errors joined.
+func (m *ENIManager) RemediateDrift(ctx context.Context, report *DriftReport) ([]Drift, error) {
+	var fixed []Drift
+	var errs []error
+	for _, drift := range report.Drifts {
+		if !drift.Remediable {
+			continue
+		}
+		if err := m.remediate(ctx, report.specs[drift.ENIID], report.live[drift.ENIID], drift.Field); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s: %w", drift.ENIID, drift.Field, err))
+			continue
+		}
+		fixed = append(fixed, drift)
+	}
+	return fixed, errors.Join(errs...)
+}
+
+func (m *ENIManager) remediate(ctx context.Context, spec ENISpec, eni types.NetworkInterface, field DriftField) error {
+	switch field {
+	case DriftDescription:
+		return m.ModifyENIAttribute(ctx, spec.ENIID, ENIModifyConfig{Description: spec.Description})
+
+	case DriftSecurityGroups:
+		return m.ModifyENIAttribute(ctx, spec.ENIID, ENIModifyConfig{SecurityGroupIDs: spec.SecurityGroupIDs})
+
+	case DriftTags:
+		actual := userTags(eni)
+		set := make(map[string]string)
+		for k, v := range spec.Tags {
+			if value, ok := actual[k]; !ok || value != v {
+				set[k] = v
+			}
+		}
+		var remove []string
+		for k := range actual {
+			if _, ok := spec.Tags[k]; !ok {
+				remove = append(remove, k)
+			}
+		}
+		sort.Strings(remove)
+		if len(set) > 0 {
+			if err := m.TagENI(ctx, spec.ENIID, set); err != nil {
+				return err
+			}
+		}
+		if len(remove) > 0 {
+			return m.UntagENI(ctx, spec.ENIID, remove)
+		}
+		return nil
+
+	case DriftPrivateIPs:
+		var secondary []string
+		for _, addr := range eni.PrivateIpAddresses {
+			if !aws.ToBool(addr.Primary) {
+				secondary = append(secondary, aws.ToString(addr.PrivateIpAddress))
+			}
+		}
+		diff := *spec.PrivateIPCount - int32(len(secondary))
+		if diff > 0 {
+			return m.AssignPrivateIPs(ctx, spec.ENIID, diff, nil)
+		}
+		sortAddresses(secondary)
+		return m.UnassignPrivateIPs(ctx, spec.ENIID, secondary[len(secondary)+int(diff):])
+
+	case DriftIPv6Addresses:
+		var addresses []string
+		for _, addr := range eni.Ipv6Addresses {
+			addresses = append(addresses, aws.ToString(addr.Ipv6Address))
+		}
+		diff := *spec.IPv6AddressCount - int32(len(addresses))
+		if diff > 0 {
+			return m.AssignIPv6Addresses(ctx, spec.ENIID, nil, &diff)
+		}
+		sortAddresses(addresses)
+		return m.UnassignIPv6Addresses(ctx, spec.ENIID, addresses[len(addresses)+int(diff):])
+	}
+	return fmt.Errorf("drift in %s can't be remediated", field)
+}
+
+// sortAddresses sorts IP addresses numerically, so 10.0.1.9 comes before
+// 10.0.1.10
+func sortAddresses(addrs []string) {
+	slices.SortFunc(addrs, func(a, b string) int {
+		x, errX := netip.ParseAddr(a)
+		y, errY := netip.ParseAddr(b)
+		if errX != nil || errY != nil {
+			return strings.Compare(a, b)
+		}
+		return x.Compare(y)
+	})
+}
+
+// sortedGroups returns the ENI's security groups, sorted
+func sortedGroups(eni types.NetworkInterface) []string {
+	groups := eniGroups(eni)
+	sort.Strings(groups)
+	return groups
+}
+
+// userTags returns the ENI's tags without the aws: ones, which can't be changed
+func userTags(eni types.NetworkInterface) map[string]string {
+	tags := make(map[string]string)
+	for _, tag := range eni.TagSet {
+		if key := aws.ToString(tag.Key); !strings.HasPrefix(key, "aws:") {
+			tags[key] = aws.ToString(tag.Value)
+		}
+	}
+	return tags
+}
+
+// secondaryIPCount returns the number of private IPs besides the primary
+func secondaryIPCount(eni types.NetworkInterface) int32 {
+	return max(int32(len(eni.PrivateIpAddresses))-1, 0)
+}
+
+// formatTags renders tags as sorted key=value pairs
+func formatTags(tags map[string]string) string {
+	pairs := make([]string, 0, len(tags))
+	for k, v := range tags {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
@@ -0,0 +1,184 @@
+package ec2
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// driftedENI has drifted from driftSpec in everything but its attachment
+var driftedENI = types.NetworkInterface{
+	NetworkInterfaceId: aws.String("eni-12345678"),
+	Description:        aws.String("edited in the console"),
+	Groups:             []types.GroupIdentifier{{GroupId: aws.String("sg-bbbbbbbb")}},
+	TagSet: []types.Tag{
+		{Key: aws.String("Name"), Value: aws.String("web")},
+		{Key: aws.String("Owner"), Value: aws.String("someone")},
+		{Key: aws.String("aws:cloudformation:stack-name"), Value: aws.String("stack")},
+	},
+	PrivateIpAddresses: []types.NetworkInterfacePrivateIpAddress{
+		{PrivateIpAddress: aws.String("10.0.1.10"), Primary: aws.Bool(true)},
+		{PrivateIpAddress: aws.String("10.0.1.11"), Primary: aws.Bool(false)},
+		{PrivateIpAddress: aws.String("10.0.1.12"), Primary: aws.Bool(false)},
+	},
+	Attachment: &types.NetworkInterfaceAttachment{InstanceId: aws.String("i-12345678"), DeviceIndex: aws.Int32(1)},
+}
+
+var driftSpec = ENISpec{
+	ENIID:            "eni-12345678",
+	Description:      aws.String("web ENI"),
+	SecurityGroupIDs: []string{"sg-aaaaaaaa"},
+	Tags:             map[string]string{"Name": "web", "Team": "payments"},
+	PrivateIPCount:   aws.Int32(1),
+	IPv6AddressCount: aws.Int32(1),
+	InstanceID:       aws.String("i-12345678"),
+	DeviceIndex:      aws.Int32(1),
+}
+
+func TestCompareENI(t *testing.T) {
+	drifts := CompareENI(driftSpec, driftedENI)
+
+	var fields []DriftField
+	for _, d := range drifts {
+		fields = append(fields, d.Field)
+		assert.True(t, d.Remediable)
+	}
+	assert.Equal(t, []DriftField{DriftDescription, DriftSecurityGroups, DriftTags, DriftPrivateIPs, DriftIPv6Addresses}, fields)
+	assert.Equal(t, "Name=web,Team=payments", drifts[2].Expected)
+	assert.Equal(t, "Name=web,Owner=someone", drifts[2].Actual)
+
+	// A recorded spec has no drift
+	assert.Empty(t, CompareENI(RecordENISpec(driftedENI), driftedENI))
+
+	moved := driftedENI
+	moved.Attachment = &types.NetworkInterfaceAttachment{InstanceId: aws.String("i-87654321"), DeviceIndex: aws.Int32(1)}
+	drifts = CompareENI(ENISpec{ENIID: "eni-12345678", InstanceID: aws.String("i-12345678")}, moved)
+	if assert.Len(t, drifts, 1) {
+		assert.Equal(t, DriftAttachment, drifts[0].Field)
+		assert.False(t, drifts[0].Remediable)
+	}
+}
+
+func TestRemediateDrift(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []types.NetworkInterface{driftedENI}}, nil)
+
+	report, err := manager.DetectDrift(context.Background(), []ENISpec{driftSpec, {ENIID: "eni-87654321"}})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, report.Checked)
+	if !assert.Len(t, report.Drifts, 6) {
+		return
+	}
+	assert.Equal(t, DriftMissing, report.Drifts[5].Field)
+
+	mockClient.EXPECT().ModifyNetworkInterfaceAttribute(gomock.Any(), gomock.Eq(&ec2.ModifyNetworkInterfaceAttributeInput{
+		NetworkInterfaceId: aws.String("eni-12345678"),
+		Description:        &types.AttributeValue{Value: aws.String("web ENI")},
+	})).Return(&ec2.ModifyNetworkInterfaceAttributeOutput{}, nil)
+	mockClient.EXPECT().ModifyNetworkInterfaceAttribute(gomock.Any(), gomock.Eq(&ec2.ModifyNetworkInterfaceAttributeInput{
+		NetworkInterfaceId: aws.String("eni-12345678"),
+		Groups:             []string{"sg-aaaaaaaa"},
+	})).Return(&ec2.ModifyNetworkInterfaceAttributeOutput{}, nil)
+	mockClient.EXPECT().CreateTags(gomock.Any(), gomock.Eq(&ec2.CreateTagsInput{
+		Resources: []string{"eni-12345678"},
+		Tags:      []types.Tag{{Key: aws.String("Team"), Value: aws.String("payments")}},
+	})).Return(&ec2.CreateTagsOutput{}, nil)
+	mockClient.EXPECT().DeleteTags(gomock.Any(), gomock.Eq(&ec2.DeleteTagsInput{
+		Resources: []string{"eni-12345678"},
+		Tags:      []types.Tag{{Key: aws.String("Owner")}},
+	})).Return(&ec2.DeleteTagsOutput{}, nil)
+	mockClient.EXPECT().UnassignPrivateIpAddresses(gomock.Any(), gomock.Eq(&ec2.UnassignPrivateIpAddressesInput{
+		NetworkInterfaceId: aws.String("eni-12345678"),
+		PrivateIpAddresses: []string{"10.0.1.12"},
+	})).Return(&ec2.UnassignPrivateIpAddressesOutput{}, nil)
+	mockClient.EXPECT().AssignIpv6Addresses(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *ec2.AssignIpv6AddressesInput, _ ...func(*ec2.Options)) (*ec2.AssignIpv6AddressesOutput, error) {
+			assert.Equal(t, int32(1), aws.ToInt32(input.Ipv6AddressCount))
+			return &ec2.AssignIpv6AddressesOutput{}, nil
+		})
+
+	fixed, err := manager.RemediateDrift(context.Background(), report)
+	assert.NoError(t, err)
+	assert.Len(t, fixed, 5)
+}
+
+func TestENISpecs_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "enis.yaml")
+	f, err := os.Create(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, WriteENISpecs(f, []ENISpec{RecordENISpec(driftedENI)}))
+	f.Close()
+
+	specs, err := LoadENISpecs(path)
+	assert.NoError(t, err)
+	assert.Equal(t, []ENISpec{RecordENISpec(driftedENI)}, specs)
+
+	assert.NoError(t, os.WriteFile(path, []byte("enis:\n  - eni_id: eni-1\n    device_index: 1\n"), 0o644))
+	_, err = LoadENISpecs(path)
+	assert.ErrorContains(t, err, "device_index needs an instance_id")
+
+	assert.NoError(t, os.WriteFile(path, []byte("# enis:\n"), 0o644))
+	_, err = LoadENISpecs(path)
+	assert.ErrorContains(t, err, "is empty")
+}
+
+func TestRemediateDrift_UnassignsHighestAddresses(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	// Listed out of order, and 10.0.1.9 sorts after 10.0.1.12 as a string
+	eni := types.NetworkInterface{
+		NetworkInterfaceId: aws.String("eni-12345678"),
+		PrivateIpAddresses: []types.NetworkInterfacePrivateIpAddress{
+			{PrivateIpAddress: aws.String("10.0.1.10"), Primary: aws.Bool(true)},
+			{PrivateIpAddress: aws.String("10.0.1.100"), Primary: aws.Bool(false)},
+			{PrivateIpAddress: aws.String("10.0.1.9"), Primary: aws.Bool(false)},
+			{PrivateIpAddress: aws.String("10.0.1.12"), Primary: aws.Bool(false)},
+		},
+		Ipv6Addresses: []types.NetworkInterfaceIpv6Address{
+			{Ipv6Address: aws.String("2001:db8::20")},
+			{Ipv6Address: aws.String("2001:db8::3")},
+		},
+	}
+	mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []types.NetworkInterface{eni}}, nil)
+
+	report, err := manager.DetectDrift(context.Background(), []ENISpec{{
+		ENIID:            "eni-12345678",
+		PrivateIPCount:   aws.Int32(1),
+		IPv6AddressCount: aws.Int32(1),
+	}})
+	assert.NoError(t, err)
+
+	mockClient.EXPECT().UnassignPrivateIpAddresses(gomock.Any(), gomock.Eq(&ec2.UnassignPrivateIpAddressesInput{
+		NetworkInterfaceId: aws.String("eni-12345678"),
+		PrivateIpAddresses: []string{"10.0.1.12", "10.0.1.100"},
+	})).Return(&ec2.UnassignPrivateIpAddressesOutput{}, nil)
+	mockClient.EXPECT().UnassignIpv6Addresses(gomock.Any(), gomock.Eq(&ec2.UnassignIpv6AddressesInput{
+		NetworkInterfaceId: aws.String("eni-12345678"),
+		Ipv6Addresses:      []string{"2001:db8::20"},
+	})).Return(&ec2.UnassignIpv6AddressesOutput{}, nil)
+
+	fixed, err := manager.RemediateDrift(context.Background(), report)
+	assert.NoError(t, err)
+	assert.Len(t, fixed, 2)
+}
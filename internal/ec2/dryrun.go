@@ -0,0 +1,484 @@
+package ec2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// DryRunMode selects how mutations are previewed
+type DryRunMode string
+
+const (
+	// DryRunOff sends mutations to AWS as usual
+	DryRunOff DryRunMode = ""
+	// DryRunAWS sends mutations with the EC2 DryRun flag, so AWS checks
+	// permissions and parameters without changing anything
+	DryRunAWS DryRunMode = "aws"
+	// DryRunOffline validates mutations locally and never contacts AWS
+	DryRunOffline DryRunMode = "offline"
+)
+
+// ParseDryRunMode parses the --dry-run flag value
+func ParseDryRunMode(s string) (DryRunMode, error) {
+	switch mode := DryRunMode(s); mode {
+	case DryRunOff, DryRunAWS, DryRunOffline:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid dry-run mode %q (want aws or offline)", s)
+	}
+}
+
+// Placeholder IDs returned for resources a dry run would have created
+const (
+	DryRunENIID           = "eni-00000000000000000"
+	DryRunAttachmentID    = "eni-attach-00000000000000000"
+	DryRunSecurityGroupID = "sg-00000000000000000"
+	DryRunAssociationID   = "trunk-assoc-00000000000000000"
+)
+
+// Dry run results reported for each previewed request
+const (
+	DryRunWouldSucceed     = "would succeed"
+	DryRunPermissionDenied = "permission denied"
+	DryRunFailed           = "failed"
+)
+
+// DryRunReport describes one previewed request, written as a JSON line
+type DryRunReport struct {
+	Mode   DryRunMode `json:"mode"`
+	Action string     `json:"action"`
+	Input  any        `json:"input"`
+	Result string     `json:"result"`
+	Error  string     `json:"error,omitempty"`
+}
+
+// DryRunClient is an EC2ClientAPI that previews mutations instead of making
+// them. Each mutation is reported to out and, when it would succeed, returns
+// a placeholder output so multi-step workflows can continue. Describe calls
+// pass through in DryRunAWS mode and return empty results offline.
+//
+// EC2 has no DryRun flag for the IP assign and unassign actions, so those are
+// validated locally in both modes.
+type DryRunClient struct {
+	client EC2ClientAPI
+	mode   DryRunMode
+
+	mu  sync.Mutex
+	out io.Writer
+}
+
+var _ EC2ClientAPI = (*DryRunClient)(nil)
+
+func NewDryRunClient(client EC2ClientAPI, mode DryRunMode, out io.Writer) *DryRunClient {
+	return &DryRunClient{client: client, mode: mode, out: out}
+}
+
+// WithDryRun previews every mutation through a DryRunClient reporting to
+// out. Hooks and the audit log are skipped since nothing changes.
+func WithDryRun(mode DryRunMode, out io.Writer) Option {
+	return func(m *ENIManager) {
+		if mode == DryRunOff {
+			return
+		}
+		m.client = NewDryRunClient(m.client, mode, out)
+		m.dryRun = mode
+	}
+}
+
+// DryRun returns the manager's dry-run mode
+func (m *ENIManager) DryRun() DryRunMode {
+	return m.dryRun
+}
+
+func (c *DryRunClient) report(action string, input any, err error) {
+	rep := DryRunReport{Mode: c.mode, Action: action, Input: input, Result: DryRunWouldSucceed}
+	switch code := ErrorCode(err); {
+	case err == nil:
+	case code == "UnauthorizedOperation":
+		rep.Result, rep.Error = DryRunPermissionDenied, err.Error()
+	default:
+		rep.Result, rep.Error = DryRunFailed, err.Error()
+	}
+
+	data, jsonErr := json.Marshal(rep)
+	if jsonErr != nil || c.out == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.out.Write(append(data, '\n'))
+}
+
+// preview runs one mutation in dry-run mode. call sends the request with
+// the DryRun flag set and is nil when EC2 has no such flag for the action.
+func preview[Out any](c *DryRunClient, action string, input any, validate func() error, call func() (*Out, error), placeholder *Out) (*Out, error) {
+	var err error
+	if c.mode == DryRunAWS && call != nil {
+		if _, err = call(); ErrorCode(err) == "DryRunOperation" {
+			err = nil
+		}
+	} else {
+		err = validate()
+	}
+
+	c.report(action, input, err)
+	switch {
+	case err == nil:
+		return placeholder, nil
+	case ErrorCode(err) == "UnauthorizedOperation":
+		return nil, fmt.Errorf("dry run: missing permission for %s: %w", action, err)
+	default:
+		return nil, fmt.Errorf("dry run: %s would fail: %w", action, err)
+	}
+}
+
+func validateENIID(input *string) func() error {
+	return func() error {
+		return ValidateID("NetworkInterfaceId", aws.ToString(input), "eni-")
+	}
+}
+
+func (c *DryRunClient) CreateNetworkInterface(ctx context.Context, input *ec2.CreateNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.CreateNetworkInterfaceOutput, error) {
+	validate := func() error {
+		return ENIConfig{
+			SubnetID:         aws.ToString(input.SubnetId),
+			SecurityGroupIDs: input.Groups,
+			PrivateIPAddress: aws.ToString(input.PrivateIpAddress),
+			PrivateIPCount:   aws.ToInt32(input.SecondaryPrivateIpAddressCount),
+			IPv6AddressCount: aws.ToInt32(input.Ipv6AddressCount),
+			InterfaceType:    input.InterfaceType,
+		}.Validate()
+	}
+	call := func() (*ec2.CreateNetworkInterfaceOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.CreateNetworkInterface(ctx, &in, opts...)
+	}
+
+	eni := &types.NetworkInterface{
+		NetworkInterfaceId: aws.String(DryRunENIID),
+		SubnetId:           input.SubnetId,
+		Description:        input.Description,
+		PrivateIpAddress:   input.PrivateIpAddress,
+		Status:             types.NetworkInterfaceStatusAvailable,
+		InterfaceType:      types.NetworkInterfaceType(input.InterfaceType),
+	}
+	for _, group := range input.Groups {
+		eni.Groups = append(eni.Groups, types.GroupIdentifier{GroupId: aws.String(group)})
+	}
+	for _, spec := range input.TagSpecifications {
+		eni.TagSet = append(eni.TagSet, spec.Tags...)
+	}
+	return preview(c, "CreateNetworkInterface", input, validate, call, &ec2.CreateNetworkInterfaceOutput{NetworkInterface: eni})
+}
+
+func (c *DryRunClient) AttachNetworkInterface(ctx context.Context, input *ec2.AttachNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.AttachNetworkInterfaceOutput, error) {
+	validate := func() error {
+		if err := validateENIID(input.NetworkInterfaceId)(); err != nil {
+			return err
+		}
+		if err := ValidateID("InstanceId", aws.ToString(input.InstanceId), "i-"); err != nil {
+			return err
+		}
+		if aws.ToInt32(input.DeviceIndex) < 1 {
+			return fmt.Errorf("DeviceIndex must be at least 1")
+		}
+		if aws.ToInt32(input.NetworkCardIndex) < 0 {
+			return fmt.Errorf("NetworkCardIndex must not be negative")
+		}
+		return nil
+	}
+	call := func() (*ec2.AttachNetworkInterfaceOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.AttachNetworkInterface(ctx, &in, opts...)
+	}
+	return preview(c, "AttachNetworkInterface", input, validate, call,
+		&ec2.AttachNetworkInterfaceOutput{AttachmentId: aws.String(DryRunAttachmentID)})
+}
+
+func (c *DryRunClient) DeleteNetworkInterface(ctx context.Context, input *ec2.DeleteNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DeleteNetworkInterfaceOutput, error) {
+	call := func() (*ec2.DeleteNetworkInterfaceOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.DeleteNetworkInterface(ctx, &in, opts...)
+	}
+	return preview(c, "DeleteNetworkInterface", input, validateENIID(input.NetworkInterfaceId), call,
+		&ec2.DeleteNetworkInterfaceOutput{})
+}
+
+func (c *DryRunClient) DetachNetworkInterface(ctx context.Context, input *ec2.DetachNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DetachNetworkInterfaceOutput, error) {
+	validate := func() error {
+		return ValidateID("AttachmentId", aws.ToString(input.AttachmentId), "eni-attach-")
+	}
+	call := func() (*ec2.DetachNetworkInterfaceOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.DetachNetworkInterface(ctx, &in, opts...)
+	}
+	return preview(c, "DetachNetworkInterface", input, validate, call, &ec2.DetachNetworkInterfaceOutput{})
+}
+
+func (c *DryRunClient) ModifyNetworkInterfaceAttribute(ctx context.Context, input *ec2.ModifyNetworkInterfaceAttributeInput, opts ...func(*ec2.Options)) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+	validate := func() error {
+		if err := validateENIID(input.NetworkInterfaceId)(); err != nil {
+			return err
+		}
+		return ValidateIDs("Groups", input.Groups, "sg-")
+	}
+	call := func() (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.ModifyNetworkInterfaceAttribute(ctx, &in, opts...)
+	}
+	return preview(c, "ModifyNetworkInterfaceAttribute", input, validate, call, &ec2.ModifyNetworkInterfaceAttributeOutput{})
+}
+
+func (c *DryRunClient) AssignPrivateIpAddresses(ctx context.Context, input *ec2.AssignPrivateIpAddressesInput, opts ...func(*ec2.Options)) (*ec2.AssignPrivateIpAddressesOutput, error) {
+	validate := func() error {
+		if err := validateENIID(input.NetworkInterfaceId)(); err != nil {
+			return err
+		}
+		if aws.ToInt32(input.SecondaryPrivateIpAddressCount) == 0 && len(input.PrivateIpAddresses) == 0 {
+			return fmt.Errorf("one of SecondaryPrivateIpAddressCount or PrivateIpAddresses is required")
+		}
+		return ValidateAddresses(input.PrivateIpAddresses, false)
+	}
+	return preview(c, "AssignPrivateIpAddresses", input, validate, nil, &ec2.AssignPrivateIpAddressesOutput{})
+}
+
+func (c *DryRunClient) UnassignPrivateIpAddresses(ctx context.Context, input *ec2.UnassignPrivateIpAddressesInput, opts ...func(*ec2.Options)) (*ec2.UnassignPrivateIpAddressesOutput, error) {
+	validate := func() error {
+		if err := validateENIID(input.NetworkInterfaceId)(); err != nil {
+			return err
+		}
+		if len(input.PrivateIpAddresses) == 0 {
+			return fmt.Errorf("PrivateIpAddresses is required")
+		}
+		return ValidateAddresses(input.PrivateIpAddresses, false)
+	}
+	return preview(c, "UnassignPrivateIpAddresses", input, validate, nil, &ec2.UnassignPrivateIpAddressesOutput{})
+}
+
+func (c *DryRunClient) AssignIpv6Addresses(ctx context.Context, input *ec2.AssignIpv6AddressesInput, opts ...func(*ec2.Options)) (*ec2.AssignIpv6AddressesOutput, error) {
+	validate := func() error {
+		if err := validateENIID(input.NetworkInterfaceId)(); err != nil {
+			return err
+		}
+		if aws.ToInt32(input.Ipv6AddressCount) == 0 && len(input.Ipv6Addresses) == 0 {
+			return fmt.Errorf("one of Ipv6AddressCount or Ipv6Addresses is required")
+		}
+		return ValidateAddresses(input.Ipv6Addresses, true)
+	}
+	return preview(c, "AssignIpv6Addresses", input, validate, nil, &ec2.AssignIpv6AddressesOutput{})
+}
+
+func (c *DryRunClient) UnassignIpv6Addresses(ctx context.Context, input *ec2.UnassignIpv6AddressesInput, opts ...func(*ec2.Options)) (*ec2.UnassignIpv6AddressesOutput, error) {
+	validate := func() error {
+		if err := validateENIID(input.NetworkInterfaceId)(); err != nil {
+			return err
+		}
+		if len(input.Ipv6Addresses) == 0 {
+			return fmt.Errorf("Ipv6Addresses is required")
+		}
+		return ValidateAddresses(input.Ipv6Addresses, true)
+	}
+	return preview(c, "UnassignIpv6Addresses", input, validate, nil, &ec2.UnassignIpv6AddressesOutput{})
+}
+
+func (c *DryRunClient) CreateTags(ctx context.Context, input *ec2.CreateTagsInput, opts ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+	validate := func() error {
+		if len(input.Resources) == 0 || len(input.Tags) == 0 {
+			return fmt.Errorf("Resources and Tags are required")
+		}
+		return nil
+	}
+	call := func() (*ec2.CreateTagsOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.CreateTags(ctx, &in, opts...)
+	}
+	return preview(c, "CreateTags", input, validate, call, &ec2.CreateTagsOutput{})
+}
+
+func (c *DryRunClient) DeleteTags(ctx context.Context, input *ec2.DeleteTagsInput, opts ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error) {
+	validate := func() error {
+		if len(input.Resources) == 0 {
+			return fmt.Errorf("Resources is required")
+		}
+		return nil
+	}
+	call := func() (*ec2.DeleteTagsOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.DeleteTags(ctx, &in, opts...)
+	}
+	return preview(c, "DeleteTags", input, validate, call, &ec2.DeleteTagsOutput{})
+}
+
+func (c *DryRunClient) CreateSecurityGroup(ctx context.Context, input *ec2.CreateSecurityGroupInput, opts ...func(*ec2.Options)) (*ec2.CreateSecurityGroupOutput, error) {
+	validate := func() error {
+		if aws.ToString(input.GroupName) == "" || aws.ToString(input.Description) == "" {
+			return fmt.Errorf("GroupName and Description are required")
+		}
+		return nil
+	}
+	call := func() (*ec2.CreateSecurityGroupOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.CreateSecurityGroup(ctx, &in, opts...)
+	}
+	return preview(c, "CreateSecurityGroup", input, validate, call,
+		&ec2.CreateSecurityGroupOutput{GroupId: aws.String(DryRunSecurityGroupID)})
+}
+
+func validatePermissions(groupID *string, permissions []types.IpPermission) func() error {
+	return func() error {
+		if err := ValidateID("GroupId", aws.ToString(groupID), "sg-"); err != nil {
+			return err
+		}
+		if len(permissions) == 0 {
+			return fmt.Errorf("IpPermissions is required")
+		}
+		return nil
+	}
+}
+
+func (c *DryRunClient) AuthorizeSecurityGroupIngress(ctx context.Context, input *ec2.AuthorizeSecurityGroupIngressInput, opts ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	call := func() (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.AuthorizeSecurityGroupIngress(ctx, &in, opts...)
+	}
+	return preview(c, "AuthorizeSecurityGroupIngress", input, validatePermissions(input.GroupId, input.IpPermissions), call,
+		&ec2.AuthorizeSecurityGroupIngressOutput{Return: aws.Bool(true)})
+}
+
+func (c *DryRunClient) AuthorizeSecurityGroupEgress(ctx context.Context, input *ec2.AuthorizeSecurityGroupEgressInput, opts ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+	call := func() (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.AuthorizeSecurityGroupEgress(ctx, &in, opts...)
+	}
+	return preview(c, "AuthorizeSecurityGroupEgress", input, validatePermissions(input.GroupId, input.IpPermissions), call,
+		&ec2.AuthorizeSecurityGroupEgressOutput{Return: aws.Bool(true)})
+}
+
+func (c *DryRunClient) RevokeSecurityGroupIngress(ctx context.Context, input *ec2.RevokeSecurityGroupIngressInput, opts ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	call := func() (*ec2.RevokeSecurityGroupIngressOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.RevokeSecurityGroupIngress(ctx, &in, opts...)
+	}
+	return preview(c, "RevokeSecurityGroupIngress", input, validatePermissions(input.GroupId, input.IpPermissions), call,
+		&ec2.RevokeSecurityGroupIngressOutput{Return: aws.Bool(true)})
+}
+
+func (c *DryRunClient) RevokeSecurityGroupEgress(ctx context.Context, input *ec2.RevokeSecurityGroupEgressInput, opts ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	call := func() (*ec2.RevokeSecurityGroupEgressOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.RevokeSecurityGroupEgress(ctx, &in, opts...)
+	}
+	return preview(c, "RevokeSecurityGroupEgress", input, validatePermissions(input.GroupId, input.IpPermissions), call,
+		&ec2.RevokeSecurityGroupEgressOutput{Return: aws.Bool(true)})
+}
+
+func (c *DryRunClient) DeleteSecurityGroup(ctx context.Context, input *ec2.DeleteSecurityGroupInput, opts ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error) {
+	validate := func() error {
+		return ValidateID("GroupId", aws.ToString(input.GroupId), "sg-")
+	}
+	call := func() (*ec2.DeleteSecurityGroupOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.DeleteSecurityGroup(ctx, &in, opts...)
+	}
+	return preview(c, "DeleteSecurityGroup", input, validate, call, &ec2.DeleteSecurityGroupOutput{})
+}
+
+func (c *DryRunClient) AssociateTrunkInterface(ctx context.Context, input *ec2.AssociateTrunkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.AssociateTrunkInterfaceOutput, error) {
+	validate := func() error {
+		if err := ValidateID("TrunkInterfaceId", aws.ToString(input.TrunkInterfaceId), "eni-"); err != nil {
+			return err
+		}
+		if err := validateENIID(input.BranchInterfaceId)(); err != nil {
+			return err
+		}
+		return ValidateVLAN(aws.ToInt32(input.VlanId))
+	}
+	call := func() (*ec2.AssociateTrunkInterfaceOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.AssociateTrunkInterface(ctx, &in, opts...)
+	}
+	return preview(c, "AssociateTrunkInterface", input, validate, call,
+		&ec2.AssociateTrunkInterfaceOutput{InterfaceAssociation: &types.TrunkInterfaceAssociation{
+			AssociationId:     aws.String(DryRunAssociationID),
+			TrunkInterfaceId:  input.TrunkInterfaceId,
+			BranchInterfaceId: input.BranchInterfaceId,
+			VlanId:            input.VlanId,
+			InterfaceProtocol: types.InterfaceProtocolTypeVlan,
+		}})
+}
+
+func (c *DryRunClient) DisassociateTrunkInterface(ctx context.Context, input *ec2.DisassociateTrunkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DisassociateTrunkInterfaceOutput, error) {
+	validate := func() error {
+		return ValidateID("AssociationId", aws.ToString(input.AssociationId), "trunk-assoc-")
+	}
+	call := func() (*ec2.DisassociateTrunkInterfaceOutput, error) {
+		in := *input
+		in.DryRun = aws.Bool(true)
+		return c.client.DisassociateTrunkInterface(ctx, &in, opts...)
+	}
+	return preview(c, "DisassociateTrunkInterface", input, validate, call,
+		&ec2.DisassociateTrunkInterfaceOutput{Return: aws.Bool(true)})
+}
+
+func (c *DryRunClient) DescribeInstances(ctx context.Context, input *ec2.DescribeInstancesInput, opts ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	if c.mode == DryRunOffline {
+		return &ec2.DescribeInstancesOutput{}, nil
+	}
+	return c.client.DescribeInstances(ctx, input, opts...)
+}
+
+func (c *DryRunClient) DescribeInstanceTypes(ctx context.Context, input *ec2.DescribeInstanceTypesInput, opts ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+	if c.mode == DryRunOffline {
+		return &ec2.DescribeInstanceTypesOutput{}, nil
+	}
+	return c.client.DescribeInstanceTypes(ctx, input, opts...)
+}
+
+func (c *DryRunClient) DescribeNetworkInterfaces(ctx context.Context, input *ec2.DescribeNetworkInterfacesInput, opts ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	if c.mode == DryRunOffline {
+		return &ec2.DescribeNetworkInterfacesOutput{}, nil
+	}
+	return c.client.DescribeNetworkInterfaces(ctx, input, opts...)
+}
+
+func (c *DryRunClient) DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput, opts ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	if c.mode == DryRunOffline {
+		return &ec2.DescribeSubnetsOutput{}, nil
+	}
+	return c.client.DescribeSubnets(ctx, input, opts...)
+}
+
+func (c *DryRunClient) DescribeSecurityGroups(ctx context.Context, input *ec2.DescribeSecurityGroupsInput, opts ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	if c.mode == DryRunOffline {
+		return &ec2.DescribeSecurityGroupsOutput{}, nil
+	}
+	return c.client.DescribeSecurityGroups(ctx, input, opts...)
+}
+
+func (c *DryRunClient) DescribeTrunkInterfaceAssociations(ctx context.Context, input *ec2.DescribeTrunkInterfaceAssociationsInput, opts ...func(*ec2.Options)) (*ec2.DescribeTrunkInterfaceAssociationsOutput, error) {
+	if c.mode == DryRunOffline {
+		return &ec2.DescribeTrunkInterfaceAssociationsOutput{}, nil
+	}
+	return c.client.DescribeTrunkInterfaceAssociations(ctx, input, opts...)
+}
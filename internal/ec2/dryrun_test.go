@@ -0,0 +1,106 @@
+// internal/ec2/dryrun_test.go
+package ec2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeReports(t *testing.T, buf *bytes.Buffer) []DryRunReport {
+	t.Helper()
+
+	var reports []DryRunReport
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var rep DryRunReport
+		assert.NoError(t, dec.Decode(&rep))
+		reports = append(reports, rep)
+	}
+	return reports
+}
+
+func TestDryRun_AWS(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var out bytes.Buffer
+	hook := &recordingHook{}
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient, WithDryRun(DryRunAWS, &out), WithHooks(HookRule{Name: "dns", Hook: hook}))
+
+	mockClient.EXPECT().
+		CreateNetworkInterface(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *ec2.CreateNetworkInterfaceInput, _ ...func(*ec2.Options)) (*ec2.CreateNetworkInterfaceOutput, error) {
+			assert.True(t, aws.ToBool(input.DryRun))
+			return nil, &smithy.GenericAPIError{Code: "DryRunOperation"}
+		})
+	mockClient.EXPECT().
+		DeleteNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(nil, &smithy.GenericAPIError{Code: "UnauthorizedOperation"})
+
+	ctx := context.Background()
+	output, err := manager.CreateENI(ctx, ENIConfig{SubnetID: "subnet-12345678", Description: "preview"})
+	assert.NoError(t, err)
+	assert.Equal(t, DryRunENIID, *output.NetworkInterface.NetworkInterfaceId)
+	assert.Equal(t, "subnet-12345678", *output.NetworkInterface.SubnetId)
+
+	err = manager.DeleteENI(ctx, "eni-12345678")
+	assert.ErrorContains(t, err, "missing permission for DeleteNetworkInterface")
+	assert.Equal(t, "UnauthorizedOperation", ErrorCode(err))
+
+	// IP assignment has no EC2 DryRun flag and is only validated
+	assert.NoError(t, manager.AssignPrivateIPs(ctx, "eni-12345678", 2, nil))
+
+	reports := decodeReports(t, &out)
+	assert.Len(t, reports, 3)
+	assert.Equal(t, DryRunWouldSucceed, reports[0].Result)
+	assert.Equal(t, "CreateNetworkInterface", reports[0].Action)
+	assert.Equal(t, DryRunPermissionDenied, reports[1].Result)
+	assert.Equal(t, DryRunWouldSucceed, reports[2].Result)
+	assert.Empty(t, hook.events)
+}
+
+func TestDryRun_Offline(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Offline dry runs never reach the client
+	var out bytes.Buffer
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient, WithDryRun(DryRunOffline, &out))
+
+	ctx := context.Background()
+	attachmentID, err := manager.AttachENI(ctx, "eni-12345678", "i-12345678", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, DryRunAttachmentID, *attachmentID)
+
+	err = manager.UnassignIPv6Addresses(ctx, "eni-12345678", []string{"10.0.0.1"})
+	assert.ErrorContains(t, err, "is not an IPv6 address")
+
+	output, err := manager.DescribeENIs(ctx, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, output.NetworkInterfaces)
+
+	reports := decodeReports(t, &out)
+	assert.Len(t, reports, 2)
+	assert.Equal(t, DryRunOffline, reports[0].Mode)
+	assert.Equal(t, DryRunFailed, reports[1].Result)
+}
+
+func TestParseDryRunMode(t *testing.T) {
+	mode, err := ParseDryRunMode("offline")
+	assert.NoError(t, err)
+	assert.Equal(t, DryRunOffline, mode)
+
+	_, err = ParseDryRunMode("yes")
+	assert.Error(t, err)
+}
@@ -0,0 +1,169 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// ErrEFAUnsupported is returned when an instance type has no EFA support
+var ErrEFAUnsupported = errors.New("instance type does not support EFA")
+
+// IsEFA reports whether an interface type is an Elastic Fabric Adapter
+func IsEFA(interfaceType string) bool {
+	return interfaceType == string(types.NetworkInterfaceTypeEfa) ||
+		interfaceType == string(types.NetworkInterfaceTypeEfaOnly)
+}
+
+// EFACapacity is the EFA support of an instance and the EFAs attached to it
+type EFACapacity struct {
+	InstanceID   string
+	InstanceType string
+	// NetworkCards is the number of network cards of the instance type
+	NetworkCards int32
+	// Limit is the maximum number of EFAs the instance can have
+	Limit int32
+	// EFAs are the IDs of the attached EFA ENIs by network card index
+	EFAs map[int32][]string
+}
+
+// Used returns the number of EFAs attached to the instance
+func (c *EFACapacity) Used() int32 {
+	var used int32
+	for _, enis := range c.EFAs {
+		used += int32(len(enis))
+	}
+	return used
+}
+
+// FreeCards returns the network cards without an EFA, ascending
+func (c *EFACapacity) FreeCards() []int32 {
+	var free []int32
+	for card := int32(0); card < c.NetworkCards; card++ {
+		if len(c.EFAs[card]) == 0 {
+			free = append(free, card)
+		}
+	}
+	return free
+}
+
+// Check returns an error if another EFA can't be attached on networkCardIndex
+func (c *EFACapacity) Check(networkCardIndex int32) error {
+	if networkCardIndex < 0 || networkCardIndex >= c.NetworkCards {
+		return fmt.Errorf("network card %d out of range: %s has %d network cards",
+			networkCardIndex, c.InstanceType, c.NetworkCards)
+	}
+	if used := c.Used(); used >= c.Limit {
+		return fmt.Errorf("instance %s already has %d of %d EFAs", c.InstanceID, used, c.Limit)
+	}
+	return nil
+}
+
+// EFACapacity reports the EFA support of an instance from DescribeInstanceTypes
+// and the EFAs already attached to it. It returns ErrEFAUnsupported if the
+// instance type has no EFA support.
+func (m *ENIManager) EFACapacity(ctx context.Context, instanceID string) (*EFACapacity, error) {
+	instance, err := m.DescribeInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	info, err := m.DescribeInstanceType(ctx, string(instance.InstanceType))
+	if err != nil {
+		return nil, err
+	}
+	network := info.NetworkInfo
+	if network == nil || !aws.ToBool(network.EfaSupported) {
+		return nil, fmt.Errorf("%s: %w", instance.InstanceType, ErrEFAUnsupported)
+	}
+
+	capacity := &EFACapacity{
+		InstanceID:   instanceID,
+		InstanceType: string(instance.InstanceType),
+		NetworkCards: max(aws.ToInt32(network.MaximumNetworkCards), 1),
+		EFAs:         make(map[int32][]string),
+	}
+	if network.EfaInfo != nil {
+		capacity.Limit = aws.ToInt32(network.EfaInfo.MaximumEfaInterfaces)
+	}
+	for _, eni := range instance.NetworkInterfaces {
+		if !IsEFA(aws.ToString(eni.InterfaceType)) || eni.Attachment == nil {
+			continue
+		}
+		card := aws.ToInt32(eni.Attachment.NetworkCardIndex)
+		capacity.EFAs[card] = append(capacity.EFAs[card], aws.ToString(eni.NetworkInterfaceId))
+	}
+	return capacity, nil
+}
+
+// AttachEFA attaches an EFA ENI at deviceIndex on networkCardIndex after
+// checking the instance type supports EFA and has room for another. Offline
+// dry runs can't see the instance, so they skip the check.
+func (m *ENIManager) AttachEFA(ctx context.Context, networkInterfaceID, instanceID string, deviceIndex, networkCardIndex int32) (*string, error) {
+	if m.dryRun != DryRunOffline {
+		capacity, err := m.EFACapacity(ctx, instanceID)
+		if err != nil {
+			return nil, err
+		}
+		if err := capacity.Check(networkCardIndex); err != nil {
+			return nil, err
+		}
+	}
+	return m.AttachENIToCard(ctx, networkInterfaceID, instanceID, deviceIndex, networkCardIndex)
+}
+
+// EFAAttachment is an EFA created and attached by ProvisionEFAs
+type EFAAttachment struct {
+	NetworkCardIndex int32
+	ENIID            string
+	AttachmentID     string
+}
+
+// ProvisionEFAs creates an EFA from config on every network card of an
+// instance that doesn't have one yet, up to the instance type's EFA limit,
+// and attaches each at deviceIndex on its card. config.InterfaceType
+// defaults to efa. An ENI whose attach fails is deleted; the EFAs
+// provisioned before a failure are returned with the error. It needs the
+// instance's network cards, so it fails in offline dry runs.
+func (m *ENIManager) ProvisionEFAs(ctx context.Context, instanceID string, config ENIConfig, deviceIndex int32) ([]EFAAttachment, error) {
+	if config.InterfaceType == "" {
+		config.InterfaceType = types.NetworkInterfaceCreationTypeEfa
+	}
+	if !IsEFA(string(config.InterfaceType)) {
+		return nil, fmt.Errorf("interface type %q is not an EFA type", config.InterfaceType)
+	}
+
+	capacity, err := m.EFACapacity(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	cards := capacity.FreeCards()
+	if room := int(capacity.Limit - capacity.Used()); len(cards) > room {
+		cards = cards[:max(room, 0)]
+	}
+
+	var provisioned []EFAAttachment
+	for _, card := range cards {
+		eni, err := m.CreateENI(ctx, config)
+		if err != nil {
+			return provisioned, err
+		}
+		eniID := aws.ToString(eni.NetworkInterface.NetworkInterfaceId)
+
+		attachmentID, err := m.AttachENIToCard(ctx, eniID, instanceID, deviceIndex, card)
+		if err != nil {
+			if deleteErr := m.DeleteENI(ctx, eniID); deleteErr != nil {
+				err = errors.Join(err, deleteErr)
+			}
+			return provisioned, fmt.Errorf("network card %d: %w", card, err)
+		}
+		provisioned = append(provisioned, EFAAttachment{
+			NetworkCardIndex: card,
+			ENIID:            eniID,
+			AttachmentID:     aws.ToString(attachmentID),
+		})
+	}
+	return provisioned, nil
+}
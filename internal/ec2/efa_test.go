@@ -0,0 +1,125 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectEFAInstance sets up an instance with its primary EFA on card 0, of an
+// instance type with the given network cards and EFA limit
+func expectEFAInstance(mockClient *mocks.MockEC2ClientAPI, efaSupported bool, cards, limit int32) {
+	mockClient.EXPECT().DescribeInstances(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeInstancesOutput{Reservations: []types.Reservation{{
+			Instances: []types.Instance{{
+				InstanceId:   aws.String("i-12345678"),
+				InstanceType: types.InstanceTypeP4d24xlarge,
+				NetworkInterfaces: []types.InstanceNetworkInterface{{
+					NetworkInterfaceId: aws.String("eni-primary"),
+					InterfaceType:      aws.String("efa"),
+					Attachment:         &types.InstanceNetworkInterfaceAttachment{DeviceIndex: aws.Int32(0), NetworkCardIndex: aws.Int32(0)},
+				}},
+			}},
+		}}}, nil)
+	mockClient.EXPECT().DescribeInstanceTypes(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeInstanceTypesOutput{InstanceTypes: []types.InstanceTypeInfo{{
+			InstanceType: types.InstanceTypeP4d24xlarge,
+			NetworkInfo: &types.NetworkInfo{
+				EfaSupported:        aws.Bool(efaSupported),
+				EfaInfo:             &types.EfaInfo{MaximumEfaInterfaces: aws.Int32(limit)},
+				MaximumNetworkCards: aws.Int32(cards),
+			},
+		}}}, nil)
+}
+
+func TestProvisionEFAs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	expectEFAInstance(mockClient, true, 4, 4)
+	for _, card := range []int32{1, 2, 3} {
+		eniID := aws.String(fmt.Sprintf("eni-efa%d", card))
+		mockClient.EXPECT().CreateNetworkInterface(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, input *ec2.CreateNetworkInterfaceInput, _ ...func(*ec2.Options)) (*ec2.CreateNetworkInterfaceOutput, error) {
+				assert.Equal(t, types.NetworkInterfaceCreationTypeEfaOnly, input.InterfaceType)
+				return &ec2.CreateNetworkInterfaceOutput{NetworkInterface: &types.NetworkInterface{NetworkInterfaceId: eniID}}, nil
+			})
+		mockClient.EXPECT().AttachNetworkInterface(gomock.Any(), gomock.Eq(&ec2.AttachNetworkInterfaceInput{
+			NetworkInterfaceId: eniID,
+			InstanceId:         aws.String("i-12345678"),
+			DeviceIndex:        aws.Int32(1),
+			NetworkCardIndex:   aws.Int32(card),
+		})).Return(&ec2.AttachNetworkInterfaceOutput{AttachmentId: aws.String("eni-attach-12345678")}, nil)
+	}
+
+	attachments, err := manager.ProvisionEFAs(context.Background(), "i-12345678", ENIConfig{
+		SubnetID:      "subnet-12345678",
+		InterfaceType: types.NetworkInterfaceCreationTypeEfaOnly,
+	}, 1)
+	assert.NoError(t, err)
+	if assert.Len(t, attachments, 3) {
+		assert.Equal(t, int32(1), attachments[0].NetworkCardIndex)
+		assert.Equal(t, "eni-efa3", attachments[2].ENIID)
+	}
+}
+
+func TestProvisionEFAs_AttachFailureDeletesENI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	expectEFAInstance(mockClient, true, 2, 2)
+	mockClient.EXPECT().CreateNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(&ec2.CreateNetworkInterfaceOutput{NetworkInterface: &types.NetworkInterface{NetworkInterfaceId: aws.String("eni-efa1")}}, nil)
+	mockClient.EXPECT().AttachNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("attach failed"))
+	mockClient.EXPECT().DeleteNetworkInterface(gomock.Any(), gomock.Eq(&ec2.DeleteNetworkInterfaceInput{
+		NetworkInterfaceId: aws.String("eni-efa1"),
+	})).Return(&ec2.DeleteNetworkInterfaceOutput{}, nil)
+
+	attachments, err := manager.ProvisionEFAs(context.Background(), "i-12345678", ENIConfig{SubnetID: "subnet-12345678"}, 1)
+	assert.ErrorContains(t, err, "network card 1")
+	assert.Empty(t, attachments)
+}
+
+func TestAttachEFA_Unsupported(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	expectEFAInstance(mockClient, false, 1, 0)
+
+	_, err := manager.AttachEFA(context.Background(), "eni-efa1", "i-12345678", 1, 0)
+	assert.ErrorIs(t, err, ErrEFAUnsupported)
+}
+
+func TestEFACapacity_Check(t *testing.T) {
+	capacity := &EFACapacity{
+		InstanceID:   "i-12345678",
+		InstanceType: "p4d.24xlarge",
+		NetworkCards: 4,
+		Limit:        2,
+		EFAs:         map[int32][]string{0: {"eni-primary"}},
+	}
+	assert.NoError(t, capacity.Check(1))
+	assert.ErrorContains(t, capacity.Check(4), "out of range")
+	assert.Equal(t, []int32{1, 2, 3}, capacity.FreeCards())
+
+	capacity.EFAs[1] = []string{"eni-efa1"}
+	assert.ErrorContains(t, capacity.Check(2), "already has 2 of 2 EFAs")
+}
@@ -0,0 +1,39 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+
+	"github.com/aws/smithy-go"
+)
+
+// ErrorCode returns the AWS error code carried by err (e.g. "InvalidNetworkInterfaceID.NotFound"),
+// "" for a nil error and "Unknown" when err did not come from the EC2 API.
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+
+	var vetoErr *HookVetoError
+	var policyErr *PolicyError
+	var branchErr *BranchCapacityError
+	switch {
+	case errors.As(err, &vetoErr):
+		return CodeHookVetoed
+	case errors.As(err, &policyErr):
+		return CodePolicyViolation
+	case errors.As(err, &branchErr):
+		return CodeBranchLimitExceeded
+	case errors.Is(err, context.Canceled):
+		return "Canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "DeadlineExceeded"
+	}
+
+	return "Unknown"
+}
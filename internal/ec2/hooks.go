@@ -0,0 +1,264 @@
+package ec2
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+)
+
+// HookPhase is when a hook fires relative to the EC2 call
+type HookPhase string
+
+const (
+	// HookPre fires before the EC2 call; an error vetoes the operation
+	HookPre HookPhase = "pre"
+	// HookPost fires after a successful EC2 call; errors are only logged
+	HookPost HookPhase = "post"
+)
+
+// HookEvent is the JSON document delivered to hooks. Resources holds the
+// IDs involved in the operation (eni_id, instance_id, subnet_id,
+// attachment_id); post hooks also see IDs created by the operation.
+type HookEvent struct {
+	ID        string            `json:"id"`
+	Phase     HookPhase         `json:"phase"`
+	Operation string            `json:"operation"`
+	Resources map[string]string `json:"resources,omitempty"`
+	Time      time.Time         `json:"time"`
+}
+
+// Hook is notified of ENIManager mutations
+type Hook interface {
+	Fire(ctx context.Context, event HookEvent) error
+}
+
+// HookRule selects which operations and phases a hook fires for
+type HookRule struct {
+	Name string
+	// Phase limits the hook to pre or post; empty fires in both
+	Phase HookPhase
+	// Operations limits the hook to the named ENIManager operations
+	// (e.g. "CreateENI"); empty fires for every mutation
+	Operations []string
+	Hook       Hook
+}
+
+func (r HookRule) matches(phase HookPhase, operation string) bool {
+	if r.Phase != "" && r.Phase != phase {
+		return false
+	}
+	return len(r.Operations) == 0 || slices.Contains(r.Operations, operation)
+}
+
+// WithHooks fires rules around every mutating operation. Pre hooks run in
+// order and the first error vetoes the operation with a *HookVetoError;
+// post hooks run after the EC2 call succeeds.
+func WithHooks(rules ...HookRule) Option {
+	return func(m *ENIManager) {
+		m.hooks = append(m.hooks, rules...)
+	}
+}
+
+// CodeHookVetoed is the error code reported for operations vetoed by a pre hook
+const CodeHookVetoed = "HookVetoed"
+
+// HookVetoError reports an operation rejected by a pre hook
+type HookVetoError struct {
+	Hook      string
+	Operation string
+	Err       error
+}
+
+func (e *HookVetoError) Error() string {
+	return fmt.Sprintf("%s vetoed by hook %s: %v", e.Operation, e.Hook, e.Err)
+}
+
+func (e *HookVetoError) Unwrap() error {
+	return e.Err
+}
+
+// before runs the pre hooks for a mutating operation and marks it so end
+// runs the post hooks
+func (op *operation) before() error {
+	// Dry runs change nothing, so there is nothing to notify or veto
+	if op.m.dryRun != DryRunOff {
+		return nil
+	}
+	op.hooked = true
+	for _, rule := range op.m.hooks {
+		if !rule.matches(HookPre, op.name) {
+			continue
+		}
+		if err := rule.Hook.Fire(op.ctx, op.hookEvent(HookPre)); err != nil {
+			return &HookVetoError{Hook: rule.Name, Operation: op.name, Err: err}
+		}
+	}
+	return nil
+}
+
+// after runs the post hooks; the operation has already happened, so
+// failures are logged rather than returned
+func (op *operation) after() {
+	for _, rule := range op.m.hooks {
+		if !rule.matches(HookPost, op.name) {
+			continue
+		}
+		if err := rule.Hook.Fire(op.ctx, op.hookEvent(HookPost)); err != nil && op.m.logger != nil {
+			op.m.logger.WarnContext(op.ctx, "post hook failed", "hook", rule.Name, "operation", op.name, "error", err)
+		}
+	}
+}
+
+func (op *operation) hookEvent(phase HookPhase) HookEvent {
+	return HookEvent{
+		ID:        newEventID(),
+		Phase:     phase,
+		Operation: op.name,
+		Resources: op.resources(),
+		Time:      time.Now().UTC(),
+	}
+}
+
+func newEventID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b)
+}
+
+// WebhookHook POSTs each event as JSON to URL. When Secret is set the body
+// is signed with HMAC-SHA256 in the X-ENI-Signature header as
+// "sha256=<hex>". Connection errors, 429 and 5xx responses are retried with
+// exponential backoff; any other non-2xx response fails immediately.
+type WebhookHook struct {
+	URL        string
+	Secret     string
+	Client     *http.Client
+	MaxRetries int
+	Backoff    time.Duration
+}
+
+// NewWebhookHook returns a webhook with a 10 second timeout that retries
+// three times starting at 500ms
+func NewWebhookHook(url, secret string) *WebhookHook {
+	return &WebhookHook{
+		URL:        url,
+		Secret:     secret,
+		Client:     &http.Client{Timeout: 10 * time.Second},
+		MaxRetries: 3,
+		Backoff:    500 * time.Millisecond,
+	}
+}
+
+// Sign returns the X-ENI-Signature value for body
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func (h *WebhookHook) Fire(ctx context.Context, event HookEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	backoff := h.Backoff
+	for attempt := 0; ; attempt++ {
+		retry, err := h.post(ctx, event, body)
+		if err == nil || !retry || attempt >= h.MaxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post delivers body once and reports whether a failure is worth retrying
+func (h *WebhookHook) post(ctx context.Context, event HookEvent, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.URL, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-ENI-Event", string(event.Phase)+"."+event.Operation)
+	req.Header.Set("X-ENI-Delivery", event.ID)
+	if h.Secret != "" {
+		req.Header.Set("X-ENI-Signature", Sign(h.Secret, body))
+	}
+
+	client := h.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("webhook returned %s: %s", resp.Status, strings.TrimSpace(string(msg)))
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, err
+}
+
+// CommandHook runs a local executable with the event as JSON on stdin and
+// ENI_HOOK_PHASE and ENI_HOOK_OPERATION in its environment. A non-zero exit
+// fails the hook, with stderr as the reason.
+type CommandHook struct {
+	Path    string
+	Args    []string
+	Timeout time.Duration
+}
+
+func (h *CommandHook) Fire(ctx context.Context, event HookEvent) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	if h.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.Timeout)
+		defer cancel()
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, h.Path, h.Args...)
+	cmd.Stdin = bytes.NewReader(body)
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(),
+		"ENI_HOOK_PHASE="+string(event.Phase),
+		"ENI_HOOK_OPERATION="+event.Operation)
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if msg := strings.TrimSpace(stderr.String()); msg != "" && errors.As(err, &exitErr) {
+			return fmt.Errorf("%s: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
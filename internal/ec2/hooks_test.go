@@ -0,0 +1,157 @@
+// internal/ec2/hooks_test.go
+package ec2
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// recordingHook records events and returns err from Fire
+type recordingHook struct {
+	events []HookEvent
+	err    error
+}
+
+func (h *recordingHook) Fire(_ context.Context, event HookEvent) error {
+	h.events = append(h.events, event)
+	return h.err
+}
+
+func TestENIManager_Hooks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	pre, post := &recordingHook{}, &recordingHook{}
+	manager := NewENIManager(mockClient, WithHooks(
+		HookRule{Name: "pre", Phase: HookPre, Hook: pre},
+		HookRule{Name: "post", Phase: HookPost, Operations: []string{"CreateENI"}, Hook: post},
+	))
+
+	mockClient.EXPECT().
+		CreateNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(&ec2.CreateNetworkInterfaceOutput{
+			NetworkInterface: &types.NetworkInterface{NetworkInterfaceId: aws.String("eni-12345678")},
+		}, nil)
+	mockClient.EXPECT().
+		DeleteNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(&ec2.DeleteNetworkInterfaceOutput{}, nil)
+	mockClient.EXPECT().
+		DescribeSubnets(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeSubnetsOutput{}, nil)
+
+	ctx := context.Background()
+	_, err := manager.CreateENI(ctx, ENIConfig{SubnetID: "subnet-12345678"})
+	assert.NoError(t, err)
+	assert.NoError(t, manager.DeleteENI(ctx, "eni-12345678"))
+	// Reads never fire hooks
+	_, err = manager.DescribeSubnet(ctx, "subnet-12345678")
+	assert.NoError(t, err)
+
+	assert.Len(t, pre.events, 2)
+	assert.Equal(t, HookPre, pre.events[0].Phase)
+	assert.Equal(t, "CreateENI", pre.events[0].Operation)
+	assert.Equal(t, map[string]string{"subnet_id": "subnet-12345678"}, pre.events[0].Resources)
+	assert.Equal(t, "DeleteENI", pre.events[1].Operation)
+
+	assert.Len(t, post.events, 1)
+	assert.Equal(t, map[string]string{"subnet_id": "subnet-12345678", "eni_id": "eni-12345678"}, post.events[0].Resources)
+}
+
+func TestENIManager_HookVeto(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// No EC2 call is expected: the veto happens first
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	post := &recordingHook{}
+	manager := NewENIManager(mockClient, WithHooks(
+		HookRule{Name: "firewall", Phase: HookPre, Hook: &recordingHook{err: errors.New("not allowed")}},
+		HookRule{Name: "post", Phase: HookPost, Hook: post},
+	))
+
+	err := manager.DeleteENI(context.Background(), "eni-12345678")
+	var vetoErr *HookVetoError
+	assert.ErrorAs(t, err, &vetoErr)
+	assert.Equal(t, "firewall", vetoErr.Hook)
+	assert.Equal(t, CodeHookVetoed, ErrorCode(err))
+	assert.Empty(t, post.events)
+}
+
+func TestWebhookHook(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, Sign("s3cret", body), r.Header.Get("X-ENI-Signature"))
+		assert.Equal(t, "post.CreateENI", r.Header.Get("X-ENI-Event"))
+
+		var event HookEvent
+		assert.NoError(t, json.Unmarshal(body, &event))
+		assert.Equal(t, "eni-12345678", event.Resources["eni_id"])
+
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	hook := NewWebhookHook(server.URL, "s3cret")
+	hook.Backoff = time.Millisecond
+	err := hook.Fire(context.Background(), HookEvent{
+		Phase:     HookPost,
+		Operation: "CreateENI",
+		Resources: map[string]string{"eni_id": "eni-12345678"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestWebhookHook_ClientErrorNotRetried(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		http.Error(w, "subnet is frozen", http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	hook := NewWebhookHook(server.URL, "")
+	hook.Backoff = time.Millisecond
+	err := hook.Fire(context.Background(), HookEvent{Phase: HookPre, Operation: "CreateENI"})
+	assert.ErrorContains(t, err, "subnet is frozen")
+	assert.Equal(t, 1, attempts)
+}
+
+func TestCommandHook(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "event.json")
+	hook := &CommandHook{Path: "sh", Args: []string{"-c", `cat > "$1"; [ "$ENI_HOOK_PHASE" = post ]`, "hook", out}}
+
+	event := HookEvent{Phase: HookPost, Operation: "AttachENI", Resources: map[string]string{"eni_id": "eni-12345678"}}
+	assert.NoError(t, hook.Fire(context.Background(), event))
+
+	data, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	var got HookEvent
+	assert.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, event.Resources, got.Resources)
+
+	veto := &CommandHook{Path: "sh", Args: []string{"-c", "echo denied >&2; exit 1"}}
+	assert.ErrorContains(t, veto.Fire(context.Background(), event), "denied")
+}
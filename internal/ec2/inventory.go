@@ -0,0 +1,183 @@
+package ec2
+
+import (
+	"context"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// InstanceInventory is the network configuration of one instance
+type InstanceInventory struct {
+	InstanceID       string `json:"instance_id"`
+	InstanceType     string `json:"instance_type"`
+	AvailabilityZone string `json:"availability_zone"`
+	// Interfaces are ordered by network card, then device index
+	Interfaces []InterfaceInventory `json:"interfaces"`
+	// MaxInterfaces is the instance type's interface limit across all cards
+	MaxInterfaces  int32 `json:"max_interfaces"`
+	FreeInterfaces int32 `json:"free_interfaces"`
+}
+
+// InterfaceInventory is one ENI of an instance
+type InterfaceInventory struct {
+	ENIID               string `json:"eni_id"`
+	InterfaceType       string `json:"interface_type"`
+	NetworkCardIndex    int32  `json:"network_card_index"`
+	DeviceIndex         int32  `json:"device_index"`
+	AttachmentID        string `json:"attachment_id"`
+	DeleteOnTermination bool   `json:"delete_on_termination"`
+	SourceDestCheck     bool   `json:"source_dest_check"`
+
+	SubnetID         string   `json:"subnet_id"`
+	AvailabilityZone string   `json:"availability_zone"`
+	SubnetCIDR       string   `json:"subnet_cidr"`
+	SubnetIPv6CIDRs  []string `json:"subnet_ipv6_cidrs,omitempty"`
+	SecurityGroupIDs []string `json:"security_group_ids"`
+
+	PrimaryIPv4   string     `json:"primary_ipv4"`
+	SecondaryIPv4 []string   `json:"secondary_ipv4,omitempty"`
+	IPv6          []string   `json:"ipv6,omitempty"`
+	IPv4Prefixes  []string   `json:"ipv4_prefixes,omitempty"`
+	IPv6Prefixes  []string   `json:"ipv6_prefixes,omitempty"`
+	PublicIPs     []PublicIP `json:"public_ips,omitempty"`
+
+	// FreeIPv4 and FreeIPv6 are the addresses that can still be assigned
+	// under the instance type's per-interface limits; each prefix takes one
+	FreeIPv4 int32 `json:"free_ipv4"`
+	FreeIPv6 int32 `json:"free_ipv6"`
+}
+
+// PublicIP is a public address associated with a private IP of an ENI
+type PublicIP struct {
+	Address   string `json:"address"`
+	PrivateIP string `json:"private_ip"`
+	// AllocationID is set for Elastic IPs
+	AllocationID string `json:"allocation_id,omitempty"`
+}
+
+// InstanceInventory joins DescribeInstances, DescribeENIs and DescribeSubnet
+// into one view of an instance's interfaces, with the capacity left under
+// the limits from DescribeInstanceTypes
+func (m *ENIManager) InstanceInventory(ctx context.Context, instanceID string) (*InstanceInventory, error) {
+	instance, err := m.DescribeInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	info, err := m.DescribeInstanceType(ctx, string(instance.InstanceType))
+	if err != nil {
+		return nil, err
+	}
+	network := info.NetworkInfo
+	if network == nil {
+		network = &types.NetworkInfo{}
+	}
+	enis, err := m.DescribeENIs(ctx, []types.Filter{
+		{Name: aws.String("attachment.instance-id"), Values: []string{instanceID}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &InstanceInventory{
+		InstanceID:    instanceID,
+		InstanceType:  string(instance.InstanceType),
+		MaxInterfaces: aws.ToInt32(network.MaximumNetworkInterfaces),
+	}
+	if instance.Placement != nil {
+		inventory.AvailabilityZone = aws.ToString(instance.Placement.AvailabilityZone)
+	}
+
+	subnets := make(map[string]*types.Subnet)
+	for _, eni := range enis.NetworkInterfaces {
+		subnetID := aws.ToString(eni.SubnetId)
+		subnet, ok := subnets[subnetID]
+		if !ok {
+			output, err := m.DescribeSubnet(ctx, subnetID)
+			if err != nil {
+				return nil, err
+			}
+			if len(output.Subnets) > 0 {
+				subnet = &output.Subnets[0]
+			}
+			subnets[subnetID] = subnet
+		}
+		iface := newInterfaceInventory(eni, subnet)
+		iface.FreeIPv4 = max(aws.ToInt32(network.Ipv4AddressesPerInterface)-ipv4Slots(eni), 0)
+		iface.FreeIPv6 = max(aws.ToInt32(network.Ipv6AddressesPerInterface)-ipv6Slots(eni), 0)
+		inventory.Interfaces = append(inventory.Interfaces, iface)
+	}
+	slices.SortFunc(inventory.Interfaces, func(a, b InterfaceInventory) int {
+		if a.NetworkCardIndex != b.NetworkCardIndex {
+			return int(a.NetworkCardIndex - b.NetworkCardIndex)
+		}
+		return int(a.DeviceIndex - b.DeviceIndex)
+	})
+	inventory.FreeInterfaces = max(inventory.MaxInterfaces-int32(len(inventory.Interfaces)), 0)
+	return inventory, nil
+}
+
+func newInterfaceInventory(eni types.NetworkInterface, subnet *types.Subnet) InterfaceInventory {
+	iface := InterfaceInventory{
+		ENIID:           aws.ToString(eni.NetworkInterfaceId),
+		InterfaceType:   string(eni.InterfaceType),
+		SourceDestCheck: aws.ToBool(eni.SourceDestCheck),
+		SubnetID:        aws.ToString(eni.SubnetId),
+		PrimaryIPv4:     aws.ToString(eni.PrivateIpAddress),
+	}
+	if eni.Attachment != nil {
+		iface.NetworkCardIndex = aws.ToInt32(eni.Attachment.NetworkCardIndex)
+		iface.DeviceIndex = aws.ToInt32(eni.Attachment.DeviceIndex)
+		iface.AttachmentID = aws.ToString(eni.Attachment.AttachmentId)
+		iface.DeleteOnTermination = aws.ToBool(eni.Attachment.DeleteOnTermination)
+	}
+	if subnet != nil {
+		iface.AvailabilityZone = aws.ToString(subnet.AvailabilityZone)
+		iface.SubnetCIDR = aws.ToString(subnet.CidrBlock)
+		for _, block := range subnet.Ipv6CidrBlockAssociationSet {
+			iface.SubnetIPv6CIDRs = append(iface.SubnetIPv6CIDRs, aws.ToString(block.Ipv6CidrBlock))
+		}
+	}
+	for _, group := range eni.Groups {
+		iface.SecurityGroupIDs = append(iface.SecurityGroupIDs, aws.ToString(group.GroupId))
+	}
+	for _, addr := range eni.PrivateIpAddresses {
+		privateIP := aws.ToString(addr.PrivateIpAddress)
+		if !aws.ToBool(addr.Primary) {
+			iface.SecondaryIPv4 = append(iface.SecondaryIPv4, privateIP)
+		}
+		if addr.Association != nil && addr.Association.PublicIp != nil {
+			iface.PublicIPs = append(iface.PublicIPs, PublicIP{
+				Address:      aws.ToString(addr.Association.PublicIp),
+				PrivateIP:    privateIP,
+				AllocationID: aws.ToString(addr.Association.AllocationId),
+			})
+		}
+	}
+	for _, addr := range eni.Ipv6Addresses {
+		iface.IPv6 = append(iface.IPv6, aws.ToString(addr.Ipv6Address))
+	}
+	for _, prefix := range eni.Ipv4Prefixes {
+		iface.IPv4Prefixes = append(iface.IPv4Prefixes, aws.ToString(prefix.Ipv4Prefix))
+	}
+	for _, prefix := range eni.Ipv6Prefixes {
+		iface.IPv6Prefixes = append(iface.IPv6Prefixes, aws.ToString(prefix.Ipv6Prefix))
+	}
+	return iface
+}
+
+// ipv4Slots returns the IPv4 address slots an ENI uses: its private IPs,
+// including the primary, and one per prefix
+func ipv4Slots(eni types.NetworkInterface) int32 {
+	slots := int32(len(eni.PrivateIpAddresses) + len(eni.Ipv4Prefixes))
+	if slots == 0 && eni.PrivateIpAddress != nil {
+		slots = 1
+	}
+	return slots
+}
+
+// ipv6Slots returns the IPv6 address slots an ENI uses
+func ipv6Slots(eni types.NetworkInterface) int32 {
+	return int32(len(eni.Ipv6Addresses) + len(eni.Ipv6Prefixes))
+}
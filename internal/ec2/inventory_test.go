@@ -0,0 +1,97 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInstanceInventory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	mockClient.EXPECT().DescribeInstances(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeInstancesOutput{Reservations: []types.Reservation{{
+			Instances: []types.Instance{{
+				InstanceType: types.InstanceTypeM5Large,
+				Placement:    &types.Placement{AvailabilityZone: aws.String("us-west-2a")},
+			}},
+		}}}, nil)
+	mockClient.EXPECT().DescribeInstanceTypes(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeInstanceTypesOutput{InstanceTypes: []types.InstanceTypeInfo{{
+			NetworkInfo: &types.NetworkInfo{
+				MaximumNetworkInterfaces:  aws.Int32(3),
+				Ipv4AddressesPerInterface: aws.Int32(10),
+				Ipv6AddressesPerInterface: aws.Int32(10),
+			},
+		}}}, nil)
+	mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Eq(&ec2.DescribeNetworkInterfacesInput{
+		Filters: []types.Filter{{Name: aws.String("attachment.instance-id"), Values: []string{"i-12345678"}}},
+	})).Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []types.NetworkInterface{
+		{
+			NetworkInterfaceId: aws.String("eni-secondary"),
+			SubnetId:           aws.String("subnet-12345678"),
+			PrivateIpAddress:   aws.String("10.0.1.20"),
+			PrivateIpAddresses: []types.NetworkInterfacePrivateIpAddress{
+				{PrivateIpAddress: aws.String("10.0.1.20"), Primary: aws.Bool(true)},
+				{PrivateIpAddress: aws.String("10.0.1.21"), Primary: aws.Bool(false)},
+			},
+			Ipv4Prefixes:    []types.Ipv4PrefixSpecification{{Ipv4Prefix: aws.String("10.0.1.48/28")}},
+			SourceDestCheck: aws.Bool(false),
+			Attachment:      &types.NetworkInterfaceAttachment{DeviceIndex: aws.Int32(1)},
+		},
+		{
+			NetworkInterfaceId: aws.String("eni-primary"),
+			SubnetId:           aws.String("subnet-12345678"),
+			PrivateIpAddress:   aws.String("10.0.1.10"),
+			PrivateIpAddresses: []types.NetworkInterfacePrivateIpAddress{{
+				PrivateIpAddress: aws.String("10.0.1.10"),
+				Primary:          aws.Bool(true),
+				Association: &types.NetworkInterfaceAssociation{
+					PublicIp:     aws.String("203.0.113.7"),
+					AllocationId: aws.String("eipalloc-12345678"),
+				},
+			}},
+			Groups:          []types.GroupIdentifier{{GroupId: aws.String("sg-12345678")}},
+			Ipv6Addresses:   []types.NetworkInterfaceIpv6Address{{Ipv6Address: aws.String("2001:db8::1")}},
+			SourceDestCheck: aws.Bool(true),
+			Attachment: &types.NetworkInterfaceAttachment{
+				DeviceIndex:         aws.Int32(0),
+				DeleteOnTermination: aws.Bool(true),
+			},
+		},
+	}}, nil)
+	// Both ENIs share a subnet, which is described once
+	mockClient.EXPECT().DescribeSubnets(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeSubnetsOutput{Subnets: []types.Subnet{{
+			AvailabilityZone: aws.String("us-west-2a"),
+			CidrBlock:        aws.String("10.0.1.0/24"),
+		}}}, nil)
+
+	inventory, err := manager.InstanceInventory(context.Background(), "i-12345678")
+	assert.NoError(t, err)
+	assert.Equal(t, "us-west-2a", inventory.AvailabilityZone)
+	assert.Equal(t, int32(1), inventory.FreeInterfaces)
+	if assert.Len(t, inventory.Interfaces, 2) {
+		primary, secondary := inventory.Interfaces[0], inventory.Interfaces[1]
+		assert.Equal(t, "eni-primary", primary.ENIID)
+		assert.Equal(t, []PublicIP{{Address: "203.0.113.7", PrivateIP: "10.0.1.10", AllocationID: "eipalloc-12345678"}}, primary.PublicIPs)
+		assert.True(t, primary.DeleteOnTermination)
+		assert.Equal(t, int32(9), primary.FreeIPv4)
+		assert.Equal(t, int32(9), primary.FreeIPv6)
+
+		assert.Equal(t, []string{"10.0.1.21"}, secondary.SecondaryIPv4)
+		assert.Equal(t, "10.0.1.0/24", secondary.SubnetCIDR)
+		assert.False(t, secondary.SourceDestCheck)
+		assert.Equal(t, int32(7), secondary.FreeIPv4)
+	}
+}
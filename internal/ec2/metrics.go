@@ -0,0 +1,73 @@
+package ec2
+
+import (
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics holds the Prometheus collectors ENIManager reports to. A nil
+// *Metrics is valid and records nothing.
+type Metrics struct {
+	operations *prometheus.CounterVec
+	latency    *prometheus.HistogramVec
+	enis       *prometheus.GaugeVec
+	ips        *prometheus.GaugeVec
+}
+
+// NewMetrics creates the ENIManager collectors and registers them with reg
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		operations: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "eni_manager",
+			Name:      "operations_total",
+			Help:      "ENIManager operations by operation and AWS error code (empty on success).",
+		}, []string{"operation", "error_code"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "eni_manager",
+			Name:      "operation_duration_seconds",
+			Help:      "Latency of ENIManager operations.",
+			Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		}, []string{"operation", "error_code"}),
+		enis: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "eni_manager",
+			Name:      "managed_enis",
+			Help:      "Managed ENIs by status, as of the last inventory refresh.",
+		}, []string{"status"}),
+		ips: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "eni_manager",
+			Name:      "assigned_ips",
+			Help:      "IP addresses assigned to managed ENIs by family, as of the last inventory refresh.",
+		}, []string{"family"}),
+	}
+
+	reg.MustRegister(m.operations, m.latency, m.enis, m.ips)
+	return m
+}
+
+func (m *Metrics) observe(operation string, duration time.Duration, err error) {
+	if m == nil {
+		return
+	}
+	code := ErrorCode(err)
+	m.operations.WithLabelValues(operation, code).Inc()
+	m.latency.WithLabelValues(operation, code).Observe(duration.Seconds())
+}
+
+// setInventory replaces the ENI and IP gauges with counts taken from enis
+func (m *Metrics) setInventory(enis []types.NetworkInterface) {
+	if m == nil {
+		return
+	}
+
+	m.enis.Reset()
+	var ipv4, ipv6 int
+	for _, eni := range enis {
+		m.enis.WithLabelValues(string(eni.Status)).Inc()
+		ipv4 += len(eni.PrivateIpAddresses)
+		ipv6 += len(eni.Ipv6Addresses)
+	}
+	m.ips.WithLabelValues("ipv4").Set(float64(ipv4))
+	m.ips.WithLabelValues("ipv6").Set(float64(ipv6))
+}
@@ -0,0 +1,106 @@
+// internal/ec2/metrics_test.go
+package ec2
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/golang/mock/gomock"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func scrape(t *testing.T, registry *prometheus.Registry) string {
+	t.Helper()
+
+	server := httptest.NewServer(promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	assert.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	return string(body)
+}
+
+func TestENIManager_Metrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	registry := prometheus.NewRegistry()
+	manager := NewENIManager(mockClient, WithMetrics(NewMetrics(registry)))
+
+	mockClient.EXPECT().
+		DeleteNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(&ec2.DeleteNetworkInterfaceOutput{}, nil)
+	mockClient.EXPECT().
+		AttachNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(nil, &smithy.GenericAPIError{Code: "InvalidInstanceID.NotFound"})
+	mockClient.EXPECT().
+		DescribeNetworkInterfaces(gomock.Any(), gomock.Eq(&ec2.DescribeNetworkInterfacesInput{
+			Filters: ManagedENIFilters(),
+		})).
+		Return(&ec2.DescribeNetworkInterfacesOutput{
+			NetworkInterfaces: []types.NetworkInterface{
+				{
+					NetworkInterfaceId: aws.String("eni-12345678"),
+					Status:             types.NetworkInterfaceStatusInUse,
+					PrivateIpAddresses: []types.NetworkInterfacePrivateIpAddress{
+						{PrivateIpAddress: aws.String("10.0.0.10")},
+						{PrivateIpAddress: aws.String("10.0.0.11")},
+					},
+				},
+				{
+					NetworkInterfaceId: aws.String("eni-87654321"),
+					Status:             types.NetworkInterfaceStatusAvailable,
+					PrivateIpAddresses: []types.NetworkInterfacePrivateIpAddress{
+						{PrivateIpAddress: aws.String("10.0.0.12")},
+					},
+					Ipv6Addresses: []types.NetworkInterfaceIpv6Address{
+						{Ipv6Address: aws.String("2600:1f14::1")},
+					},
+				},
+			},
+		}, nil)
+
+	ctx := context.Background()
+	assert.NoError(t, manager.DeleteENI(ctx, "eni-12345678"))
+	_, err := manager.AttachENI(ctx, "eni-12345678", "i-12345678", 1)
+	assert.Error(t, err)
+	assert.NoError(t, manager.RefreshMetrics(ctx))
+
+	body := scrape(t, registry)
+	assert.Contains(t, body, `eni_manager_operations_total{error_code="",operation="DeleteENI"} 1`)
+	assert.Contains(t, body, `eni_manager_operations_total{error_code="InvalidInstanceID.NotFound",operation="AttachENI"} 1`)
+	assert.Contains(t, body, `eni_manager_operation_duration_seconds_count{error_code="",operation="DescribeENIs"} 1`)
+	assert.Contains(t, body, `eni_manager_managed_enis{status="in-use"} 1`)
+	assert.Contains(t, body, `eni_manager_managed_enis{status="available"} 1`)
+	assert.Contains(t, body, `eni_manager_assigned_ips{family="ipv4"} 3`)
+	assert.Contains(t, body, `eni_manager_assigned_ips{family="ipv6"} 1`)
+}
+
+func TestENIManager_NoMetrics(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	mockClient.EXPECT().
+		DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{}, nil)
+
+	assert.NoError(t, manager.RefreshMetrics(context.Background()))
+}
@@ -0,0 +1,213 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// MoveConfig represents configuration for MoveENI
+type MoveConfig struct {
+	// DeviceIndex on the target instance; nil picks the lowest free device
+	// index, on NetworkCardIndex if that is set
+	DeviceIndex      *int32
+	NetworkCardIndex *int32
+	// Force detaches the ENI even if the source instance doesn't respond
+	Force bool
+	// PollInterval is how often the ENI is polled while it detaches
+	PollInterval time.Duration
+	// DetachTimeout bounds the wait for the ENI to become available
+	DetachTimeout time.Duration
+}
+
+// DefaultMoveConfig picks the device index and waits up to 2 minutes for
+// the detach, polling every 2 seconds
+func DefaultMoveConfig() MoveConfig {
+	return MoveConfig{
+		PollInterval:  2 * time.Second,
+		DetachTimeout: 2 * time.Minute,
+	}
+}
+
+// MoveResult is where MoveENI took an ENI from and put it
+type MoveResult struct {
+	ENIID                string `json:"eni_id"`
+	FromInstanceID       string `json:"from_instance_id"`
+	FromDeviceIndex      int32  `json:"from_device_index"`
+	FromNetworkCardIndex int32  `json:"from_network_card_index"`
+	ToInstanceID         string `json:"to_instance_id"`
+	DeviceIndex          int32  `json:"device_index"`
+	NetworkCardIndex     int32  `json:"network_card_index"`
+	// AttachmentID is the new attachment, on the source instance if the
+	// move was rolled back
+	AttachmentID string `json:"attachment_id,omitempty"`
+	// RolledBack is set when the detach wait or the attach to the target
+	// failed and the ENI was reattached where it came from
+	RolledBack bool `json:"rolled_back"`
+}
+
+// MoveENI moves an attached ENI, with its addresses, to another instance in
+// the same availability zone. It detaches the ENI, waits for it to become
+// available and attaches it to the target. If the wait or that attach
+// fails, the ENI is reattached to the source instance at its old device
+// index and the error is returned with the result. It needs the ENI's current attachment, so it
+// fails in offline dry runs.
+func (m *ENIManager) MoveENI(ctx context.Context, networkInterfaceID, targetInstanceID string, config MoveConfig) (*MoveResult, error) {
+	if config.PollInterval <= 0 {
+		config.PollInterval = DefaultMoveConfig().PollInterval
+	}
+	if config.DetachTimeout <= 0 {
+		config.DetachTimeout = DefaultMoveConfig().DetachTimeout
+	}
+
+	eni, err := m.describeENI(ctx, networkInterfaceID)
+	if err != nil {
+		return nil, err
+	}
+	attachment := eni.Attachment
+	if attachment == nil || aws.ToString(attachment.InstanceId) == "" {
+		return nil, fmt.Errorf("ENI %s is not attached to an instance", networkInterfaceID)
+	}
+	result := &MoveResult{
+		ENIID:                networkInterfaceID,
+		FromInstanceID:       aws.ToString(attachment.InstanceId),
+		FromDeviceIndex:      aws.ToInt32(attachment.DeviceIndex),
+		FromNetworkCardIndex: aws.ToInt32(attachment.NetworkCardIndex),
+		ToInstanceID:         targetInstanceID,
+	}
+	if result.FromDeviceIndex == 0 {
+		return nil, fmt.Errorf("ENI %s is the primary ENI of %s and can't be detached", networkInterfaceID, result.FromInstanceID)
+	}
+	if result.FromInstanceID == targetInstanceID {
+		return nil, fmt.Errorf("ENI %s is already attached to %s", networkInterfaceID, targetInstanceID)
+	}
+
+	// Check the target before detaching, so a move that can't work leaves
+	// the ENI where it is
+	target, err := m.DescribeInstance(ctx, targetInstanceID)
+	if err != nil {
+		return nil, err
+	}
+	if target.Placement != nil && eni.AvailabilityZone != nil {
+		if az := aws.ToString(target.Placement.AvailabilityZone); az != *eni.AvailabilityZone {
+			return nil, fmt.Errorf("ENI %s is in %s but %s is in %s", networkInterfaceID, *eni.AvailabilityZone, targetInstanceID, az)
+		}
+	}
+	if err := m.planMove(ctx, result, config); err != nil {
+		return nil, err
+	}
+
+	if err := m.DetachENI(ctx, aws.ToString(attachment.AttachmentId), config.Force); err != nil {
+		return nil, err
+	}
+	// Dry runs only preview the detach, so the ENI never becomes available
+	if m.dryRun == DryRunOff {
+		waitCtx, cancel := context.WithTimeout(ctx, config.DetachTimeout)
+		err := m.waitForStatus(waitCtx, networkInterfaceID, types.NetworkInterfaceStatusAvailable, config.PollInterval)
+		cancel()
+		if err != nil {
+			return m.rollbackMove(ctx, result, err)
+		}
+	}
+
+	attachmentID, err := m.AttachENIToCard(ctx, networkInterfaceID, targetInstanceID, result.DeviceIndex, result.NetworkCardIndex)
+	if err == nil {
+		result.AttachmentID = aws.ToString(attachmentID)
+		return result, nil
+	}
+
+	return m.rollbackMove(ctx, result, err)
+}
+
+// rollbackTimeout bounds the reattach to the source instance after a failed
+// move
+const rollbackTimeout = 30 * time.Second
+
+// rollbackMove reattaches a detached ENI to the instance it came from after
+// the move failed with cause. It runs without ctx's deadline, which may be
+// what failed the move.
+func (m *ENIManager) rollbackMove(ctx context.Context, result *MoveResult, cause error) (*MoveResult, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+	defer cancel()
+
+	rollbackID, err := m.AttachENIToCard(ctx, result.ENIID, result.FromInstanceID, result.FromDeviceIndex, result.FromNetworkCardIndex)
+	if err != nil {
+		return result, errors.Join(cause, fmt.Errorf("rollback to %s failed, ENI %s is left detached: %w", result.FromInstanceID, result.ENIID, err))
+	}
+	result.AttachmentID = aws.ToString(rollbackID)
+	result.RolledBack = true
+	return result, fmt.Errorf("moved ENI %s back to %s: %w", result.ENIID, result.FromInstanceID, cause)
+}
+
+// planMove fills in the device and network card index the ENI gets on the
+// target, picking a free device index unless config sets one
+func (m *ENIManager) planMove(ctx context.Context, result *MoveResult, config MoveConfig) error {
+	if config.DeviceIndex != nil {
+		result.DeviceIndex = *config.DeviceIndex
+		result.NetworkCardIndex = aws.ToInt32(config.NetworkCardIndex)
+		return nil
+	}
+
+	cards, err := m.NetworkCards(ctx, result.ToInstanceID)
+	if err != nil {
+		return err
+	}
+	candidates := cards.Cards
+	if config.NetworkCardIndex != nil {
+		candidates = nil
+		for _, card := range cards.Cards {
+			if card.Index == *config.NetworkCardIndex {
+				candidates = append(candidates, card)
+			}
+		}
+		if len(candidates) == 0 {
+			return fmt.Errorf("%s has no network card %d", result.ToInstanceID, *config.NetworkCardIndex)
+		}
+	}
+	plan, err := PlanAttachments(candidates, []string{result.ENIID}, AttachPack)
+	if err != nil {
+		return fmt.Errorf("%s: %w", result.ToInstanceID, err)
+	}
+	result.DeviceIndex = plan[0].DeviceIndex
+	result.NetworkCardIndex = plan[0].NetworkCardIndex
+	return nil
+}
+
+// describeENI returns one ENI by ID
+func (m *ENIManager) describeENI(ctx context.Context, networkInterfaceID string) (*types.NetworkInterface, error) {
+	output, err := m.DescribeENIs(ctx, []types.Filter{
+		{Name: aws.String("network-interface-id"), Values: []string{networkInterfaceID}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.NetworkInterfaces) == 0 {
+		return nil, fmt.Errorf("ENI %s not found", networkInterfaceID)
+	}
+	return &output.NetworkInterfaces[0], nil
+}
+
+// waitForStatus polls an ENI until it has status or ctx is done
+func (m *ENIManager) waitForStatus(ctx context.Context, networkInterfaceID string, status types.NetworkInterfaceStatus, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		eni, err := m.describeENI(ctx, networkInterfaceID)
+		if err != nil {
+			return err
+		}
+		if eni.Status == status {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("ENI %s still %s, waiting to become %s: %w", networkInterfaceID, eni.Status, status, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
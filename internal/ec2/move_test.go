@@ -0,0 +1,220 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// movingENI is attached to i-source at device index 2
+func movingENI(status types.NetworkInterfaceStatus) *ec2.DescribeNetworkInterfacesOutput {
+	eni := types.NetworkInterface{
+		NetworkInterfaceId: aws.String("eni-12345678"),
+		AvailabilityZone:   aws.String("us-east-1a"),
+		Status:             status,
+	}
+	if status == types.NetworkInterfaceStatusInUse {
+		eni.Attachment = &types.NetworkInterfaceAttachment{
+			AttachmentId:     aws.String("eni-attach-source"),
+			InstanceId:       aws.String("i-source"),
+			DeviceIndex:      aws.Int32(2),
+			NetworkCardIndex: aws.Int32(0),
+		}
+	}
+	return &ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []types.NetworkInterface{eni}}
+}
+
+// expectTarget expects the target instance i-target, in us-east-1a with
+// device index 1 in use, to be described
+func expectTarget(mockClient *mocks.MockEC2ClientAPI, times int) {
+	mockClient.EXPECT().DescribeInstances(gomock.Any(), gomock.Any()).Times(times).
+		Return(&ec2.DescribeInstancesOutput{Reservations: []types.Reservation{{
+			Instances: []types.Instance{{
+				InstanceType: types.InstanceTypeM5Large,
+				Placement:    &types.Placement{AvailabilityZone: aws.String("us-east-1a")},
+				NetworkInterfaces: []types.InstanceNetworkInterface{
+					{Attachment: &types.InstanceNetworkInterfaceAttachment{DeviceIndex: aws.Int32(0), NetworkCardIndex: aws.Int32(0)}},
+					{Attachment: &types.InstanceNetworkInterfaceAttachment{DeviceIndex: aws.Int32(1), NetworkCardIndex: aws.Int32(0)}},
+				},
+			}},
+		}}}, nil)
+}
+
+func TestMoveENI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	gomock.InOrder(
+		mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).Return(movingENI(types.NetworkInterfaceStatusInUse), nil),
+		mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).Return(movingENI(types.NetworkInterfaceStatusDetaching), nil),
+		mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).Return(movingENI(types.NetworkInterfaceStatusAvailable), nil),
+	)
+	expectTarget(mockClient, 2)
+	mockClient.EXPECT().DescribeInstanceTypes(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeInstanceTypesOutput{InstanceTypes: []types.InstanceTypeInfo{{
+			NetworkInfo: &types.NetworkInfo{MaximumNetworkInterfaces: aws.Int32(3)},
+		}}}, nil)
+	mockClient.EXPECT().DetachNetworkInterface(gomock.Any(), gomock.Eq(&ec2.DetachNetworkInterfaceInput{
+		AttachmentId: aws.String("eni-attach-source"),
+		Force:        aws.Bool(false),
+	})).Return(&ec2.DetachNetworkInterfaceOutput{}, nil)
+	mockClient.EXPECT().AttachNetworkInterface(gomock.Any(), gomock.Eq(&ec2.AttachNetworkInterfaceInput{
+		NetworkInterfaceId: aws.String("eni-12345678"),
+		InstanceId:         aws.String("i-target"),
+		DeviceIndex:        aws.Int32(2),
+		NetworkCardIndex:   aws.Int32(0),
+	})).Return(&ec2.AttachNetworkInterfaceOutput{AttachmentId: aws.String("eni-attach-target")}, nil)
+
+	result, err := manager.MoveENI(context.Background(), "eni-12345678", "i-target", MoveConfig{PollInterval: time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, &MoveResult{
+		ENIID:           "eni-12345678",
+		FromInstanceID:  "i-source",
+		FromDeviceIndex: 2,
+		ToInstanceID:    "i-target",
+		DeviceIndex:     2,
+		AttachmentID:    "eni-attach-target",
+	}, result)
+}
+
+func TestMoveENI_RollsBack(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	gomock.InOrder(
+		mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).Return(movingENI(types.NetworkInterfaceStatusInUse), nil),
+		mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).Return(movingENI(types.NetworkInterfaceStatusAvailable), nil),
+	)
+	expectTarget(mockClient, 1)
+	mockClient.EXPECT().DetachNetworkInterface(gomock.Any(), gomock.Any()).Return(&ec2.DetachNetworkInterfaceOutput{}, nil)
+	gomock.InOrder(
+		mockClient.EXPECT().AttachNetworkInterface(gomock.Any(), gomock.Eq(&ec2.AttachNetworkInterfaceInput{
+			NetworkInterfaceId: aws.String("eni-12345678"),
+			InstanceId:         aws.String("i-target"),
+			DeviceIndex:        aws.Int32(1),
+			NetworkCardIndex:   aws.Int32(0),
+		})).Return(nil, &smithy.GenericAPIError{Code: "InvalidParameterValue", Message: "device index 1 is in use"}),
+		mockClient.EXPECT().AttachNetworkInterface(gomock.Any(), gomock.Eq(&ec2.AttachNetworkInterfaceInput{
+			NetworkInterfaceId: aws.String("eni-12345678"),
+			InstanceId:         aws.String("i-source"),
+			DeviceIndex:        aws.Int32(2),
+			NetworkCardIndex:   aws.Int32(0),
+		})).Return(&ec2.AttachNetworkInterfaceOutput{AttachmentId: aws.String("eni-attach-rollback")}, nil),
+	)
+
+	result, err := manager.MoveENI(context.Background(), "eni-12345678", "i-target",
+		MoveConfig{DeviceIndex: aws.Int32(1), PollInterval: time.Millisecond})
+	assert.ErrorContains(t, err, "moved ENI eni-12345678 back to i-source")
+	assert.Equal(t, "InvalidParameterValue", ErrorCode(err))
+	if assert.NotNil(t, result) {
+		assert.True(t, result.RolledBack)
+		assert.Equal(t, "eni-attach-rollback", result.AttachmentID)
+	}
+}
+
+func TestMoveENI_Rejected(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	primary := movingENI(types.NetworkInterfaceStatusInUse)
+	primary.NetworkInterfaces[0].Attachment.DeviceIndex = aws.Int32(0)
+	otherAZ := movingENI(types.NetworkInterfaceStatusInUse)
+	otherAZ.NetworkInterfaces[0].AvailabilityZone = aws.String("us-east-1b")
+	gomock.InOrder(
+		mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).Return(primary, nil),
+		mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).Return(movingENI(types.NetworkInterfaceStatusAvailable), nil),
+		mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).Return(otherAZ, nil),
+	)
+	expectTarget(mockClient, 1)
+
+	// Nothing is detached when the move can't work
+	_, err := manager.MoveENI(context.Background(), "eni-12345678", "i-target", MoveConfig{})
+	assert.ErrorContains(t, err, "primary ENI of i-source")
+	_, err = manager.MoveENI(context.Background(), "eni-12345678", "i-target", MoveConfig{})
+	assert.ErrorContains(t, err, "not attached")
+	_, err = manager.MoveENI(context.Background(), "eni-12345678", "i-target", MoveConfig{})
+	assert.ErrorContains(t, err, "is in us-east-1b but i-target is in us-east-1a")
+}
+
+func TestMoveENI_RollsBackStuckDetach(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	gomock.InOrder(
+		mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).Return(movingENI(types.NetworkInterfaceStatusInUse), nil),
+		mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).AnyTimes().
+			Return(movingENI(types.NetworkInterfaceStatusDetaching), nil),
+	)
+	expectTarget(mockClient, 1)
+	mockClient.EXPECT().DetachNetworkInterface(gomock.Any(), gomock.Any()).Return(&ec2.DetachNetworkInterfaceOutput{}, nil)
+	// The detach never finishes, so the ENI goes back to i-source on a
+	// context that outlives the expired request
+	mockClient.EXPECT().AttachNetworkInterface(gomock.Any(), gomock.Eq(&ec2.AttachNetworkInterfaceInput{
+		NetworkInterfaceId: aws.String("eni-12345678"),
+		InstanceId:         aws.String("i-source"),
+		DeviceIndex:        aws.Int32(2),
+		NetworkCardIndex:   aws.Int32(0),
+	})).DoAndReturn(func(ctx context.Context, _ *ec2.AttachNetworkInterfaceInput, _ ...func(*ec2.Options)) (*ec2.AttachNetworkInterfaceOutput, error) {
+		assert.NoError(t, ctx.Err())
+		return &ec2.AttachNetworkInterfaceOutput{AttachmentId: aws.String("eni-attach-rollback")}, nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	result, err := manager.MoveENI(ctx, "eni-12345678", "i-target",
+		MoveConfig{DeviceIndex: aws.Int32(1), PollInterval: time.Millisecond, DetachTimeout: time.Hour})
+	assert.ErrorContains(t, err, "moved ENI eni-12345678 back to i-source")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	if assert.NotNil(t, result) {
+		assert.True(t, result.RolledBack)
+		assert.Equal(t, "eni-attach-rollback", result.AttachmentID)
+	}
+}
+
+func TestMoveENI_RollbackFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	gomock.InOrder(
+		mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).Return(movingENI(types.NetworkInterfaceStatusInUse), nil),
+		mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).AnyTimes().
+			Return(movingENI(types.NetworkInterfaceStatusDetaching), nil),
+	)
+	expectTarget(mockClient, 1)
+	mockClient.EXPECT().DetachNetworkInterface(gomock.Any(), gomock.Any()).Return(&ec2.DetachNetworkInterfaceOutput{}, nil)
+	mockClient.EXPECT().AttachNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(nil, &smithy.GenericAPIError{Code: "IncorrectState", Message: "interface is detaching"})
+
+	result, err := manager.MoveENI(context.Background(), "eni-12345678", "i-target",
+		MoveConfig{DeviceIndex: aws.Int32(1), PollInterval: time.Millisecond, DetachTimeout: 10 * time.Millisecond})
+	assert.ErrorContains(t, err, "ENI eni-12345678 is left detached")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	if assert.NotNil(t, result) {
+		assert.False(t, result.RolledBack)
+		assert.Equal(t, "i-source", result.FromInstanceID)
+		assert.Equal(t, int32(2), result.FromDeviceIndex)
+	}
+}
@@ -0,0 +1,182 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// AttachStrategy decides which network card each ENI of a batch goes to
+type AttachStrategy string
+
+const (
+	// AttachSpread puts each ENI on the card with the most free interfaces,
+	// balancing bandwidth across cards
+	AttachSpread AttachStrategy = "spread"
+	// AttachPack fills the lowest-numbered card with room first, leaving
+	// whole cards free for later
+	AttachPack AttachStrategy = "pack"
+)
+
+// ParseAttachStrategy parses an attach strategy; "" means spread
+func ParseAttachStrategy(s string) (AttachStrategy, error) {
+	switch strategy := AttachStrategy(s); strategy {
+	case "":
+		return AttachSpread, nil
+	case AttachSpread, AttachPack:
+		return strategy, nil
+	default:
+		return "", fmt.Errorf("invalid attach strategy %q (want spread or pack)", s)
+	}
+}
+
+// NetworkCard is one network card of an instance and the interfaces on it
+type NetworkCard struct {
+	Index int32
+	// MaxInterfaces is the card's interface limit from DescribeInstanceTypes
+	MaxInterfaces int32
+	// DeviceIndexes are the device indexes in use on the card
+	DeviceIndexes []int32
+}
+
+// Free returns how many more interfaces the card can take
+func (c NetworkCard) Free() int32 {
+	return max(c.MaxInterfaces-int32(len(c.DeviceIndexes)), 0)
+}
+
+// InstanceNetworkCards are the network cards of an instance
+type InstanceNetworkCards struct {
+	InstanceID   string
+	InstanceType string
+	Cards        []NetworkCard
+}
+
+// NetworkCards reads an instance's network cards and their interface limits
+// from DescribeInstanceTypes and their current usage from DescribeInstances.
+// Instance types without per-card information have a single card 0.
+func (m *ENIManager) NetworkCards(ctx context.Context, instanceID string) (*InstanceNetworkCards, error) {
+	instance, err := m.DescribeInstance(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	info, err := m.DescribeInstanceType(ctx, string(instance.InstanceType))
+	if err != nil {
+		return nil, err
+	}
+	if info.NetworkInfo == nil {
+		return nil, fmt.Errorf("instance type %s has no network information", instance.InstanceType)
+	}
+
+	result := &InstanceNetworkCards{InstanceID: instanceID, InstanceType: string(instance.InstanceType)}
+	byIndex := make(map[int32]*NetworkCard)
+	for _, card := range info.NetworkInfo.NetworkCards {
+		result.Cards = append(result.Cards, NetworkCard{
+			Index:         aws.ToInt32(card.NetworkCardIndex),
+			MaxInterfaces: aws.ToInt32(card.MaximumNetworkInterfaces),
+		})
+	}
+	if len(result.Cards) == 0 {
+		result.Cards = []NetworkCard{{MaxInterfaces: aws.ToInt32(info.NetworkInfo.MaximumNetworkInterfaces)}}
+	}
+	slices.SortFunc(result.Cards, func(a, b NetworkCard) int { return int(a.Index - b.Index) })
+	for i := range result.Cards {
+		byIndex[result.Cards[i].Index] = &result.Cards[i]
+	}
+
+	for _, eni := range instance.NetworkInterfaces {
+		if eni.Attachment == nil {
+			continue
+		}
+		card, ok := byIndex[aws.ToInt32(eni.Attachment.NetworkCardIndex)]
+		if !ok {
+			continue
+		}
+		card.DeviceIndexes = append(card.DeviceIndexes, aws.ToInt32(eni.Attachment.DeviceIndex))
+	}
+	return result, nil
+}
+
+// PlannedAttachment is where PlanAttachments places an ENI. AttachmentID is
+// set once AttachENIs has attached it.
+type PlannedAttachment struct {
+	ENIID            string
+	NetworkCardIndex int32
+	DeviceIndex      int32
+	AttachmentID     string
+}
+
+// PlanAttachments assigns each ENI a network card by strategy and the lowest
+// free device index on that card, starting at 1 since device 0 holds the
+// primary interface. It fails without a plan if the cards don't have room
+// for every ENI. cards is not modified.
+func PlanAttachments(cards []NetworkCard, eniIDs []string, strategy AttachStrategy) ([]PlannedAttachment, error) {
+	var free int32
+	for _, card := range cards {
+		free += card.Free()
+	}
+	if int32(len(eniIDs)) > free {
+		return nil, fmt.Errorf("not enough network interface slots: %d ENIs for %d free", len(eniIDs), free)
+	}
+
+	// Work on copies so the caller's usage is left alone
+	cards = slices.Clone(cards)
+	for i := range cards {
+		cards[i].DeviceIndexes = slices.Clone(cards[i].DeviceIndexes)
+	}
+
+	plan := make([]PlannedAttachment, 0, len(eniIDs))
+	for _, eniID := range eniIDs {
+		best := -1
+		for i, card := range cards {
+			if card.Free() == 0 {
+				continue
+			}
+			if best == -1 {
+				best = i
+				if strategy == AttachPack {
+					break
+				}
+				continue
+			}
+			if card.Free() > cards[best].Free() {
+				best = i
+			}
+		}
+
+		card := &cards[best]
+		device := int32(1)
+		for slices.Contains(card.DeviceIndexes, device) {
+			device++
+		}
+		card.DeviceIndexes = append(card.DeviceIndexes, device)
+		plan = append(plan, PlannedAttachment{ENIID: eniID, NetworkCardIndex: card.Index, DeviceIndex: device})
+	}
+	return plan, nil
+}
+
+// AttachENIs plans the attachment of a batch of ENIs across the network
+// cards of an instance and attaches them in order. The plan is returned with
+// the attachment IDs filled in as far as it got, together with the first
+// error. It needs the instance's cards, so it fails in offline dry runs.
+func (m *ENIManager) AttachENIs(ctx context.Context, instanceID string, eniIDs []string, strategy AttachStrategy) ([]PlannedAttachment, error) {
+	cards, err := m.NetworkCards(ctx, instanceID)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := PlanAttachments(cards.Cards, eniIDs, strategy)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range plan {
+		p := &plan[i]
+		attachmentID, err := m.AttachENIToCard(ctx, p.ENIID, instanceID, p.DeviceIndex, p.NetworkCardIndex)
+		if err != nil {
+			return plan, err
+		}
+		p.AttachmentID = aws.ToString(attachmentID)
+	}
+	return plan, nil
+}
@@ -0,0 +1,93 @@
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanAttachments(t *testing.T) {
+	cards := []NetworkCard{
+		{Index: 0, MaxInterfaces: 3, DeviceIndexes: []int32{0}},
+		{Index: 1, MaxInterfaces: 3},
+	}
+	enis := []string{"eni-a", "eni-b", "eni-c"}
+
+	tests := []struct {
+		strategy AttachStrategy
+		want     []PlannedAttachment
+	}{
+		{AttachSpread, []PlannedAttachment{
+			{ENIID: "eni-a", NetworkCardIndex: 1, DeviceIndex: 1},
+			{ENIID: "eni-b", NetworkCardIndex: 0, DeviceIndex: 1},
+			{ENIID: "eni-c", NetworkCardIndex: 1, DeviceIndex: 2},
+		}},
+		{AttachPack, []PlannedAttachment{
+			{ENIID: "eni-a", NetworkCardIndex: 0, DeviceIndex: 1},
+			{ENIID: "eni-b", NetworkCardIndex: 0, DeviceIndex: 2},
+			{ENIID: "eni-c", NetworkCardIndex: 1, DeviceIndex: 1},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			plan, err := PlanAttachments(cards, enis, tt.strategy)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, plan)
+		})
+	}
+	assert.Equal(t, []int32{0}, cards[0].DeviceIndexes, "cards must not be modified")
+}
+
+func TestPlanAttachments_NoRoom(t *testing.T) {
+	cards := []NetworkCard{{Index: 0, MaxInterfaces: 2, DeviceIndexes: []int32{0}}}
+
+	_, err := PlanAttachments(cards, []string{"eni-a", "eni-b"}, AttachSpread)
+	assert.ErrorContains(t, err, "2 ENIs for 1 free")
+}
+
+func TestAttachENIs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	mockClient.EXPECT().DescribeInstances(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeInstancesOutput{Reservations: []types.Reservation{{
+			Instances: []types.Instance{{
+				InstanceType: types.InstanceTypeP548xlarge,
+				NetworkInterfaces: []types.InstanceNetworkInterface{
+					{Attachment: &types.InstanceNetworkInterfaceAttachment{DeviceIndex: aws.Int32(0), NetworkCardIndex: aws.Int32(0)}},
+					{Attachment: &types.InstanceNetworkInterfaceAttachment{DeviceIndex: aws.Int32(1), NetworkCardIndex: aws.Int32(1)}},
+				},
+			}},
+		}}}, nil)
+	mockClient.EXPECT().DescribeInstanceTypes(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeInstanceTypesOutput{InstanceTypes: []types.InstanceTypeInfo{{
+			NetworkInfo: &types.NetworkInfo{NetworkCards: []types.NetworkCardInfo{
+				{NetworkCardIndex: aws.Int32(1), MaximumNetworkInterfaces: aws.Int32(2)},
+				{NetworkCardIndex: aws.Int32(0), MaximumNetworkInterfaces: aws.Int32(2)},
+			}},
+		}}}, nil)
+	for card, eniID := range []string{"eni-a", "eni-b"} {
+		mockClient.EXPECT().AttachNetworkInterface(gomock.Any(), gomock.Eq(&ec2.AttachNetworkInterfaceInput{
+			NetworkInterfaceId: aws.String(eniID),
+			InstanceId:         aws.String("i-12345678"),
+			DeviceIndex:        aws.Int32(1 + int32(card)),
+			NetworkCardIndex:   aws.Int32(int32(card)),
+		})).Return(&ec2.AttachNetworkInterfaceOutput{AttachmentId: aws.String("eni-attach-" + eniID)}, nil)
+	}
+
+	plan, err := manager.AttachENIs(context.Background(), "i-12345678", []string{"eni-a", "eni-b"}, AttachSpread)
+	assert.NoError(t, err)
+	if assert.Len(t, plan, 2) {
+		assert.Equal(t, "eni-attach-eni-a", plan[0].AttachmentID)
+		assert.Equal(t, int32(1), plan[1].NetworkCardIndex)
+	}
+}
@@ -0,0 +1,121 @@
+package ec2
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// logKeys maps span attribute keys to the field names used in log events
+var logKeys = map[attribute.Key]string{
+	AttrENIID:           "eni_id",
+	AttrInstanceID:      "instance_id",
+	AttrSubnetID:        "subnet_id",
+	AttrAttachmentID:    "attachment_id",
+	AttrSecurityGroupID: "security_group_id",
+	AttrTrunkENIID:      "trunk_eni_id",
+	AttrAssociationID:   "association_id",
+}
+
+// operation tracks a single ENIManager call so its outcome is reported to
+// tracing, metrics and the log with the same set of resource IDs
+type operation struct {
+	m     *ENIManager
+	ctx   context.Context
+	name  string
+	start time.Time
+	span  trace.Span
+	attrs []attribute.KeyValue
+	// mutating is false for read-only Describe operations
+	mutating bool
+	// hooked is set by before for mutations that fire hooks
+	hooked bool
+	// input and requestID describe the EC2 call for the audit log
+	input     any
+	requestID string
+}
+
+// begin starts an operation; every operation defers op.end with its named
+// error result to record the outcome
+func (m *ENIManager) begin(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, *operation) {
+	ctx, span := m.tracer.Start(ctx, "ENIManager."+name, trace.WithAttributes(attrs...))
+	return ctx, &operation{
+		m:     m,
+		ctx:   ctx,
+		name:  name,
+		start: time.Now(),
+		span:  span,
+		attrs: attrs,
+
+		mutating: !strings.HasPrefix(name, "Describe"),
+	}
+}
+
+// set records resource IDs learned part way through the operation
+func (op *operation) set(attrs ...attribute.KeyValue) {
+	op.span.SetAttributes(attrs...)
+	op.attrs = append(op.attrs, attrs...)
+}
+
+// record notes the EC2 request and response for the audit log
+func (op *operation) record(input, output any) {
+	op.input = input
+	op.requestID = RequestID(output, nil)
+}
+
+// resources returns the operation's resource IDs keyed by log field name
+func (op *operation) resources() map[string]string {
+	resources := make(map[string]string)
+	for _, kv := range op.attrs {
+		if key, ok := logKeys[kv.Key]; ok && kv.Value.AsString() != "" {
+			resources[key] = kv.Value.AsString()
+		}
+	}
+	return resources
+}
+
+func (op *operation) end(err *error) {
+	duration := time.Since(op.start)
+	op.m.metrics.observe(op.name, duration, *err)
+	op.log(duration, *err)
+	endSpan(op.span, *err)
+	if op.mutating && op.m.audit != nil && op.m.dryRun == DryRunOff {
+		op.audit(duration, *err)
+	}
+	if op.hooked && *err == nil {
+		op.after()
+	}
+}
+
+func (op *operation) log(duration time.Duration, err error) {
+	if op.m.logger == nil {
+		return
+	}
+
+	// Reads are routine; only mutations are worth reporting at info
+	level := slog.LevelInfo
+	if !op.mutating {
+		level = slog.LevelDebug
+	}
+
+	fields := []slog.Attr{slog.String("operation", op.name)}
+	for _, kv := range op.attrs {
+		if key, ok := logKeys[kv.Key]; ok && kv.Value.AsString() != "" {
+			fields = append(fields, slog.String(key, kv.Value.AsString()))
+		}
+	}
+	fields = append(fields, slog.Duration("duration", duration))
+
+	msg := "operation succeeded"
+	if err != nil {
+		level = slog.LevelError
+		msg = "operation failed"
+		fields = append(fields, slog.String("error_code", ErrorCode(err)), slog.String("error", err.Error()))
+	}
+
+	op.m.logger.LogAttrs(op.ctx, level, msg, fields...)
+}
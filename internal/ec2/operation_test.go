@@ -0,0 +1,78 @@
+// internal/ec2/operation_test.go
+package ec2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func decodeLogLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var lines []map[string]any
+	dec := json.NewDecoder(buf)
+	for dec.More() {
+		var line map[string]any
+		assert.NoError(t, dec.Decode(&line))
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+func TestENIManager_Logging(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient, WithLogger(logger.With("run_id", "run-1")))
+
+	mockClient.EXPECT().
+		AttachNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(&ec2.AttachNetworkInterfaceOutput{AttachmentId: aws.String("eni-attach-12345678")}, nil)
+	mockClient.EXPECT().
+		DescribeSubnets(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeSubnetsOutput{}, nil)
+	mockClient.EXPECT().
+		DeleteNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(nil, &smithy.GenericAPIError{Code: "InvalidNetworkInterfaceID.NotFound"})
+
+	ctx := context.Background()
+	_, err := manager.AttachENI(ctx, "eni-12345678", "i-12345678", 1)
+	assert.NoError(t, err)
+	// Describe calls log at debug and are filtered out
+	_, err = manager.DescribeSubnet(ctx, "subnet-12345678")
+	assert.NoError(t, err)
+	assert.Error(t, manager.DeleteENI(ctx, "eni-87654321"))
+
+	lines := decodeLogLines(t, &buf)
+	assert.Len(t, lines, 2)
+
+	attach := lines[0]
+	assert.Equal(t, "INFO", attach["level"])
+	assert.Equal(t, "run-1", attach["run_id"])
+	assert.Equal(t, "AttachENI", attach["operation"])
+	assert.Equal(t, "eni-12345678", attach["eni_id"])
+	assert.Equal(t, "i-12345678", attach["instance_id"])
+	assert.Equal(t, "eni-attach-12345678", attach["attachment_id"])
+	assert.Contains(t, attach, "duration")
+	assert.NotContains(t, attach, "error_code")
+
+	del := lines[1]
+	assert.Equal(t, "ERROR", del["level"])
+	assert.Equal(t, "DeleteENI", del["operation"])
+	assert.Equal(t, "eni-87654321", del["eni_id"])
+	assert.Equal(t, "InvalidNetworkInterfaceID.NotFound", del["error_code"])
+}
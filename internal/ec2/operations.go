@@ -3,41 +3,87 @@ package ec2
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"sort"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/ec2"
 	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ENIManager struct {
-	client EC2ClientAPI
+	client  EC2ClientAPI
+	metrics *Metrics
+	tracer  trace.Tracer
+	logger  *slog.Logger
+	hooks   []HookRule
+	policy  Policy
+	audit   *AuditLog
+	dryRun  DryRunMode
+	// branchLimits overrides DefaultBranchLimits per instance type
+	branchLimits map[string]int32
 }
 
-func NewENIManager(client EC2ClientAPI) *ENIManager {
-	return &ENIManager{client: client}
+// Option configures optional ENIManager behaviour
+type Option func(*ENIManager)
+
+// WithMetrics reports operation counts, latencies and inventory to metrics
+func WithMetrics(metrics *Metrics) Option {
+	return func(m *ENIManager) {
+		m.metrics = metrics
+	}
 }
 
-func (m *ENIManager) CreateENI(ctx context.Context, config ENIConfig) (*ec2.CreateNetworkInterfaceOutput, error) {
-	var tags []types.TagSpecification
-	if len(config.Tags) > 0 {
-		var tagList []types.Tag
-		for k, v := range config.Tags {
-			tagList = append(tagList, types.Tag{
-				Key:   aws.String(k),
-				Value: aws.String(v),
-			})
-		}
-		tags = append(tags, types.TagSpecification{
-			ResourceType: types.ResourceTypeNetworkInterface,
-			Tags:         tagList,
-		})
+// WithTracerProvider traces operations with provider instead of the global one
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(m *ENIManager) {
+		m.tracer = provider.Tracer(tracerName)
+	}
+}
+
+// WithLogger emits a structured event for every operation to logger
+func WithLogger(logger *slog.Logger) Option {
+	return func(m *ENIManager) {
+		m.logger = logger
+	}
+}
+
+func NewENIManager(client EC2ClientAPI, opts ...Option) *ENIManager {
+	m := &ENIManager{client: client, tracer: otel.Tracer(tracerName)}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+func (m *ENIManager) CreateENI(ctx context.Context, config ENIConfig) (_ *ec2.CreateNetworkInterfaceOutput, err error) {
+	ctx, op := m.begin(ctx, "CreateENI", AttrSubnetID.String(config.SubnetID))
+	defer op.end(&err)
+	if err = m.checkPolicy(ctx, PolicyRequest{
+		Operation:        "CreateENI",
+		SubnetID:         config.SubnetID,
+		Tags:             config.Tags,
+		SecurityGroupIDs: config.SecurityGroupIDs,
+		AddedIPs:         1 + config.PrivateIPCount + config.IPv6AddressCount,
+	}); err != nil {
+		return nil, err
+	}
+	if err = op.before(); err != nil {
+		return nil, err
 	}
 
 	input := &ec2.CreateNetworkInterfaceInput{
 		SubnetId:          aws.String(config.SubnetID),
 		Description:       aws.String(config.Description),
 		Groups:            config.SecurityGroupIDs,
-		TagSpecifications: tags,
+		TagSpecifications: tagSpecifications(types.ResourceTypeNetworkInterface, config.Tags),
+		InterfaceType:     config.InterfaceType,
+	}
+
+	if config.PrivateIPAddress != "" {
+		input.PrivateIpAddress = aws.String(config.PrivateIPAddress)
 	}
 
 	if config.PrivateIPCount > 0 {
@@ -48,31 +94,98 @@ func (m *ENIManager) CreateENI(ctx context.Context, config ENIConfig) (*ec2.Crea
 		input.Ipv6AddressCount = aws.Int32(config.IPv6AddressCount)
 	}
 
-	return m.client.CreateNetworkInterface(ctx, input)
+	output, err := m.client.CreateNetworkInterface(ctx, input)
+	op.record(input, output)
+	if err != nil {
+		return nil, err
+	}
+
+	if output.NetworkInterface != nil {
+		op.set(AttrENIID.String(aws.ToString(output.NetworkInterface.NetworkInterfaceId)))
+	}
+	return output, nil
+}
+
+// tagSpecifications tags a resource on creation; nil if there are no tags
+func tagSpecifications(resourceType types.ResourceType, tags map[string]string) []types.TagSpecification {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	// Sorted so the request is deterministic
+	sort.Strings(keys)
+
+	var tagList []types.Tag
+	for _, k := range keys {
+		tagList = append(tagList, types.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(tags[k]),
+		})
+	}
+	return []types.TagSpecification{{ResourceType: resourceType, Tags: tagList}}
+}
+
+func (m *ENIManager) AttachENI(ctx context.Context, networkInterfaceID, instanceID string, deviceIndex int32) (_ *string, err error) {
+	return m.attachENI(ctx, networkInterfaceID, instanceID, deviceIndex, nil)
+}
+
+// AttachENIToCard attaches an ENI at deviceIndex on a specific network card
+// of a multi-card instance. Device indexes are numbered per card.
+func (m *ENIManager) AttachENIToCard(ctx context.Context, networkInterfaceID, instanceID string, deviceIndex, networkCardIndex int32) (_ *string, err error) {
+	return m.attachENI(ctx, networkInterfaceID, instanceID, deviceIndex, &networkCardIndex)
 }
 
-func (m *ENIManager) AttachENI(ctx context.Context, networkInterfaceID, instanceID string, deviceIndex int32) (*string, error) {
+func (m *ENIManager) attachENI(ctx context.Context, networkInterfaceID, instanceID string, deviceIndex int32, networkCardIndex *int32) (_ *string, err error) {
+	ctx, op := m.begin(ctx, "AttachENI", AttrENIID.String(networkInterfaceID), AttrInstanceID.String(instanceID))
+	defer op.end(&err)
+	if err = m.checkPolicy(ctx, PolicyRequest{
+		Operation:        "AttachENI",
+		ENIID:            networkInterfaceID,
+		InstanceID:       instanceID,
+		DeviceIndex:      &deviceIndex,
+		NetworkCardIndex: networkCardIndex,
+	}); err != nil {
+		return nil, err
+	}
+	if err = op.before(); err != nil {
+		return nil, err
+	}
+
 	input := &ec2.AttachNetworkInterfaceInput{
 		NetworkInterfaceId: aws.String(networkInterfaceID),
 		InstanceId:         aws.String(instanceID),
 		DeviceIndex:        aws.Int32(deviceIndex),
+		NetworkCardIndex:   networkCardIndex,
 	}
 
 	result, err := m.client.AttachNetworkInterface(ctx, input)
+	op.record(input, result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to attach ENI: %w", err)
 	}
 
+	op.set(AttrAttachmentID.String(aws.ToString(result.AttachmentId)))
 	return result.AttachmentId, nil
 }
 
-func (m *ENIManager) DetachENI(ctx context.Context, attachmentID string, force bool) error {
+func (m *ENIManager) DetachENI(ctx context.Context, attachmentID string, force bool) (err error) {
+	ctx, op := m.begin(ctx, "DetachENI", AttrAttachmentID.String(attachmentID))
+	defer op.end(&err)
+	if err = op.before(); err != nil {
+		return err
+	}
+
 	input := &ec2.DetachNetworkInterfaceInput{
 		AttachmentId: aws.String(attachmentID),
 		Force:        aws.Bool(force),
 	}
 
-	_, err := m.client.DetachNetworkInterface(ctx, input)
+	output, err := m.client.DetachNetworkInterface(ctx, input)
+	op.record(input, output)
 	if err != nil {
 		return fmt.Errorf("failed to detach ENI: %w", err)
 	}
@@ -80,12 +193,19 @@ func (m *ENIManager) DetachENI(ctx context.Context, attachmentID string, force b
 	return nil
 }
 
-func (m *ENIManager) DeleteENI(ctx context.Context, networkInterfaceID string) error {
+func (m *ENIManager) DeleteENI(ctx context.Context, networkInterfaceID string) (err error) {
+	ctx, op := m.begin(ctx, "DeleteENI", AttrENIID.String(networkInterfaceID))
+	defer op.end(&err)
+	if err = op.before(); err != nil {
+		return err
+	}
+
 	input := &ec2.DeleteNetworkInterfaceInput{
 		NetworkInterfaceId: aws.String(networkInterfaceID),
 	}
 
-	_, err := m.client.DeleteNetworkInterface(ctx, input)
+	output, err := m.client.DeleteNetworkInterface(ctx, input)
+	op.record(input, output)
 	if err != nil {
 		return fmt.Errorf("failed to delete ENI: %w", err)
 	}
@@ -93,7 +213,20 @@ func (m *ENIManager) DeleteENI(ctx context.Context, networkInterfaceID string) e
 	return nil
 }
 
-func (m *ENIManager) ModifyENIAttribute(ctx context.Context, networkInterfaceID string, config ENIModifyConfig) error {
+func (m *ENIManager) ModifyENIAttribute(ctx context.Context, networkInterfaceID string, config ENIModifyConfig) (err error) {
+	ctx, op := m.begin(ctx, "ModifyENIAttribute", AttrENIID.String(networkInterfaceID))
+	defer op.end(&err)
+	if err = m.checkPolicy(ctx, PolicyRequest{
+		Operation:        "ModifyENIAttribute",
+		ENIID:            networkInterfaceID,
+		SecurityGroupIDs: config.SecurityGroupIDs,
+	}); err != nil {
+		return err
+	}
+	if err = op.before(); err != nil {
+		return err
+	}
+
 	input := &ec2.ModifyNetworkInterfaceAttributeInput{
 		NetworkInterfaceId: aws.String(networkInterfaceID),
 	}
@@ -106,7 +239,8 @@ func (m *ENIManager) ModifyENIAttribute(ctx context.Context, networkInterfaceID
 		input.Groups = config.SecurityGroupIDs
 	}
 
-	_, err := m.client.ModifyNetworkInterfaceAttribute(ctx, input)
+	output, err := m.client.ModifyNetworkInterfaceAttribute(ctx, input)
+	op.record(input, output)
 	if err != nil {
 		return fmt.Errorf("failed to modify ENI attribute: %w", err)
 	}
@@ -114,7 +248,68 @@ func (m *ENIManager) ModifyENIAttribute(ctx context.Context, networkInterfaceID
 	return nil
 }
 
-func (m *ENIManager) AssignPrivateIPs(ctx context.Context, networkInterfaceID string, count int32, specificIPs []string) error {
+// TagENI adds tags to an ENI, overwriting the values of existing keys
+func (m *ENIManager) TagENI(ctx context.Context, networkInterfaceID string, tags map[string]string) (err error) {
+	ctx, op := m.begin(ctx, "TagENI", AttrENIID.String(networkInterfaceID))
+	defer op.end(&err)
+	if err = op.before(); err != nil {
+		return err
+	}
+
+	input := &ec2.CreateTagsInput{
+		Resources: []string{networkInterfaceID},
+	}
+	for _, spec := range tagSpecifications(types.ResourceTypeNetworkInterface, tags) {
+		input.Tags = spec.Tags
+	}
+
+	output, err := m.client.CreateTags(ctx, input)
+	op.record(input, output)
+	if err != nil {
+		return fmt.Errorf("failed to tag ENI: %w", err)
+	}
+
+	return nil
+}
+
+// UntagENI removes the tags with the given keys from an ENI
+func (m *ENIManager) UntagENI(ctx context.Context, networkInterfaceID string, keys []string) (err error) {
+	ctx, op := m.begin(ctx, "UntagENI", AttrENIID.String(networkInterfaceID))
+	defer op.end(&err)
+	if err = op.before(); err != nil {
+		return err
+	}
+
+	input := &ec2.DeleteTagsInput{
+		Resources: []string{networkInterfaceID},
+	}
+	for _, key := range keys {
+		input.Tags = append(input.Tags, types.Tag{Key: aws.String(key)})
+	}
+
+	output, err := m.client.DeleteTags(ctx, input)
+	op.record(input, output)
+	if err != nil {
+		return fmt.Errorf("failed to untag ENI: %w", err)
+	}
+
+	return nil
+}
+
+func (m *ENIManager) AssignPrivateIPs(ctx context.Context, networkInterfaceID string, count int32, specificIPs []string) (err error) {
+	ctx, op := m.begin(ctx, "AssignPrivateIPs", AttrENIID.String(networkInterfaceID))
+	defer op.end(&err)
+	if err = m.checkPolicy(ctx, PolicyRequest{
+		Operation: "AssignPrivateIPs",
+		ENIID:     networkInterfaceID,
+		AddedIPs:  count + int32(len(specificIPs)),
+	}); err != nil {
+		return err
+	}
+	if err = op.before(); err != nil {
+		return err
+	}
+
 	input := &ec2.AssignPrivateIpAddressesInput{
 		NetworkInterfaceId: aws.String(networkInterfaceID),
 	}
@@ -127,7 +322,8 @@ func (m *ENIManager) AssignPrivateIPs(ctx context.Context, networkInterfaceID st
 		input.PrivateIpAddresses = specificIPs
 	}
 
-	_, err := m.client.AssignPrivateIpAddresses(ctx, input)
+	output, err := m.client.AssignPrivateIpAddresses(ctx, input)
+	op.record(input, output)
 	if err != nil {
 		return fmt.Errorf("failed to assign private IPs: %w", err)
 	}
@@ -135,13 +331,20 @@ func (m *ENIManager) AssignPrivateIPs(ctx context.Context, networkInterfaceID st
 	return nil
 }
 
-func (m *ENIManager) UnassignPrivateIPs(ctx context.Context, networkInterfaceID string, ips []string) error {
+func (m *ENIManager) UnassignPrivateIPs(ctx context.Context, networkInterfaceID string, ips []string) (err error) {
+	ctx, op := m.begin(ctx, "UnassignPrivateIPs", AttrENIID.String(networkInterfaceID))
+	defer op.end(&err)
+	if err = op.before(); err != nil {
+		return err
+	}
+
 	input := &ec2.UnassignPrivateIpAddressesInput{
 		NetworkInterfaceId: aws.String(networkInterfaceID),
 		PrivateIpAddresses: ips,
 	}
 
-	_, err := m.client.UnassignPrivateIpAddresses(ctx, input)
+	output, err := m.client.UnassignPrivateIpAddresses(ctx, input)
+	op.record(input, output)
 	if err != nil {
 		return fmt.Errorf("failed to unassign private IPs: %w", err)
 	}
@@ -149,7 +352,24 @@ func (m *ENIManager) UnassignPrivateIPs(ctx context.Context, networkInterfaceID
 	return nil
 }
 
-func (m *ENIManager) AssignIPv6Addresses(ctx context.Context, networkInterfaceID string, addresses []string, count *int32) error {
+func (m *ENIManager) AssignIPv6Addresses(ctx context.Context, networkInterfaceID string, addresses []string, count *int32) (err error) {
+	ctx, op := m.begin(ctx, "AssignIPv6Addresses", AttrENIID.String(networkInterfaceID))
+	defer op.end(&err)
+	added := int32(len(addresses))
+	if count != nil {
+		added += *count
+	}
+	if err = m.checkPolicy(ctx, PolicyRequest{
+		Operation: "AssignIPv6Addresses",
+		ENIID:     networkInterfaceID,
+		AddedIPs:  added,
+	}); err != nil {
+		return err
+	}
+	if err = op.before(); err != nil {
+		return err
+	}
+
 	input := &ec2.AssignIpv6AddressesInput{
 		NetworkInterfaceId: aws.String(networkInterfaceID),
 	}
@@ -162,7 +382,8 @@ func (m *ENIManager) AssignIPv6Addresses(ctx context.Context, networkInterfaceID
 		input.Ipv6AddressCount = count
 	}
 
-	_, err := m.client.AssignIpv6Addresses(ctx, input)
+	output, err := m.client.AssignIpv6Addresses(ctx, input)
+	op.record(input, output)
 	if err != nil {
 		return fmt.Errorf("failed to assign IPv6 addresses: %w", err)
 	}
@@ -170,13 +391,20 @@ func (m *ENIManager) AssignIPv6Addresses(ctx context.Context, networkInterfaceID
 	return nil
 }
 
-func (m *ENIManager) UnassignIPv6Addresses(ctx context.Context, networkInterfaceID string, addresses []string) error {
+func (m *ENIManager) UnassignIPv6Addresses(ctx context.Context, networkInterfaceID string, addresses []string) (err error) {
+	ctx, op := m.begin(ctx, "UnassignIPv6Addresses", AttrENIID.String(networkInterfaceID))
+	defer op.end(&err)
+	if err = op.before(); err != nil {
+		return err
+	}
+
 	input := &ec2.UnassignIpv6AddressesInput{
 		NetworkInterfaceId: aws.String(networkInterfaceID),
 		Ipv6Addresses:      addresses,
 	}
 
-	_, err := m.client.UnassignIpv6Addresses(ctx, input)
+	output, err := m.client.UnassignIpv6Addresses(ctx, input)
+	op.record(input, output)
 	if err != nil {
 		return fmt.Errorf("failed to unassign IPv6 addresses: %w", err)
 	}
@@ -184,7 +412,10 @@ func (m *ENIManager) UnassignIPv6Addresses(ctx context.Context, networkInterface
 	return nil
 }
 
-func (m *ENIManager) DescribeENIs(ctx context.Context, filters []types.Filter) (*ec2.DescribeNetworkInterfacesOutput, error) {
+func (m *ENIManager) DescribeENIs(ctx context.Context, filters []types.Filter) (_ *ec2.DescribeNetworkInterfacesOutput, err error) {
+	ctx, op := m.begin(ctx, "DescribeENIs")
+	defer op.end(&err)
+
 	input := &ec2.DescribeNetworkInterfacesInput{
 		Filters: filters,
 	}
@@ -192,10 +423,59 @@ func (m *ENIManager) DescribeENIs(ctx context.Context, filters []types.Filter) (
 	return m.client.DescribeNetworkInterfaces(ctx, input)
 }
 
-func (m *ENIManager) DescribeSubnet(ctx context.Context, subnetID string) (*ec2.DescribeSubnetsOutput, error) {
+func (m *ENIManager) DescribeSubnet(ctx context.Context, subnetID string) (_ *ec2.DescribeSubnetsOutput, err error) {
+	ctx, op := m.begin(ctx, "DescribeSubnet", AttrSubnetID.String(subnetID))
+	defer op.end(&err)
+
 	input := &ec2.DescribeSubnetsInput{
 		SubnetIds: []string{subnetID},
 	}
 
 	return m.client.DescribeSubnets(ctx, input)
 }
+
+// DescribeInstance returns an instance, including its network interfaces
+func (m *ENIManager) DescribeInstance(ctx context.Context, instanceID string) (_ *types.Instance, err error) {
+	ctx, op := m.begin(ctx, "DescribeInstance", AttrInstanceID.String(instanceID))
+	defer op.end(&err)
+
+	output, err := m.client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{InstanceIds: []string{instanceID}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe instance %s: %w", instanceID, err)
+	}
+	for _, reservation := range output.Reservations {
+		for i := range reservation.Instances {
+			return &reservation.Instances[i], nil
+		}
+	}
+	return nil, fmt.Errorf("instance %s not found", instanceID)
+}
+
+// DescribeInstanceType returns the network capabilities and limits of an
+// instance type
+func (m *ENIManager) DescribeInstanceType(ctx context.Context, instanceType string) (_ *types.InstanceTypeInfo, err error) {
+	ctx, op := m.begin(ctx, "DescribeInstanceType")
+	defer op.end(&err)
+
+	output, err := m.client.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{
+		InstanceTypes: []types.InstanceType{types.InstanceType(instanceType)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe instance type %s: %w", instanceType, err)
+	}
+	if len(output.InstanceTypes) == 0 {
+		return nil, fmt.Errorf("instance type %s not found", instanceType)
+	}
+	return &output.InstanceTypes[0], nil
+}
+
+// RefreshMetrics updates the inventory gauges from the ENIs tagged as managed by this tool
+func (m *ENIManager) RefreshMetrics(ctx context.Context) error {
+	output, err := m.DescribeENIs(ctx, ManagedENIFilters())
+	if err != nil {
+		return fmt.Errorf("failed to refresh metrics: %w", err)
+	}
+
+	m.metrics.setInventory(output.NetworkInterfaces)
+	return nil
+}
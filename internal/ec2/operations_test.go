@@ -42,14 +42,14 @@ func TestENIManager_CreateENI(t *testing.T) {
 			{
 				ResourceType: types.ResourceTypeNetworkInterface,
 				Tags: []types.Tag{
-					{
-						Key:   aws.String("Name"),
-						Value: aws.String("TestENI"),
-					},
 					{
 						Key:   aws.String("Env"),
 						Value: aws.String("Test"),
 					},
+					{
+						Key:   aws.String("Name"),
+						Value: aws.String("TestENI"),
+					},
 				},
 			},
 		},
@@ -0,0 +1,203 @@
+package ec2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"gopkg.in/yaml.v3"
+)
+
+// CodePolicyViolation is the error code reported for requests a policy rejects
+const CodePolicyViolation = "PolicyViolation"
+
+// PolicyRequest describes an operation for a Policy to evaluate. Only the
+// fields relevant to the operation are set.
+type PolicyRequest struct {
+	Operation        string
+	ENIID            string
+	InstanceID       string
+	SubnetID         string
+	Tags             map[string]string
+	SecurityGroupIDs []string
+	// DeviceIndex is set for AttachENI
+	DeviceIndex *int32
+	// NetworkCardIndex is set for AttachENIToCard
+	NetworkCardIndex *int32
+	// AddedIPs is the number of addresses the operation adds to the ENI,
+	// including the primary private IP for CreateENI
+	AddedIPs int32
+	// Lookup resolves the current state of resources the request refers to.
+	// It is nil in offline dry runs; policies skip checks that need it.
+	Lookup PolicyLookup
+}
+
+// PolicyLookup resolves resources for policies that need more than the request
+type PolicyLookup interface {
+	Subnet(ctx context.Context, subnetID string) (*types.Subnet, error)
+	NetworkInterface(ctx context.Context, eniID string) (*types.NetworkInterface, error)
+}
+
+// Policy decides whether an operation may proceed. Check returns a
+// *PolicyError listing the violations, or another error if it could not
+// evaluate the request.
+type Policy interface {
+	Check(ctx context.Context, req PolicyRequest) error
+}
+
+// WithPolicy evaluates policy before CreateENI, AttachENI,
+// ModifyENIAttribute and the IP assign methods call AWS
+func WithPolicy(policy Policy) Option {
+	return func(m *ENIManager) {
+		m.policy = policy
+	}
+}
+
+// PolicyError reports the rules an operation violated
+type PolicyError struct {
+	Operation  string
+	Violations []string
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("%s violates policy: %s", e.Operation, strings.Join(e.Violations, "; "))
+}
+
+// checkPolicy evaluates the configured policy, if any, against req
+func (m *ENIManager) checkPolicy(ctx context.Context, req PolicyRequest) error {
+	if m.policy == nil {
+		return nil
+	}
+	if m.dryRun != DryRunOffline {
+		req.Lookup = clientLookup{m.client}
+	}
+	return m.policy.Check(ctx, req)
+}
+
+// clientLookup resolves resources straight from the EC2 client
+type clientLookup struct {
+	client EC2ClientAPI
+}
+
+func (l clientLookup) Subnet(ctx context.Context, subnetID string) (*types.Subnet, error) {
+	output, err := l.client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{SubnetIds: []string{subnetID}})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.Subnets) == 0 {
+		return nil, fmt.Errorf("subnet %s not found", subnetID)
+	}
+	return &output.Subnets[0], nil
+}
+
+func (l clientLookup) NetworkInterface(ctx context.Context, eniID string) (*types.NetworkInterface, error) {
+	output, err := l.client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{
+		NetworkInterfaceIds: []string{eniID},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.NetworkInterfaces) == 0 {
+		return nil, fmt.Errorf("network interface %s not found", eniID)
+	}
+	return &output.NetworkInterfaces[0], nil
+}
+
+// Rules is a Policy built from a fixed set of constraints. Empty fields
+// impose no constraint.
+type Rules struct {
+	// RequiredTags must be present on new ENIs
+	RequiredTags []string `yaml:"required_tags"`
+	// AllowedSubnets and AllowedVPCs restrict where ENIs are created
+	AllowedSubnets []string `yaml:"allowed_subnets"`
+	AllowedVPCs    []string `yaml:"allowed_vpcs"`
+	// AllowedSecurityGroups restricts the groups ENIs are created or modified with
+	AllowedSecurityGroups []string `yaml:"allowed_security_groups"`
+	// MaxIPsPerENI caps the private IPv4 and IPv6 addresses on an ENI
+	MaxIPsPerENI int32 `yaml:"max_ips_per_eni"`
+	// ForbiddenDeviceIndexes may not be used to attach ENIs
+	ForbiddenDeviceIndexes []int32 `yaml:"forbidden_device_indexes"`
+}
+
+// LoadRules reads Rules from a YAML file, rejecting unknown keys so a typo
+// can't silently disable a rule. An empty file has no rules.
+func LoadRules(path string) (*Rules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules Rules
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&rules); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid policy file %s: %w", path, err)
+	}
+	return &rules, nil
+}
+
+func (r *Rules) Check(ctx context.Context, req PolicyRequest) error {
+	var violations []string
+	violate := func(format string, args ...any) {
+		violations = append(violations, fmt.Sprintf(format, args...))
+	}
+
+	if req.Operation == "CreateENI" {
+		for _, key := range r.RequiredTags {
+			if req.Tags[key] == "" {
+				violate("tag %s is required", key)
+			}
+		}
+		if len(r.AllowedSubnets) > 0 && !slices.Contains(r.AllowedSubnets, req.SubnetID) {
+			violate("subnet %s is not in allowed_subnets", req.SubnetID)
+		}
+		if len(r.AllowedVPCs) > 0 && req.Lookup != nil {
+			subnet, err := req.Lookup.Subnet(ctx, req.SubnetID)
+			if err != nil {
+				return fmt.Errorf("failed to evaluate allowed_vpcs: %w", err)
+			}
+			if vpcID := aws.ToString(subnet.VpcId); !slices.Contains(r.AllowedVPCs, vpcID) {
+				violate("subnet %s is in VPC %s, which is not in allowed_vpcs", req.SubnetID, vpcID)
+			}
+		}
+	}
+
+	if len(r.AllowedSecurityGroups) > 0 {
+		for _, group := range req.SecurityGroupIDs {
+			if !slices.Contains(r.AllowedSecurityGroups, group) {
+				violate("security group %s is not in allowed_security_groups", group)
+			}
+		}
+	}
+
+	if r.MaxIPsPerENI > 0 && req.AddedIPs > 0 {
+		total := req.AddedIPs
+		if req.ENIID != "" && req.Lookup != nil {
+			eni, err := req.Lookup.NetworkInterface(ctx, req.ENIID)
+			if err != nil {
+				return fmt.Errorf("failed to evaluate max_ips_per_eni: %w", err)
+			}
+			total += int32(len(eni.PrivateIpAddresses) + len(eni.Ipv6Addresses))
+		}
+		if total > r.MaxIPsPerENI {
+			violate("ENI would have %d IPs, more than max_ips_per_eni %d", total, r.MaxIPsPerENI)
+		}
+	}
+
+	if req.DeviceIndex != nil && slices.Contains(r.ForbiddenDeviceIndexes, *req.DeviceIndex) {
+		violate("device index %d is forbidden", *req.DeviceIndex)
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Operation: req.Operation, Violations: violations}
+	}
+	return nil
+}
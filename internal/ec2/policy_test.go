@@ -0,0 +1,114 @@
+// internal/ec2/policy_test.go
+package ec2
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func writePolicy(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "policy.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoadRules(t *testing.T) {
+	rules, err := LoadRules(writePolicy(t, `
+required_tags: [Owner, CostCenter]
+allowed_subnets: [subnet-12345678]
+max_ips_per_eni: 4
+forbidden_device_indexes: [0]
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Owner", "CostCenter"}, rules.RequiredTags)
+	assert.Equal(t, int32(4), rules.MaxIPsPerENI)
+
+	_, err = LoadRules(writePolicy(t, "required_tag: [Owner]\n"))
+	assert.ErrorContains(t, err, "required_tag")
+
+	rules, err = LoadRules(writePolicy(t, "# nothing enforced yet\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, &Rules{}, rules)
+}
+
+func TestRules_CreateENI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient, WithPolicy(&Rules{
+		RequiredTags:          []string{"Owner"},
+		AllowedVPCs:           []string{"vpc-12345678"},
+		AllowedSecurityGroups: []string{"sg-12345678"},
+		MaxIPsPerENI:          3,
+	}))
+
+	// Only the VPC lookup reaches AWS; CreateNetworkInterface is never called
+	mockClient.EXPECT().
+		DescribeSubnets(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeSubnetsOutput{
+			Subnets: []types.Subnet{{SubnetId: aws.String("subnet-12345678"), VpcId: aws.String("vpc-87654321")}},
+		}, nil)
+
+	_, err := manager.CreateENI(context.Background(), ENIConfig{
+		SubnetID:         "subnet-12345678",
+		SecurityGroupIDs: []string{"sg-12345678", "sg-87654321"},
+		PrivateIPCount:   3,
+	})
+
+	var policyErr *PolicyError
+	assert.ErrorAs(t, err, &policyErr)
+	assert.Equal(t, []string{
+		"tag Owner is required",
+		"subnet subnet-12345678 is in VPC vpc-87654321, which is not in allowed_vpcs",
+		"security group sg-87654321 is not in allowed_security_groups",
+		"ENI would have 4 IPs, more than max_ips_per_eni 3",
+	}, policyErr.Violations)
+	assert.Equal(t, CodePolicyViolation, ErrorCode(err))
+}
+
+func TestRules_AssignAndAttach(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient, WithPolicy(&Rules{
+		MaxIPsPerENI:           3,
+		ForbiddenDeviceIndexes: []int32{0},
+	}))
+
+	mockClient.EXPECT().
+		DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{
+			NetworkInterfaces: []types.NetworkInterface{{
+				NetworkInterfaceId: aws.String("eni-12345678"),
+				PrivateIpAddresses: []types.NetworkInterfacePrivateIpAddress{
+					{PrivateIpAddress: aws.String("10.0.0.10")},
+					{PrivateIpAddress: aws.String("10.0.0.11")},
+				},
+			}},
+		}, nil).
+		Times(2)
+	mockClient.EXPECT().
+		AssignPrivateIpAddresses(gomock.Any(), gomock.Any()).
+		Return(&ec2.AssignPrivateIpAddressesOutput{}, nil)
+
+	ctx := context.Background()
+	assert.NoError(t, manager.AssignPrivateIPs(ctx, "eni-12345678", 1, nil))
+	err := manager.AssignPrivateIPs(ctx, "eni-12345678", 2, nil)
+	assert.ErrorContains(t, err, "ENI would have 4 IPs")
+
+	_, err = manager.AttachENI(ctx, "eni-12345678", "i-12345678", 0)
+	assert.ErrorContains(t, err, "device index 0 is forbidden")
+}
@@ -0,0 +1,326 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// Permission check outcomes
+const (
+	// PermissionGranted means EC2 answered the DryRun call with DryRunOperation
+	PermissionGranted = "granted"
+	// PermissionDenied means EC2 answered with UnauthorizedOperation
+	PermissionDenied = "denied"
+	// PermissionUnverified means EC2 has no DryRun flag for the action
+	PermissionUnverified = "unverified"
+	// PermissionInconclusive means EC2 failed the call for another reason,
+	// e.g. a placeholder resource ID that doesn't exist, or answered it
+	// without checking the DryRun flag
+	PermissionInconclusive = "inconclusive"
+)
+
+// PreflightTarget names the resources the planned operations will touch.
+// Resource-level IAM conditions are only evaluated accurately against real
+// resources; unset IDs are replaced with placeholders.
+type PreflightTarget struct {
+	SubnetID         string
+	SecurityGroupIDs []string
+	ENIID            string
+	InstanceID       string
+	AttachmentID     string
+	// TrunkENIID is the trunk ENIID is associated with as a branch
+	TrunkENIID    string
+	AssociationID string
+}
+
+// PermissionCheck is the result of checking one EC2 action
+type PermissionCheck struct {
+	// Operations are the ENIManager operations that need the action
+	Operations []string `json:"operations"`
+	Action     string   `json:"action"`
+	Status     string   `json:"status"`
+	Error      string   `json:"error,omitempty"`
+}
+
+// PreflightOperations lists the ENIManager operations Preflight knows, in
+// workflow order
+var PreflightOperations = []string{
+	"CreateSecurityGroup",
+	"AuthorizeSecurityGroupRules",
+	"CreateENI",
+	"AttachENI",
+	"AttachENIToCard",
+	"AttachEFA",
+	"AttachENIs",
+	"AssignPrivateIPs",
+	"UnassignPrivateIPs",
+	"AssignIPv6Addresses",
+	"UnassignIPv6Addresses",
+	"ModifyENIAttribute",
+	"UpdateENISecurityGroups",
+	"AssociateBranchENI",
+	"TagENI",
+	"UntagENI",
+	"DescribeENIs",
+	"DescribeSubnet",
+	"DescribeInstance",
+	"DescribeInstanceType",
+	"NetworkCards",
+	"DescribeSecurityGroups",
+	"DetachENI",
+	"DeleteENI",
+	"DescribeTrunkAssociations",
+	"DisassociateBranchENI",
+	"RevokeSecurityGroupRules",
+	"DeleteSecurityGroup",
+}
+
+// requiredActions maps each operation to the EC2 actions it calls. Tagging
+// on create needs ec2:CreateTags as well, and associating a branch or
+// attaching an EFA or a batch of ENIs reads the capacity of the trunk or
+// instance first.
+var requiredActions = map[string][]string{
+	"CreateENI":                   {"CreateNetworkInterface", "CreateTags"},
+	"AttachENI":                   {"AttachNetworkInterface"},
+	"DetachENI":                   {"DetachNetworkInterface"},
+	"DeleteENI":                   {"DeleteNetworkInterface"},
+	"ModifyENIAttribute":          {"ModifyNetworkInterfaceAttribute"},
+	"AssignPrivateIPs":            {"AssignPrivateIpAddresses"},
+	"UnassignPrivateIPs":          {"UnassignPrivateIpAddresses"},
+	"AssignIPv6Addresses":         {"AssignIpv6Addresses"},
+	"UnassignIPv6Addresses":       {"UnassignIpv6Addresses"},
+	"TagENI":                      {"CreateTags"},
+	"UntagENI":                    {"DeleteTags"},
+	"DescribeENIs":                {"DescribeNetworkInterfaces"},
+	"DescribeSubnet":              {"DescribeSubnets"},
+	"CreateSecurityGroup":         {"CreateSecurityGroup", "CreateTags"},
+	"DescribeSecurityGroups":      {"DescribeSecurityGroups"},
+	"AuthorizeSecurityGroupRules": {"AuthorizeSecurityGroupIngress", "AuthorizeSecurityGroupEgress"},
+	"RevokeSecurityGroupRules":    {"RevokeSecurityGroupIngress", "RevokeSecurityGroupEgress"},
+	"DeleteSecurityGroup":         {"DeleteSecurityGroup"},
+	"UpdateENISecurityGroups":     {"DescribeNetworkInterfaces", "ModifyNetworkInterfaceAttribute"},
+	"AssociateBranchENI":          {"DescribeNetworkInterfaces", "DescribeInstances", "DescribeTrunkInterfaceAssociations", "AssociateTrunkInterface"},
+	"DisassociateBranchENI":       {"DisassociateTrunkInterface"},
+	"DescribeTrunkAssociations":   {"DescribeTrunkInterfaceAssociations"},
+	"DescribeInstance":            {"DescribeInstances"},
+	"DescribeInstanceType":        {"DescribeInstanceTypes"},
+	"AttachENIToCard":             {"AttachNetworkInterface"},
+	"AttachEFA":                   {"DescribeInstances", "DescribeInstanceTypes", "AttachNetworkInterface"},
+	"NetworkCards":                {"DescribeInstances", "DescribeInstanceTypes"},
+	"AttachENIs":                  {"DescribeInstances", "DescribeInstanceTypes", "AttachNetworkInterface"},
+}
+
+// Preflight checks the IAM permissions the operations need by calling each
+// required EC2 action with DryRun set, so nothing changes. Checks are
+// returned once per action, in the order first needed. The checks have to
+// reach EC2, so they bypass a dry-run client and fail in offline dry runs.
+func (m *ENIManager) Preflight(ctx context.Context, operations []string, target PreflightTarget) ([]PermissionCheck, error) {
+	if m.dryRun == DryRunOffline {
+		return nil, fmt.Errorf("preflight needs EC2 and can't run in %s dry-run mode", DryRunOffline)
+	}
+	client := m.client
+	if dryRunClient, ok := client.(*DryRunClient); ok {
+		client = dryRunClient.client
+	}
+	target = target.withPlaceholders()
+
+	var checks []PermissionCheck
+	index := make(map[string]int)
+	for _, operation := range operations {
+		actions, ok := requiredActions[operation]
+		if !ok {
+			return nil, fmt.Errorf("unknown operation %q", operation)
+		}
+		for _, action := range actions {
+			if i, ok := index[action]; ok {
+				checks[i].Operations = append(checks[i].Operations, operation)
+				continue
+			}
+			index[action] = len(checks)
+			checks = append(checks, PermissionCheck{Operations: []string{operation}, Action: "ec2:" + action})
+		}
+	}
+
+	for i := range checks {
+		supported, err := dryRunAction(ctx, client, strings.TrimPrefix(checks[i].Action, "ec2:"), target)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		switch code := ErrorCode(err); {
+		case !supported:
+			checks[i].Status = PermissionUnverified
+		case code == "DryRunOperation":
+			checks[i].Status = PermissionGranted
+		case err == nil:
+			// EC2 never succeeds a DryRun call, so the flag wasn't honoured
+			checks[i].Status = PermissionInconclusive
+			checks[i].Error = "call succeeded without DryRunOperation"
+		case code == "UnauthorizedOperation":
+			checks[i].Status, checks[i].Error = PermissionDenied, err.Error()
+		default:
+			checks[i].Status, checks[i].Error = PermissionInconclusive, err.Error()
+		}
+	}
+	return checks, nil
+}
+
+func (t PreflightTarget) withPlaceholders() PreflightTarget {
+	if t.SubnetID == "" {
+		t.SubnetID = "subnet-00000000000000000"
+	}
+	if t.ENIID == "" {
+		t.ENIID = DryRunENIID
+	}
+	if t.InstanceID == "" {
+		t.InstanceID = "i-00000000000000000"
+	}
+	if t.AttachmentID == "" {
+		t.AttachmentID = DryRunAttachmentID
+	}
+	if t.TrunkENIID == "" {
+		t.TrunkENIID = DryRunENIID
+	}
+	if t.AssociationID == "" {
+		t.AssociationID = DryRunAssociationID
+	}
+	return t
+}
+
+// securityGroupID is the group security group actions are checked against
+func (t PreflightTarget) securityGroupID() string {
+	if len(t.SecurityGroupIDs) > 0 {
+		return t.SecurityGroupIDs[0]
+	}
+	return DryRunSecurityGroupID
+}
+
+// dryRunAction calls action through client with DryRun set. supported is
+// false for actions EC2 can't dry run.
+func dryRunAction(ctx context.Context, client EC2ClientAPI, action string, t PreflightTarget) (supported bool, err error) {
+	dryRun := aws.Bool(true)
+	switch action {
+	case "CreateNetworkInterface":
+		_, err = client.CreateNetworkInterface(ctx, &ec2.CreateNetworkInterfaceInput{
+			SubnetId: aws.String(t.SubnetID),
+			Groups:   t.SecurityGroupIDs,
+			DryRun:   dryRun,
+		})
+	case "CreateTags":
+		_, err = client.CreateTags(ctx, &ec2.CreateTagsInput{
+			Resources: []string{t.ENIID},
+			Tags:      []types.Tag{{Key: aws.String(ManagedByTagKey), Value: aws.String(ManagedByTagValue)}},
+			DryRun:    dryRun,
+		})
+	case "DeleteTags":
+		_, err = client.DeleteTags(ctx, &ec2.DeleteTagsInput{
+			Resources: []string{t.ENIID},
+			Tags:      []types.Tag{{Key: aws.String(ManagedByTagKey)}},
+			DryRun:    dryRun,
+		})
+	case "AttachNetworkInterface":
+		_, err = client.AttachNetworkInterface(ctx, &ec2.AttachNetworkInterfaceInput{
+			NetworkInterfaceId: aws.String(t.ENIID),
+			InstanceId:         aws.String(t.InstanceID),
+			DeviceIndex:        aws.Int32(1),
+			DryRun:             dryRun,
+		})
+	case "DetachNetworkInterface":
+		_, err = client.DetachNetworkInterface(ctx, &ec2.DetachNetworkInterfaceInput{
+			AttachmentId: aws.String(t.AttachmentID),
+			DryRun:       dryRun,
+		})
+	case "DeleteNetworkInterface":
+		_, err = client.DeleteNetworkInterface(ctx, &ec2.DeleteNetworkInterfaceInput{
+			NetworkInterfaceId: aws.String(t.ENIID),
+			DryRun:             dryRun,
+		})
+	case "ModifyNetworkInterfaceAttribute":
+		_, err = client.ModifyNetworkInterfaceAttribute(ctx, &ec2.ModifyNetworkInterfaceAttributeInput{
+			NetworkInterfaceId: aws.String(t.ENIID),
+			Description:        &types.AttributeValue{Value: aws.String("preflight")},
+			DryRun:             dryRun,
+		})
+	case "DescribeNetworkInterfaces":
+		_, err = client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{DryRun: dryRun})
+	case "DescribeSubnets":
+		_, err = client.DescribeSubnets(ctx, &ec2.DescribeSubnetsInput{
+			SubnetIds: []string{t.SubnetID},
+			DryRun:    dryRun,
+		})
+	case "CreateSecurityGroup":
+		_, err = client.CreateSecurityGroup(ctx, &ec2.CreateSecurityGroupInput{
+			GroupName:   aws.String("preflight"),
+			Description: aws.String("preflight"),
+			DryRun:      dryRun,
+		})
+	case "DescribeSecurityGroups":
+		_, err = client.DescribeSecurityGroups(ctx, &ec2.DescribeSecurityGroupsInput{DryRun: dryRun})
+	case "AuthorizeSecurityGroupIngress":
+		_, err = client.AuthorizeSecurityGroupIngress(ctx, &ec2.AuthorizeSecurityGroupIngressInput{
+			GroupId:       aws.String(t.securityGroupID()),
+			IpPermissions: preflightPermissions,
+			DryRun:        dryRun,
+		})
+	case "AuthorizeSecurityGroupEgress":
+		_, err = client.AuthorizeSecurityGroupEgress(ctx, &ec2.AuthorizeSecurityGroupEgressInput{
+			GroupId:       aws.String(t.securityGroupID()),
+			IpPermissions: preflightPermissions,
+			DryRun:        dryRun,
+		})
+	case "RevokeSecurityGroupIngress":
+		_, err = client.RevokeSecurityGroupIngress(ctx, &ec2.RevokeSecurityGroupIngressInput{
+			GroupId:       aws.String(t.securityGroupID()),
+			IpPermissions: preflightPermissions,
+			DryRun:        dryRun,
+		})
+	case "RevokeSecurityGroupEgress":
+		_, err = client.RevokeSecurityGroupEgress(ctx, &ec2.RevokeSecurityGroupEgressInput{
+			GroupId:       aws.String(t.securityGroupID()),
+			IpPermissions: preflightPermissions,
+			DryRun:        dryRun,
+		})
+	case "DeleteSecurityGroup":
+		_, err = client.DeleteSecurityGroup(ctx, &ec2.DeleteSecurityGroupInput{
+			GroupId: aws.String(t.securityGroupID()),
+			DryRun:  dryRun,
+		})
+	case "DescribeInstances":
+		_, err = client.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+			InstanceIds: []string{t.InstanceID},
+			DryRun:      dryRun,
+		})
+	case "DescribeInstanceTypes":
+		_, err = client.DescribeInstanceTypes(ctx, &ec2.DescribeInstanceTypesInput{DryRun: dryRun})
+	case "AssociateTrunkInterface":
+		_, err = client.AssociateTrunkInterface(ctx, &ec2.AssociateTrunkInterfaceInput{
+			TrunkInterfaceId:  aws.String(t.TrunkENIID),
+			BranchInterfaceId: aws.String(t.ENIID),
+			VlanId:            aws.Int32(1),
+			DryRun:            dryRun,
+		})
+	case "DisassociateTrunkInterface":
+		_, err = client.DisassociateTrunkInterface(ctx, &ec2.DisassociateTrunkInterfaceInput{
+			AssociationId: aws.String(t.AssociationID),
+			DryRun:        dryRun,
+		})
+	case "DescribeTrunkInterfaceAssociations":
+		_, err = client.DescribeTrunkInterfaceAssociations(ctx, &ec2.DescribeTrunkInterfaceAssociationsInput{DryRun: dryRun})
+	default:
+		return false, nil
+	}
+	return true, err
+}
+
+// preflightPermissions is the rule security group rule actions are checked
+// with
+var preflightPermissions = []types.IpPermission{{
+	IpProtocol: aws.String("tcp"),
+	FromPort:   aws.Int32(443),
+	ToPort:     aws.Int32(443),
+	IpRanges:   []types.IpRange{{CidrIp: aws.String("10.0.0.0/8")}},
+}}
@@ -0,0 +1,205 @@
+// internal/ec2/preflight_test.go
+package ec2
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestENIManager_Preflight(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	dryRunOK := &smithy.GenericAPIError{Code: "DryRunOperation"}
+	mockClient.EXPECT().
+		CreateNetworkInterface(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *ec2.CreateNetworkInterfaceInput, _ ...func(*ec2.Options)) (*ec2.CreateNetworkInterfaceOutput, error) {
+			assert.True(t, aws.ToBool(input.DryRun))
+			assert.Equal(t, "subnet-12345678", aws.ToString(input.SubnetId))
+			return nil, dryRunOK
+		})
+	mockClient.EXPECT().
+		CreateTags(gomock.Any(), gomock.Any()).
+		Return(nil, dryRunOK)
+	mockClient.EXPECT().
+		DeleteNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(nil, &smithy.GenericAPIError{Code: "UnauthorizedOperation", Message: "not authorized"})
+
+	checks, err := manager.Preflight(context.Background(),
+		[]string{"CreateENI", "AssignPrivateIPs", "DeleteENI"},
+		PreflightTarget{SubnetID: "subnet-12345678"})
+	assert.NoError(t, err)
+
+	var got []string
+	for _, check := range checks {
+		got = append(got, check.Action+"="+check.Status)
+	}
+	assert.Equal(t, []string{
+		"ec2:CreateNetworkInterface=granted",
+		"ec2:CreateTags=granted",
+		"ec2:AssignPrivateIpAddresses=unverified",
+		"ec2:DeleteNetworkInterface=denied",
+	}, got)
+	assert.Equal(t, []string{"DeleteENI"}, checks[3].Operations)
+
+	_, err = manager.Preflight(context.Background(), []string{"LaunchRocket"}, PreflightTarget{})
+	assert.Error(t, err)
+}
+
+func TestENIManager_PreflightSecurityGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	dryRunOK := &smithy.GenericAPIError{Code: "DryRunOperation"}
+	mockClient.EXPECT().
+		AuthorizeSecurityGroupIngress(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *ec2.AuthorizeSecurityGroupIngressInput, _ ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+			assert.True(t, aws.ToBool(input.DryRun))
+			assert.Equal(t, "sg-12345678", aws.ToString(input.GroupId))
+			return nil, dryRunOK
+		})
+	mockClient.EXPECT().
+		AuthorizeSecurityGroupEgress(gomock.Any(), gomock.Any()).
+		Return(nil, &smithy.GenericAPIError{Code: "UnauthorizedOperation", Message: "not authorized"})
+	mockClient.EXPECT().
+		DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(nil, dryRunOK)
+	mockClient.EXPECT().
+		ModifyNetworkInterfaceAttribute(gomock.Any(), gomock.Any()).
+		Return(nil, dryRunOK)
+
+	checks, err := manager.Preflight(context.Background(),
+		[]string{"AuthorizeSecurityGroupRules", "UpdateENISecurityGroups"},
+		PreflightTarget{SecurityGroupIDs: []string{"sg-12345678"}})
+	assert.NoError(t, err)
+
+	var got []string
+	for _, check := range checks {
+		got = append(got, check.Action+"="+check.Status)
+	}
+	assert.Equal(t, []string{
+		"ec2:AuthorizeSecurityGroupIngress=granted",
+		"ec2:AuthorizeSecurityGroupEgress=denied",
+		"ec2:DescribeNetworkInterfaces=granted",
+		"ec2:ModifyNetworkInterfaceAttribute=granted",
+	}, got)
+}
+
+func TestENIManager_PreflightTrunk(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	dryRunOK := &smithy.GenericAPIError{Code: "DryRunOperation"}
+	mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).Return(nil, dryRunOK)
+	mockClient.EXPECT().DescribeInstances(gomock.Any(), gomock.Any()).Return(nil, dryRunOK)
+	mockClient.EXPECT().DescribeTrunkInterfaceAssociations(gomock.Any(), gomock.Any()).Return(nil, dryRunOK)
+	mockClient.EXPECT().
+		AssociateTrunkInterface(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *ec2.AssociateTrunkInterfaceInput, _ ...func(*ec2.Options)) (*ec2.AssociateTrunkInterfaceOutput, error) {
+			assert.True(t, aws.ToBool(input.DryRun))
+			assert.Equal(t, "eni-trunk", aws.ToString(input.TrunkInterfaceId))
+			assert.Equal(t, "eni-branch", aws.ToString(input.BranchInterfaceId))
+			return nil, &smithy.GenericAPIError{Code: "UnauthorizedOperation", Message: "not authorized"}
+		})
+	mockClient.EXPECT().DisassociateTrunkInterface(gomock.Any(), gomock.Any()).Return(nil, dryRunOK)
+
+	checks, err := manager.Preflight(context.Background(),
+		[]string{"AssociateBranchENI", "DisassociateBranchENI"},
+		PreflightTarget{ENIID: "eni-branch", TrunkENIID: "eni-trunk"})
+	assert.NoError(t, err)
+
+	var got []string
+	for _, check := range checks {
+		got = append(got, check.Action+"="+check.Status)
+	}
+	assert.Equal(t, []string{
+		"ec2:DescribeNetworkInterfaces=granted",
+		"ec2:DescribeInstances=granted",
+		"ec2:DescribeTrunkInterfaceAssociations=granted",
+		"ec2:AssociateTrunkInterface=denied",
+		"ec2:DisassociateTrunkInterface=granted",
+	}, got)
+}
+
+func TestENIManager_PreflightEFA(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	dryRunOK := &smithy.GenericAPIError{Code: "DryRunOperation"}
+	mockClient.EXPECT().DescribeInstances(gomock.Any(), gomock.Any()).Return(nil, dryRunOK)
+	mockClient.EXPECT().
+		DescribeInstanceTypes(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *ec2.DescribeInstanceTypesInput, _ ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+			assert.True(t, aws.ToBool(input.DryRun))
+			return nil, &smithy.GenericAPIError{Code: "UnauthorizedOperation", Message: "not authorized"}
+		})
+	mockClient.EXPECT().AttachNetworkInterface(gomock.Any(), gomock.Any()).Return(nil, dryRunOK)
+
+	checks, err := manager.Preflight(context.Background(), []string{"AttachEFA", "AttachENIToCard", "AttachENIs"}, PreflightTarget{})
+	assert.NoError(t, err)
+
+	var got []string
+	for _, check := range checks {
+		got = append(got, check.Action+"="+check.Status)
+	}
+	assert.Equal(t, []string{
+		"ec2:DescribeInstances=granted",
+		"ec2:DescribeInstanceTypes=denied",
+		"ec2:AttachNetworkInterface=granted",
+	}, got)
+	assert.Equal(t, []string{"AttachEFA", "AttachENIToCard", "AttachENIs"}, checks[2].Operations)
+}
+
+func TestENIManager_PreflightDryRun(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+
+	// Offline dry runs never reach EC2, so nothing can be checked
+	offline := NewENIManager(mockClient, WithDryRun(DryRunOffline, io.Discard))
+	_, err := offline.Preflight(context.Background(), []string{"DeleteENI"}, PreflightTarget{})
+	assert.ErrorContains(t, err, "offline")
+
+	// In aws mode the checks go straight to EC2 without dry-run reports,
+	// and a call that succeeds outright proves nothing
+	var out bytes.Buffer
+	online := NewENIManager(mockClient, WithDryRun(DryRunAWS, &out))
+	mockClient.EXPECT().
+		DeleteNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(&ec2.DeleteNetworkInterfaceOutput{}, nil)
+	checks, err := online.Preflight(context.Background(), []string{"DeleteENI"}, PreflightTarget{})
+	assert.NoError(t, err)
+	if assert.Len(t, checks, 1) {
+		assert.Equal(t, PermissionInconclusive, checks[0].Status)
+	}
+	assert.Empty(t, out.String())
+}
+
+func TestPreflightOperations(t *testing.T) {
+	for _, operation := range PreflightOperations {
+		assert.Contains(t, requiredActions, operation)
+	}
+	assert.Len(t, PreflightOperations, len(requiredActions))
+}
@@ -0,0 +1,299 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"golang.org/x/time/rate"
+)
+
+// RateBucket identifies which token bucket an EC2 action draws from
+type RateBucket string
+
+const (
+	RateBucketMutating RateBucket = "mutating"
+	RateBucketDescribe RateBucket = "describe"
+)
+
+// RateLimitConfig represents configuration for the client-side token buckets.
+// Rates are in requests per second.
+type RateLimitConfig struct {
+	MutatingRate  float64 `yaml:"mutating_rate"`
+	MutatingBurst int     `yaml:"mutating_burst"`
+	DescribeRate  float64 `yaml:"describe_rate"`
+	DescribeBurst int     `yaml:"describe_burst"`
+}
+
+// DefaultRateLimitConfig returns rates that stay well inside the EC2 account
+// defaults, leaving headroom for other tooling sharing the account.
+func DefaultRateLimitConfig() RateLimitConfig {
+	return RateLimitConfig{
+		MutatingRate:  2,
+		MutatingBurst: 50,
+		DescribeRate:  10,
+		DescribeBurst: 50,
+	}
+}
+
+// RateLimitStats holds wait-time metrics for a single bucket
+type RateLimitStats struct {
+	Calls     int64
+	Throttled int64
+	TotalWait time.Duration
+	MaxWait   time.Duration
+}
+
+type tokenBucket struct {
+	limiter *rate.Limiter
+
+	mu    sync.Mutex
+	stats RateLimitStats
+}
+
+func newTokenBucket(r float64, burst int) *tokenBucket {
+	return &tokenBucket{limiter: rate.NewLimiter(rate.Limit(r), burst)}
+}
+
+func (b *tokenBucket) wait(ctx context.Context) error {
+	start := time.Now()
+	err := b.limiter.Wait(ctx)
+	waited := time.Since(start)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.stats.Calls++
+	b.stats.TotalWait += waited
+	if waited > b.stats.MaxWait {
+		b.stats.MaxWait = waited
+	}
+	// Anything beyond a millisecond means the bucket was empty
+	if waited > time.Millisecond {
+		b.stats.Throttled++
+	}
+
+	return err
+}
+
+// RateLimitedClient is an EC2ClientAPI that self-throttles through separate
+// token buckets for mutating and describe calls before delegating to client.
+type RateLimitedClient struct {
+	client   EC2ClientAPI
+	mutating *tokenBucket
+	describe *tokenBucket
+}
+
+var _ EC2ClientAPI = (*RateLimitedClient)(nil)
+
+func NewRateLimitedClient(client EC2ClientAPI, config RateLimitConfig) *RateLimitedClient {
+	return &RateLimitedClient{
+		client:   client,
+		mutating: newTokenBucket(config.MutatingRate, config.MutatingBurst),
+		describe: newTokenBucket(config.DescribeRate, config.DescribeBurst),
+	}
+}
+
+// Stats returns a snapshot of the wait-time metrics for each bucket
+func (c *RateLimitedClient) Stats() map[RateBucket]RateLimitStats {
+	stats := make(map[RateBucket]RateLimitStats, 2)
+	for name, b := range map[RateBucket]*tokenBucket{
+		RateBucketMutating: c.mutating,
+		RateBucketDescribe: c.describe,
+	} {
+		b.mu.Lock()
+		stats[name] = b.stats
+		b.mu.Unlock()
+	}
+	return stats
+}
+
+func (c *RateLimitedClient) waitMutating(ctx context.Context) error {
+	if err := c.mutating.wait(ctx); err != nil {
+		return fmt.Errorf("rate limiter: %w", err)
+	}
+	return nil
+}
+
+func (c *RateLimitedClient) waitDescribe(ctx context.Context) error {
+	if err := c.describe.wait(ctx); err != nil {
+		return fmt.Errorf("rate limiter: %w", err)
+	}
+	return nil
+}
+
+func (c *RateLimitedClient) CreateNetworkInterface(ctx context.Context, input *ec2.CreateNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.CreateNetworkInterfaceOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.CreateNetworkInterface(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) DescribeInstances(ctx context.Context, input *ec2.DescribeInstancesInput, opts ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	if err := c.waitDescribe(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.DescribeInstances(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) DescribeInstanceTypes(ctx context.Context, input *ec2.DescribeInstanceTypesInput, opts ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+	if err := c.waitDescribe(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.DescribeInstanceTypes(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) AttachNetworkInterface(ctx context.Context, input *ec2.AttachNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.AttachNetworkInterfaceOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.AttachNetworkInterface(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) DeleteNetworkInterface(ctx context.Context, input *ec2.DeleteNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DeleteNetworkInterfaceOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.DeleteNetworkInterface(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) DetachNetworkInterface(ctx context.Context, input *ec2.DetachNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DetachNetworkInterfaceOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.DetachNetworkInterface(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) AssignPrivateIpAddresses(ctx context.Context, input *ec2.AssignPrivateIpAddressesInput, opts ...func(*ec2.Options)) (*ec2.AssignPrivateIpAddressesOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.AssignPrivateIpAddresses(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) UnassignPrivateIpAddresses(ctx context.Context, input *ec2.UnassignPrivateIpAddressesInput, opts ...func(*ec2.Options)) (*ec2.UnassignPrivateIpAddressesOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.UnassignPrivateIpAddresses(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) AssignIpv6Addresses(ctx context.Context, input *ec2.AssignIpv6AddressesInput, opts ...func(*ec2.Options)) (*ec2.AssignIpv6AddressesOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.AssignIpv6Addresses(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) UnassignIpv6Addresses(ctx context.Context, input *ec2.UnassignIpv6AddressesInput, opts ...func(*ec2.Options)) (*ec2.UnassignIpv6AddressesOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.UnassignIpv6Addresses(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) DescribeNetworkInterfaces(ctx context.Context, input *ec2.DescribeNetworkInterfacesInput, opts ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	if err := c.waitDescribe(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.DescribeNetworkInterfaces(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) ModifyNetworkInterfaceAttribute(ctx context.Context, input *ec2.ModifyNetworkInterfaceAttributeInput, opts ...func(*ec2.Options)) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.ModifyNetworkInterfaceAttribute(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) CreateTags(ctx context.Context, input *ec2.CreateTagsInput, opts ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.CreateTags(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) DeleteTags(ctx context.Context, input *ec2.DeleteTagsInput, opts ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.DeleteTags(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput, opts ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	if err := c.waitDescribe(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.DescribeSubnets(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) CreateSecurityGroup(ctx context.Context, input *ec2.CreateSecurityGroupInput, opts ...func(*ec2.Options)) (*ec2.CreateSecurityGroupOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.CreateSecurityGroup(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) DescribeSecurityGroups(ctx context.Context, input *ec2.DescribeSecurityGroupsInput, opts ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	if err := c.waitDescribe(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.DescribeSecurityGroups(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) AuthorizeSecurityGroupIngress(ctx context.Context, input *ec2.AuthorizeSecurityGroupIngressInput, opts ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.AuthorizeSecurityGroupIngress(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) AuthorizeSecurityGroupEgress(ctx context.Context, input *ec2.AuthorizeSecurityGroupEgressInput, opts ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.AuthorizeSecurityGroupEgress(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) RevokeSecurityGroupIngress(ctx context.Context, input *ec2.RevokeSecurityGroupIngressInput, opts ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.RevokeSecurityGroupIngress(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) RevokeSecurityGroupEgress(ctx context.Context, input *ec2.RevokeSecurityGroupEgressInput, opts ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.RevokeSecurityGroupEgress(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) DeleteSecurityGroup(ctx context.Context, input *ec2.DeleteSecurityGroupInput, opts ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.DeleteSecurityGroup(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) AssociateTrunkInterface(ctx context.Context, input *ec2.AssociateTrunkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.AssociateTrunkInterfaceOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.AssociateTrunkInterface(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) DisassociateTrunkInterface(ctx context.Context, input *ec2.DisassociateTrunkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DisassociateTrunkInterfaceOutput, error) {
+	if err := c.waitMutating(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.DisassociateTrunkInterface(ctx, input, opts...)
+}
+
+func (c *RateLimitedClient) DescribeTrunkInterfaceAssociations(ctx context.Context, input *ec2.DescribeTrunkInterfaceAssociationsInput, opts ...func(*ec2.Options)) (*ec2.DescribeTrunkInterfaceAssociationsOutput, error) {
+	if err := c.waitDescribe(ctx); err != nil {
+		return nil, err
+	}
+	return c.client.DescribeTrunkInterfaceAssociations(ctx, input, opts...)
+}
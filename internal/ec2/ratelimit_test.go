@@ -0,0 +1,95 @@
+// internal/ec2/ratelimit_test.go
+package ec2
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimitedClient_SeparateBuckets(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	client := NewRateLimitedClient(mockClient, DefaultRateLimitConfig())
+	manager := NewENIManager(client)
+
+	mockClient.EXPECT().
+		DeleteNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(&ec2.DeleteNetworkInterfaceOutput{}, nil)
+	mockClient.EXPECT().
+		DescribeSubnets(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeSubnetsOutput{}, nil).
+		Times(2)
+
+	assert.NoError(t, manager.DeleteENI(context.Background(), "eni-12345678"))
+	_, err := manager.DescribeSubnet(context.Background(), "subnet-12345678")
+	assert.NoError(t, err)
+	_, err = manager.DescribeSubnet(context.Background(), "subnet-12345678")
+	assert.NoError(t, err)
+
+	stats := client.Stats()
+	assert.Equal(t, int64(1), stats[RateBucketMutating].Calls)
+	assert.Equal(t, int64(2), stats[RateBucketDescribe].Calls)
+	assert.Zero(t, stats[RateBucketDescribe].Throttled)
+}
+
+func TestRateLimitedClient_WaitsForToken(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	client := NewRateLimitedClient(mockClient, RateLimitConfig{
+		MutatingRate:  50,
+		MutatingBurst: 1,
+		DescribeRate:  50,
+		DescribeBurst: 1,
+	})
+
+	mockClient.EXPECT().
+		CreateTags(gomock.Any(), gomock.Any()).
+		Return(&ec2.CreateTagsOutput{}, nil).
+		Times(2)
+
+	input := &ec2.CreateTagsInput{Resources: []string{"eni-12345678"}}
+	_, err := client.CreateTags(context.Background(), input)
+	assert.NoError(t, err)
+	_, err = client.CreateTags(context.Background(), input)
+	assert.NoError(t, err)
+
+	stats := client.Stats()[RateBucketMutating]
+	assert.Equal(t, int64(2), stats.Calls)
+	assert.Equal(t, int64(1), stats.Throttled)
+	assert.GreaterOrEqual(t, stats.MaxWait, 10*time.Millisecond)
+}
+
+func TestRateLimitedClient_ContextCanceled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	client := NewRateLimitedClient(mockClient, RateLimitConfig{
+		MutatingRate:  0.001,
+		MutatingBurst: 1,
+		DescribeRate:  0.001,
+		DescribeBurst: 1,
+	})
+
+	mockClient.EXPECT().
+		DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{}, nil)
+
+	_, err := client.DescribeNetworkInterfaces(context.Background(), &ec2.DescribeNetworkInterfacesInput{})
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = client.DescribeNetworkInterfaces(ctx, &ec2.DescribeNetworkInterfacesInput{})
+	assert.Error(t, err)
+}
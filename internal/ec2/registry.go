@@ -0,0 +1,150 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// Origin identifies the account and region an ENIManager operates in.
+// Account is however the account was reached: a profile name, an assumed
+// role ARN or "default".
+type Origin struct {
+	Account string `json:"account"`
+	Region  string `json:"region"`
+}
+
+func (o Origin) String() string {
+	return o.Account + "/" + o.Region
+}
+
+// OriginENI is an ENI labeled with the account and region it was found in
+type OriginENI struct {
+	Origin Origin                 `json:"origin"`
+	ENI    types.NetworkInterface `json:"eni"`
+}
+
+// Registry holds one ENIManager per account and region and fans operations
+// out across all of them
+type Registry struct {
+	mu       sync.RWMutex
+	managers map[Origin]*ENIManager
+}
+
+func NewRegistry() *Registry {
+	return &Registry{managers: make(map[Origin]*ENIManager)}
+}
+
+// Register adds or replaces the manager for origin
+func (r *Registry) Register(origin Origin, manager *ENIManager) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.managers[origin] = manager
+}
+
+// Get returns the manager for origin
+func (r *Registry) Get(origin Origin) (*ENIManager, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	manager, ok := r.managers[origin]
+	return manager, ok
+}
+
+// Origins returns the registered origins sorted by account, then region
+func (r *Registry) Origins() []Origin {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	origins := make([]Origin, 0, len(r.managers))
+	for origin := range r.managers {
+		origins = append(origins, origin)
+	}
+	sort.Slice(origins, func(i, j int) bool {
+		if origins[i].Account != origins[j].Account {
+			return origins[i].Account < origins[j].Account
+		}
+		return origins[i].Region < origins[j].Region
+	})
+	return origins
+}
+
+// ListENIs describes the ENIs matching filters in every origin concurrently.
+// Origins that fail are reported in the joined error alongside the results
+// from the rest.
+func (r *Registry) ListENIs(ctx context.Context, filters []types.Filter) ([]OriginENI, error) {
+	return fanOut(ctx, r, func(ctx context.Context, m *ENIManager) ([]types.NetworkInterface, error) {
+		output, err := m.DescribeENIs(ctx, filters)
+		if err != nil {
+			return nil, err
+		}
+		return output.NetworkInterfaces, nil
+	})
+}
+
+// CollectGarbage runs CollectGarbage in every origin concurrently and returns
+// the ENIs deleted
+func (r *Registry) CollectGarbage(ctx context.Context) ([]OriginENI, error) {
+	return fanOut(ctx, r, func(ctx context.Context, m *ENIManager) ([]types.NetworkInterface, error) {
+		return m.CollectGarbage(ctx)
+	})
+}
+
+// fanOut calls fn with every manager concurrently and labels the ENIs it
+// returns with their origin, in Origins order
+func fanOut(ctx context.Context, r *Registry, fn func(context.Context, *ENIManager) ([]types.NetworkInterface, error)) ([]OriginENI, error) {
+	origins := r.Origins()
+	results := make([][]types.NetworkInterface, len(origins))
+	errs := make([]error, len(origins))
+
+	var wg sync.WaitGroup
+	for i, origin := range origins {
+		manager, _ := r.Get(origin)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = fn(ctx, manager)
+			if errs[i] != nil {
+				errs[i] = fmt.Errorf("%s: %w", origin, errs[i])
+			}
+		}()
+	}
+	wg.Wait()
+
+	var enis []OriginENI
+	for i, origin := range origins {
+		for _, eni := range results[i] {
+			enis = append(enis, OriginENI{Origin: origin, ENI: eni})
+		}
+	}
+	return enis, errors.Join(errs...)
+}
+
+// CollectGarbage deletes ENIs tagged as managed by this tool that are no
+// longer attached, and returns those it deleted. An ENI that fails to delete
+// is skipped and reported in the joined error.
+func (m *ENIManager) CollectGarbage(ctx context.Context) ([]types.NetworkInterface, error) {
+	filters := append(ManagedENIFilters(), types.Filter{
+		Name:   aws.String("status"),
+		Values: []string{string(types.NetworkInterfaceStatusAvailable)},
+	})
+	output, err := m.DescribeENIs(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list unattached ENIs: %w", err)
+	}
+
+	var deleted []types.NetworkInterface
+	var errs []error
+	for _, eni := range output.NetworkInterfaces {
+		if err := m.DeleteENI(ctx, aws.ToString(eni.NetworkInterfaceId)); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		deleted = append(deleted, eni)
+	}
+	return deleted, errors.Join(errs...)
+}
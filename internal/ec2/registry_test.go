@@ -0,0 +1,80 @@
+// internal/ec2/registry_test.go
+package ec2
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegistry_ListENIs(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	east, west, broken := mocks.NewMockEC2ClientAPI(ctrl), mocks.NewMockEC2ClientAPI(ctrl), mocks.NewMockEC2ClientAPI(ctrl)
+	registry := NewRegistry()
+	registry.Register(Origin{Account: "prod", Region: "us-west-2"}, NewENIManager(west))
+	registry.Register(Origin{Account: "prod", Region: "us-east-1"}, NewENIManager(east))
+	registry.Register(Origin{Account: "staging", Region: "us-east-1"}, NewENIManager(broken))
+
+	east.EXPECT().
+		DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{
+			NetworkInterfaces: []types.NetworkInterface{{NetworkInterfaceId: aws.String("eni-east")}},
+		}, nil)
+	west.EXPECT().
+		DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{
+			NetworkInterfaces: []types.NetworkInterface{{NetworkInterfaceId: aws.String("eni-west")}},
+		}, nil)
+	broken.EXPECT().
+		DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(nil, errors.New("expired token"))
+
+	enis, err := registry.ListENIs(context.Background(), nil)
+	assert.ErrorContains(t, err, "staging/us-east-1: expired token")
+	assert.Len(t, enis, 2)
+	assert.Equal(t, Origin{Account: "prod", Region: "us-east-1"}, enis[0].Origin)
+	assert.Equal(t, "eni-east", *enis[0].ENI.NetworkInterfaceId)
+	assert.Equal(t, "us-west-2", enis[1].Origin.Region)
+}
+
+func TestENIManager_CollectGarbage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	mockClient.EXPECT().
+		DescribeNetworkInterfaces(gomock.Any(), gomock.Eq(&ec2.DescribeNetworkInterfacesInput{
+			Filters: []types.Filter{
+				{Name: aws.String("tag:" + ManagedByTagKey), Values: []string{ManagedByTagValue}},
+				{Name: aws.String("status"), Values: []string{"available"}},
+			},
+		})).
+		Return(&ec2.DescribeNetworkInterfacesOutput{
+			NetworkInterfaces: []types.NetworkInterface{
+				{NetworkInterfaceId: aws.String("eni-1")},
+				{NetworkInterfaceId: aws.String("eni-2")},
+			},
+		}, nil)
+	mockClient.EXPECT().
+		DeleteNetworkInterface(gomock.Any(), gomock.Eq(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: aws.String("eni-1")})).
+		Return(&ec2.DeleteNetworkInterfaceOutput{}, nil)
+	mockClient.EXPECT().
+		DeleteNetworkInterface(gomock.Any(), gomock.Eq(&ec2.DeleteNetworkInterfaceInput{NetworkInterfaceId: aws.String("eni-2")})).
+		Return(nil, errors.New("in use"))
+
+	deleted, err := manager.CollectGarbage(context.Background())
+	assert.ErrorContains(t, err, "in use")
+	assert.Len(t, deleted, 1)
+	assert.Equal(t, "eni-1", *deleted[0].NetworkInterfaceId)
+}
@@ -0,0 +1,507 @@
+package ec2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/netip"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleDirection selects a security group's ingress or egress rules
+type RuleDirection string
+
+const (
+	Ingress RuleDirection = "ingress"
+	Egress  RuleDirection = "egress"
+)
+
+// SecurityGroupConfig represents configuration for creating a security group
+type SecurityGroupConfig struct {
+	Name        string            `yaml:"name" json:"name"`
+	Description string            `yaml:"description" json:"description"`
+	VPCID       string            `yaml:"vpc_id,omitempty" json:"vpc_id,omitempty"`
+	Tags        map[string]string `yaml:"tags,omitempty" json:"tags,omitempty"`
+}
+
+// SecurityGroupRule opens a protocol and port range to a single CIDR block
+// (IPv4 or IPv6) or to the members of another security group. Protocol is
+// tcp, udp, icmp, icmpv6 or all; ports are ignored for all. For icmp and
+// icmpv6, FromPort is the ICMP type and ToPort the code, with -1 meaning any.
+// An ICMP rule must set at least one of them, since leaving both out would
+// open echo reply (0/0) only: write -1/-1 for all ICMP or 0/-1 for echo reply.
+type SecurityGroupRule struct {
+	Protocol      string `yaml:"protocol" json:"protocol"`
+	FromPort      int32  `yaml:"from_port,omitempty" json:"from_port,omitempty"`
+	ToPort        int32  `yaml:"to_port,omitempty" json:"to_port,omitempty"`
+	CIDR          string `yaml:"cidr,omitempty" json:"cidr,omitempty"`
+	SourceGroupID string `yaml:"source_group_id,omitempty" json:"source_group_id,omitempty"`
+	Description   string `yaml:"description,omitempty" json:"description,omitempty"`
+}
+
+// SecurityGroupSpec declares a security group and the complete set of rules
+// it should have. A nil Egress leaves egress rules unmanaged, so a new group
+// keeps EC2's default allow-all egress rule.
+type SecurityGroupSpec struct {
+	SecurityGroupConfig `yaml:",inline"`
+	Ingress             []SecurityGroupRule `yaml:"ingress"`
+	Egress              []SecurityGroupRule `yaml:"egress"`
+}
+
+// SecurityGroupChanges reports what ApplySecurityGroup changed
+type SecurityGroupChanges struct {
+	GroupID    string                                `json:"group_id"`
+	Created    bool                                  `json:"created"`
+	Authorized map[RuleDirection][]SecurityGroupRule `json:"authorized,omitempty"`
+	Revoked    map[RuleDirection][]SecurityGroupRule `json:"revoked,omitempty"`
+}
+
+// LoadSecurityGroupSpecs reads the security groups declared in a YAML file
+// under a top-level security_groups key
+func LoadSecurityGroupSpecs(path string) ([]SecurityGroupSpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file struct {
+		SecurityGroups []SecurityGroupSpec `yaml:"security_groups"`
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&file); errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("security group file %s is empty", path)
+	} else if err != nil {
+		return nil, fmt.Errorf("invalid security group file %s: %w", path, err)
+	}
+	for _, spec := range file.SecurityGroups {
+		if err := spec.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: security group %s: %w", path, spec.Name, err)
+		}
+	}
+	return file.SecurityGroups, nil
+}
+
+// Validate checks config for mistakes EC2 would reject anyway
+func (c SecurityGroupConfig) Validate() error {
+	if c.Name == "" || c.Description == "" {
+		return fmt.Errorf("name and description are required")
+	}
+	if c.VPCID != "" {
+		return ValidateID("vpc_id", c.VPCID, "vpc-")
+	}
+	return nil
+}
+
+func (s SecurityGroupSpec) Validate() error {
+	if err := s.SecurityGroupConfig.Validate(); err != nil {
+		return err
+	}
+	for _, rule := range append(slices.Clip(s.Ingress), s.Egress...) {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r SecurityGroupRule) Validate() error {
+	switch r.protocol() {
+	case "-1":
+	case "tcp", "udp":
+		if r.FromPort < 0 || r.ToPort > 65535 || r.FromPort > r.ToPort {
+			return fmt.Errorf("invalid %s port range %d-%d", r.Protocol, r.FromPort, r.ToPort)
+		}
+	case "icmp", "icmpv6":
+		if r.FromPort == 0 && r.ToPort == 0 {
+			return fmt.Errorf("%s needs from_port (type) and to_port (code), -1 for any", r.Protocol)
+		}
+		if r.FromPort < -1 || r.FromPort > 255 || r.ToPort < -1 || r.ToPort > 255 {
+			return fmt.Errorf("invalid %s type %d or code %d", r.Protocol, r.FromPort, r.ToPort)
+		}
+	default:
+		return fmt.Errorf("invalid protocol %q (want tcp, udp, icmp, icmpv6 or all)", r.Protocol)
+	}
+
+	switch {
+	case (r.CIDR == "") == (r.SourceGroupID == ""):
+		return fmt.Errorf("exactly one of cidr or source_group_id is required")
+	case r.SourceGroupID != "":
+		return ValidateID("source_group_id", r.SourceGroupID, "sg-")
+	}
+	if _, err := netip.ParsePrefix(r.CIDR); err != nil {
+		return fmt.Errorf("invalid cidr %q", r.CIDR)
+	}
+	return nil
+}
+
+// protocol returns the EC2 protocol name, with "all" spelled "-1"
+func (r SecurityGroupRule) protocol() string {
+	switch p := strings.ToLower(r.Protocol); p {
+	case "", "all", "-1":
+		return "-1"
+	default:
+		return p
+	}
+}
+
+// key identifies a rule for comparison; descriptions don't count
+func (r SecurityGroupRule) key() string {
+	from, to := r.FromPort, r.ToPort
+	if r.protocol() == "-1" {
+		from, to = 0, 0
+	}
+	return fmt.Sprintf("%s/%d-%d/%s/%s", r.protocol(), from, to, r.CIDR, r.SourceGroupID)
+}
+
+func (r SecurityGroupRule) permission() types.IpPermission {
+	p := types.IpPermission{IpProtocol: aws.String(r.protocol())}
+	if r.protocol() != "-1" {
+		p.FromPort, p.ToPort = aws.Int32(r.FromPort), aws.Int32(r.ToPort)
+	}
+
+	var description *string
+	if r.Description != "" {
+		description = aws.String(r.Description)
+	}
+	switch {
+	case r.SourceGroupID != "":
+		p.UserIdGroupPairs = []types.UserIdGroupPair{{GroupId: aws.String(r.SourceGroupID), Description: description}}
+	case strings.Contains(r.CIDR, ":"):
+		p.Ipv6Ranges = []types.Ipv6Range{{CidrIpv6: aws.String(r.CIDR), Description: description}}
+	default:
+		p.IpRanges = []types.IpRange{{CidrIp: aws.String(r.CIDR), Description: description}}
+	}
+	return p
+}
+
+func permissions(rules []SecurityGroupRule) []types.IpPermission {
+	perms := make([]types.IpPermission, 0, len(rules))
+	for _, rule := range rules {
+		perms = append(perms, rule.permission())
+	}
+	return perms
+}
+
+// RulesFromPermissions flattens EC2 permissions into one rule per CIDR block
+// or source group. Prefix list sources have no rule form and are skipped.
+func RulesFromPermissions(perms []types.IpPermission) []SecurityGroupRule {
+	var rules []SecurityGroupRule
+	for _, p := range perms {
+		base := SecurityGroupRule{
+			Protocol: aws.ToString(p.IpProtocol),
+			FromPort: aws.ToInt32(p.FromPort),
+			ToPort:   aws.ToInt32(p.ToPort),
+		}
+		if base.protocol() == "-1" {
+			base.Protocol, base.FromPort, base.ToPort = "all", 0, 0
+		}
+		for _, r := range p.IpRanges {
+			rule := base
+			rule.CIDR, rule.Description = aws.ToString(r.CidrIp), aws.ToString(r.Description)
+			rules = append(rules, rule)
+		}
+		for _, r := range p.Ipv6Ranges {
+			rule := base
+			rule.CIDR, rule.Description = aws.ToString(r.CidrIpv6), aws.ToString(r.Description)
+			rules = append(rules, rule)
+		}
+		for _, pair := range p.UserIdGroupPairs {
+			rule := base
+			rule.SourceGroupID, rule.Description = aws.ToString(pair.GroupId), aws.ToString(pair.Description)
+			rules = append(rules, rule)
+		}
+	}
+	return rules
+}
+
+// diffRules returns the rules in want missing from have, and those in have
+// not in want
+func diffRules(want, have []SecurityGroupRule) (add, remove []SecurityGroupRule) {
+	wanted := make(map[string]bool, len(want))
+	for _, rule := range want {
+		wanted[rule.key()] = true
+	}
+	existing := make(map[string]bool, len(have))
+	for _, rule := range have {
+		existing[rule.key()] = true
+		if !wanted[rule.key()] {
+			remove = append(remove, rule)
+		}
+	}
+	for _, rule := range want {
+		if !existing[rule.key()] {
+			add = append(add, rule)
+			existing[rule.key()] = true
+		}
+	}
+	return add, remove
+}
+
+func (m *ENIManager) CreateSecurityGroup(ctx context.Context, config SecurityGroupConfig) (_ string, err error) {
+	ctx, op := m.begin(ctx, "CreateSecurityGroup")
+	defer op.end(&err)
+	if err = op.before(); err != nil {
+		return "", err
+	}
+
+	input := &ec2.CreateSecurityGroupInput{
+		GroupName:         aws.String(config.Name),
+		Description:       aws.String(config.Description),
+		TagSpecifications: tagSpecifications(types.ResourceTypeSecurityGroup, config.Tags),
+	}
+	if config.VPCID != "" {
+		input.VpcId = aws.String(config.VPCID)
+	}
+
+	output, err := m.client.CreateSecurityGroup(ctx, input)
+	op.record(input, output)
+	if err != nil {
+		return "", fmt.Errorf("failed to create security group: %w", err)
+	}
+
+	groupID := aws.ToString(output.GroupId)
+	op.set(AttrSecurityGroupID.String(groupID))
+	return groupID, nil
+}
+
+func (m *ENIManager) DescribeSecurityGroups(ctx context.Context, filters []types.Filter) (_ *ec2.DescribeSecurityGroupsOutput, err error) {
+	ctx, op := m.begin(ctx, "DescribeSecurityGroups")
+	defer op.end(&err)
+
+	input := &ec2.DescribeSecurityGroupsInput{
+		Filters: filters,
+	}
+
+	return m.client.DescribeSecurityGroups(ctx, input)
+}
+
+// createdSecurityGroup describes a group ApplySecurityGroup just created, so
+// its rules are diffed against whatever defaults EC2 actually gave it. A dry
+// run only has a placeholder ID, so it assumes the usual allow-all egress rule.
+func (m *ENIManager) createdSecurityGroup(ctx context.Context, groupID string) (types.SecurityGroup, error) {
+	if m.dryRun != DryRunOff {
+		return types.SecurityGroup{
+			GroupId: aws.String(groupID),
+			IpPermissionsEgress: []types.IpPermission{{
+				IpProtocol: aws.String("-1"),
+				IpRanges:   []types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+			}},
+		}, nil
+	}
+
+	output, err := m.DescribeSecurityGroups(ctx, []types.Filter{{Name: aws.String("group-id"), Values: []string{groupID}}})
+	if err != nil {
+		return types.SecurityGroup{}, fmt.Errorf("failed to describe new security group %s: %w", groupID, err)
+	}
+	if len(output.SecurityGroups) != 1 {
+		return types.SecurityGroup{}, fmt.Errorf("new security group %s not found", groupID)
+	}
+	return output.SecurityGroups[0], nil
+}
+
+func (m *ENIManager) AuthorizeSecurityGroupRules(ctx context.Context, groupID string, direction RuleDirection, rules []SecurityGroupRule) (err error) {
+	ctx, op := m.begin(ctx, "AuthorizeSecurityGroupRules", AttrSecurityGroupID.String(groupID))
+	defer op.end(&err)
+	if err = op.before(); err != nil {
+		return err
+	}
+
+	var input, output any
+	switch direction {
+	case Ingress:
+		in := &ec2.AuthorizeSecurityGroupIngressInput{GroupId: aws.String(groupID), IpPermissions: permissions(rules)}
+		input = in
+		output, err = m.client.AuthorizeSecurityGroupIngress(ctx, in)
+	case Egress:
+		in := &ec2.AuthorizeSecurityGroupEgressInput{GroupId: aws.String(groupID), IpPermissions: permissions(rules)}
+		input = in
+		output, err = m.client.AuthorizeSecurityGroupEgress(ctx, in)
+	default:
+		return fmt.Errorf("invalid rule direction %q", direction)
+	}
+	op.record(input, output)
+	if err != nil {
+		return fmt.Errorf("failed to authorize %s rules: %w", direction, err)
+	}
+
+	return nil
+}
+
+func (m *ENIManager) RevokeSecurityGroupRules(ctx context.Context, groupID string, direction RuleDirection, rules []SecurityGroupRule) (err error) {
+	ctx, op := m.begin(ctx, "RevokeSecurityGroupRules", AttrSecurityGroupID.String(groupID))
+	defer op.end(&err)
+	if err = op.before(); err != nil {
+		return err
+	}
+
+	var input, output any
+	switch direction {
+	case Ingress:
+		in := &ec2.RevokeSecurityGroupIngressInput{GroupId: aws.String(groupID), IpPermissions: permissions(rules)}
+		input = in
+		output, err = m.client.RevokeSecurityGroupIngress(ctx, in)
+	case Egress:
+		in := &ec2.RevokeSecurityGroupEgressInput{GroupId: aws.String(groupID), IpPermissions: permissions(rules)}
+		input = in
+		output, err = m.client.RevokeSecurityGroupEgress(ctx, in)
+	default:
+		return fmt.Errorf("invalid rule direction %q", direction)
+	}
+	op.record(input, output)
+	if err != nil {
+		return fmt.Errorf("failed to revoke %s rules: %w", direction, err)
+	}
+
+	return nil
+}
+
+func (m *ENIManager) DeleteSecurityGroup(ctx context.Context, groupID string) (err error) {
+	ctx, op := m.begin(ctx, "DeleteSecurityGroup", AttrSecurityGroupID.String(groupID))
+	defer op.end(&err)
+	if err = op.before(); err != nil {
+		return err
+	}
+
+	input := &ec2.DeleteSecurityGroupInput{
+		GroupId: aws.String(groupID),
+	}
+
+	output, err := m.client.DeleteSecurityGroup(ctx, input)
+	op.record(input, output)
+	if err != nil {
+		return fmt.Errorf("failed to delete security group: %w", err)
+	}
+
+	return nil
+}
+
+// ApplySecurityGroup makes the security group named in spec match it,
+// creating the group if it doesn't exist. Missing rules are authorized
+// before extra rules are revoked, so swapping a rule never leaves a gap.
+// Rules that differ only in description are left alone.
+func (m *ENIManager) ApplySecurityGroup(ctx context.Context, spec SecurityGroupSpec) (*SecurityGroupChanges, error) {
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	filters := []types.Filter{{Name: aws.String("group-name"), Values: []string{spec.Name}}}
+	if spec.VPCID != "" {
+		filters = append(filters, types.Filter{Name: aws.String("vpc-id"), Values: []string{spec.VPCID}})
+	}
+	output, err := m.DescribeSecurityGroups(ctx, filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up security group %s: %w", spec.Name, err)
+	}
+
+	changes := &SecurityGroupChanges{
+		Authorized: make(map[RuleDirection][]SecurityGroupRule),
+		Revoked:    make(map[RuleDirection][]SecurityGroupRule),
+	}
+	var current types.SecurityGroup
+	switch len(output.SecurityGroups) {
+	case 0:
+		if changes.GroupID, err = m.CreateSecurityGroup(ctx, spec.SecurityGroupConfig); err != nil {
+			return nil, err
+		}
+		changes.Created = true
+		if current, err = m.createdSecurityGroup(ctx, changes.GroupID); err != nil {
+			return changes, err
+		}
+	case 1:
+		current = output.SecurityGroups[0]
+		changes.GroupID = aws.ToString(current.GroupId)
+	default:
+		return nil, fmt.Errorf("%d security groups are named %s; set vpc_id to pick one", len(output.SecurityGroups), spec.Name)
+	}
+
+	directions := []struct {
+		direction RuleDirection
+		want      []SecurityGroupRule
+		have      []types.IpPermission
+	}{
+		{Ingress, spec.Ingress, current.IpPermissions},
+		{Egress, spec.Egress, current.IpPermissionsEgress},
+	}
+	for _, d := range directions {
+		if d.direction == Egress && d.want == nil {
+			continue
+		}
+		add, remove := diffRules(d.want, RulesFromPermissions(d.have))
+		if len(add) > 0 {
+			if err := m.AuthorizeSecurityGroupRules(ctx, changes.GroupID, d.direction, add); err != nil {
+				return changes, err
+			}
+			changes.Authorized[d.direction] = add
+		}
+		if len(remove) > 0 {
+			if err := m.RevokeSecurityGroupRules(ctx, changes.GroupID, d.direction, remove); err != nil {
+				return changes, err
+			}
+			changes.Revoked[d.direction] = remove
+		}
+	}
+	return changes, nil
+}
+
+// UpdateENISecurityGroups adds and removes security groups on an ENI with a
+// single ModifyENIAttribute call and returns the resulting groups. Swapping
+// one group for another is an add and a remove together. Offline dry runs
+// can't see the ENI's current groups, so they preview against none.
+func (m *ENIManager) UpdateENISecurityGroups(ctx context.Context, networkInterfaceID string, add, remove []string) ([]string, error) {
+	if err := ValidateID("NetworkInterfaceId", networkInterfaceID, "eni-"); err != nil {
+		return nil, err
+	}
+	if err := ValidateIDs("security group", append(slices.Clip(add), remove...), "sg-"); err != nil {
+		return nil, err
+	}
+
+	output, err := m.DescribeENIs(ctx, []types.Filter{
+		{Name: aws.String("network-interface-id"), Values: []string{networkInterfaceID}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(output.NetworkInterfaces) == 0 && m.dryRun != DryRunOffline {
+		return nil, fmt.Errorf("ENI %s not found", networkInterfaceID)
+	}
+
+	var current []string
+	for _, eni := range output.NetworkInterfaces {
+		for _, group := range eni.Groups {
+			current = append(current, aws.ToString(group.GroupId))
+		}
+	}
+
+	var groups []string
+	for _, id := range current {
+		if !slices.Contains(remove, id) {
+			groups = append(groups, id)
+		}
+	}
+	for _, id := range add {
+		if !slices.Contains(groups, id) {
+			groups = append(groups, id)
+		}
+	}
+
+	switch {
+	case len(groups) == 0:
+		return nil, fmt.Errorf("ENI %s must keep at least one security group", networkInterfaceID)
+	case slices.Equal(groups, current):
+		return groups, nil
+	}
+	if err := m.ModifyENIAttribute(ctx, networkInterfaceID, ENIModifyConfig{SecurityGroupIDs: groups}); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
@@ -0,0 +1,227 @@
+package ec2
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplySecurityGroup_CreatesGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	spec := SecurityGroupSpec{
+		SecurityGroupConfig: SecurityGroupConfig{
+			Name:        "eni-test-sg",
+			Description: "Security group for ENI testing",
+			VPCID:       "vpc-12345678",
+		},
+		Ingress: []SecurityGroupRule{
+			{Protocol: "tcp", FromPort: 22, ToPort: 22, CIDR: "203.0.113.7/32"},
+		},
+		Egress: []SecurityGroupRule{},
+	}
+
+	mockClient.EXPECT().DescribeSecurityGroups(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeSecurityGroupsOutput{}, nil)
+	mockClient.EXPECT().CreateSecurityGroup(gomock.Any(), gomock.Eq(&ec2.CreateSecurityGroupInput{
+		GroupName:   aws.String("eni-test-sg"),
+		Description: aws.String("Security group for ENI testing"),
+		VpcId:       aws.String("vpc-12345678"),
+	})).Return(&ec2.CreateSecurityGroupOutput{GroupId: aws.String("sg-12345678")}, nil)
+	// The new group's default rules come from EC2, which adds an IPv6
+	// allow-all egress rule in some VPCs
+	mockClient.EXPECT().DescribeSecurityGroups(gomock.Any(), gomock.Eq(&ec2.DescribeSecurityGroupsInput{
+		Filters: []types.Filter{{Name: aws.String("group-id"), Values: []string{"sg-12345678"}}},
+	})).Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: []types.SecurityGroup{{
+		GroupId: aws.String("sg-12345678"),
+		IpPermissionsEgress: []types.IpPermission{{
+			IpProtocol: aws.String("-1"),
+			IpRanges:   []types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+			Ipv6Ranges: []types.Ipv6Range{{CidrIpv6: aws.String("::/0")}},
+		}},
+	}}}, nil)
+	mockClient.EXPECT().AuthorizeSecurityGroupIngress(gomock.Any(), gomock.Eq(&ec2.AuthorizeSecurityGroupIngressInput{
+		GroupId: aws.String("sg-12345678"),
+		IpPermissions: []types.IpPermission{{
+			IpProtocol: aws.String("tcp"),
+			FromPort:   aws.Int32(22),
+			ToPort:     aws.Int32(22),
+			IpRanges:   []types.IpRange{{CidrIp: aws.String("203.0.113.7/32")}},
+		}},
+	})).Return(&ec2.AuthorizeSecurityGroupIngressOutput{}, nil)
+	// An empty egress list removes the default allow-all rules
+	mockClient.EXPECT().RevokeSecurityGroupEgress(gomock.Any(), gomock.Eq(&ec2.RevokeSecurityGroupEgressInput{
+		GroupId: aws.String("sg-12345678"),
+		IpPermissions: []types.IpPermission{
+			{IpProtocol: aws.String("-1"), IpRanges: []types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}}},
+			{IpProtocol: aws.String("-1"), Ipv6Ranges: []types.Ipv6Range{{CidrIpv6: aws.String("::/0")}}},
+		},
+	})).Return(&ec2.RevokeSecurityGroupEgressOutput{}, nil)
+
+	changes, err := manager.ApplySecurityGroup(context.Background(), spec)
+	assert.NoError(t, err)
+	assert.Equal(t, "sg-12345678", changes.GroupID)
+	assert.True(t, changes.Created)
+	assert.Len(t, changes.Authorized[Ingress], 1)
+	assert.Len(t, changes.Revoked[Egress], 2)
+}
+
+func TestApplySecurityGroup_UpdatesRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	mockClient.EXPECT().DescribeSecurityGroups(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeSecurityGroupsOutput{SecurityGroups: []types.SecurityGroup{{
+			GroupId: aws.String("sg-12345678"),
+			IpPermissions: []types.IpPermission{
+				{
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int32(22),
+					ToPort:     aws.Int32(22),
+					IpRanges:   []types.IpRange{{CidrIp: aws.String("203.0.113.7/32"), Description: aws.String("ssh")}},
+				},
+				{
+					IpProtocol: aws.String("tcp"),
+					FromPort:   aws.Int32(80),
+					ToPort:     aws.Int32(80),
+					IpRanges:   []types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+				},
+			},
+			IpPermissionsEgress: []types.IpPermission{{
+				IpProtocol: aws.String("-1"),
+				IpRanges:   []types.IpRange{{CidrIp: aws.String("0.0.0.0/0")}},
+			}},
+		}}}, nil)
+	mockClient.EXPECT().AuthorizeSecurityGroupIngress(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *ec2.AuthorizeSecurityGroupIngressInput, _ ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+			if assert.Len(t, input.IpPermissions, 1) {
+				assert.Equal(t, int32(443), aws.ToInt32(input.IpPermissions[0].FromPort))
+			}
+			return &ec2.AuthorizeSecurityGroupIngressOutput{}, nil
+		})
+	mockClient.EXPECT().RevokeSecurityGroupIngress(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *ec2.RevokeSecurityGroupIngressInput, _ ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+			if assert.Len(t, input.IpPermissions, 1) {
+				assert.Equal(t, int32(80), aws.ToInt32(input.IpPermissions[0].FromPort))
+			}
+			return &ec2.RevokeSecurityGroupIngressOutput{}, nil
+		})
+
+	// The ssh rule matches despite its description; egress is unmanaged
+	changes, err := manager.ApplySecurityGroup(context.Background(), SecurityGroupSpec{
+		SecurityGroupConfig: SecurityGroupConfig{Name: "web", Description: "web"},
+		Ingress: []SecurityGroupRule{
+			{Protocol: "tcp", FromPort: 22, ToPort: 22, CIDR: "203.0.113.7/32"},
+			{Protocol: "tcp", FromPort: 443, ToPort: 443, CIDR: "0.0.0.0/0"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.False(t, changes.Created)
+	assert.Empty(t, changes.Revoked[Egress])
+}
+
+func TestUpdateENISecurityGroups(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []types.NetworkInterface{{
+			NetworkInterfaceId: aws.String("eni-12345678"),
+			Groups: []types.GroupIdentifier{
+				{GroupId: aws.String("sg-aaaaaaaa")},
+				{GroupId: aws.String("sg-bbbbbbbb")},
+			},
+		}}}, nil)
+	mockClient.EXPECT().ModifyNetworkInterfaceAttribute(gomock.Any(), gomock.Eq(&ec2.ModifyNetworkInterfaceAttributeInput{
+		NetworkInterfaceId: aws.String("eni-12345678"),
+		Groups:             []string{"sg-aaaaaaaa", "sg-cccccccc"},
+	})).Return(&ec2.ModifyNetworkInterfaceAttributeOutput{}, nil)
+
+	groups, err := manager.UpdateENISecurityGroups(context.Background(), "eni-12345678",
+		[]string{"sg-cccccccc"}, []string{"sg-bbbbbbbb"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"sg-aaaaaaaa", "sg-cccccccc"}, groups)
+}
+
+func TestUpdateENISecurityGroups_KeepsOneGroup(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []types.NetworkInterface{{
+			Groups: []types.GroupIdentifier{{GroupId: aws.String("sg-aaaaaaaa")}},
+		}}}, nil)
+
+	_, err := manager.UpdateENISecurityGroups(context.Background(), "eni-12345678", nil, []string{"sg-aaaaaaaa"})
+	assert.ErrorContains(t, err, "at least one security group")
+}
+
+func TestSecurityGroupRule_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    SecurityGroupRule
+		wantErr string
+	}{
+		{"tcp", SecurityGroupRule{Protocol: "tcp", FromPort: 22, ToPort: 22, CIDR: "10.0.0.0/16"}, ""},
+		{"all from group", SecurityGroupRule{Protocol: "all", SourceGroupID: "sg-12345678"}, ""},
+		{"ipv6", SecurityGroupRule{Protocol: "udp", FromPort: 53, ToPort: 53, CIDR: "2001:db8::/32"}, ""},
+		{"bad protocol", SecurityGroupRule{Protocol: "sctp", CIDR: "10.0.0.0/16"}, "invalid protocol"},
+		{"reversed ports", SecurityGroupRule{Protocol: "tcp", FromPort: 443, ToPort: 80, CIDR: "10.0.0.0/16"}, "port range"},
+		{"no source", SecurityGroupRule{Protocol: "tcp", FromPort: 22, ToPort: 22}, "exactly one"},
+		{"bad cidr", SecurityGroupRule{Protocol: "tcp", FromPort: 22, ToPort: 22, CIDR: "10.0.0.0"}, "invalid cidr"},
+		{"all icmp", SecurityGroupRule{Protocol: "icmp", FromPort: -1, ToPort: -1, CIDR: "10.0.0.0/16"}, ""},
+		{"echo reply", SecurityGroupRule{Protocol: "icmp", FromPort: 0, ToPort: -1, CIDR: "10.0.0.0/16"}, ""},
+		{"icmp without type", SecurityGroupRule{Protocol: "icmpv6", CIDR: "2001:db8::/32"}, "needs from_port"},
+		{"bad icmp type", SecurityGroupRule{Protocol: "icmp", FromPort: 256, ToPort: -1, CIDR: "10.0.0.0/16"}, "invalid icmp type"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rule.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoadSecurityGroupSpecs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "security-groups.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("security_groups:\n  - name: web\n    description: Web servers\n    ingress:\n      - {protocol: tcp, from_port: 443, to_port: 443, cidr: 0.0.0.0/0}\n"), 0o644))
+	specs, err := LoadSecurityGroupSpecs(path)
+	assert.NoError(t, err)
+	if assert.Len(t, specs, 1) {
+		assert.Equal(t, "web", specs[0].Name)
+		assert.Len(t, specs[0].Ingress, 1)
+	}
+
+	assert.NoError(t, os.WriteFile(path, []byte("security_groups:\n  - name: web\n    descripton: Web servers\n"), 0o644))
+	_, err = LoadSecurityGroupSpecs(path)
+	assert.ErrorContains(t, err, "descripton")
+
+	assert.NoError(t, os.WriteFile(path, nil, 0o644))
+	_, err = LoadSecurityGroupSpecs(path)
+	assert.ErrorContains(t, err, "is empty")
+}
@@ -0,0 +1,311 @@
+package ec2
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Snapshot is the configuration of the ENIs in a subnet or on an instance,
+// captured before maintenance so RestoreSnapshot can recreate them
+type Snapshot struct {
+	// Source is the subnet or instance ID the snapshot was taken of
+	Source  string        `yaml:"source"`
+	TakenAt time.Time     `yaml:"taken_at"`
+	ENIs    []ENISnapshot `yaml:"enis"`
+	// Skipped maps the ENIs that can't be recreated to the reason why
+	Skipped map[string]string `yaml:"skipped,omitempty"`
+}
+
+// ENISnapshot is the configuration of one ENI, with its explicit addresses.
+// IPv4 and IPv6 prefixes are not captured.
+type ENISnapshot struct {
+	ENIID            string            `yaml:"eni_id"`
+	SubnetID         string            `yaml:"subnet_id"`
+	Description      string            `yaml:"description,omitempty"`
+	SecurityGroupIDs []string          `yaml:"security_group_ids"`
+	Tags             map[string]string `yaml:"tags,omitempty"`
+	// InterfaceType is empty for a standard ENI
+	InterfaceType types.NetworkInterfaceCreationType `yaml:"interface_type,omitempty"`
+	// PrivateIPAddress is empty for efa-only interfaces, which have no
+	// IP addresses
+	PrivateIPAddress    string              `yaml:"private_ip_address,omitempty"`
+	SecondaryPrivateIPs []string            `yaml:"secondary_private_ips,omitempty"`
+	IPv6Addresses       []string            `yaml:"ipv6_addresses,omitempty"`
+	Attachment          *SnapshotAttachment `yaml:"attachment,omitempty"`
+}
+
+// SnapshotAttachment is where a snapshotted ENI was attached
+type SnapshotAttachment struct {
+	InstanceID       string `yaml:"instance_id"`
+	DeviceIndex      int32  `yaml:"device_index"`
+	NetworkCardIndex int32  `yaml:"network_card_index"`
+}
+
+// Config returns the ENIConfig that recreates the ENI with its primary IP
+func (s ENISnapshot) Config() ENIConfig {
+	return ENIConfig{
+		SubnetID:         s.SubnetID,
+		Description:      s.Description,
+		SecurityGroupIDs: s.SecurityGroupIDs,
+		PrivateIPAddress: s.PrivateIPAddress,
+		Tags:             s.Tags,
+		InterfaceType:    s.InterfaceType,
+	}
+}
+
+// Addresses returns every address of the ENI, primary first
+func (s ENISnapshot) Addresses() []string {
+	var addresses []string
+	if s.PrivateIPAddress != "" {
+		addresses = append(addresses, s.PrivateIPAddress)
+	}
+	addresses = append(addresses, s.SecondaryPrivateIPs...)
+	return append(addresses, s.IPv6Addresses...)
+}
+
+// Validate checks the snapshot for mistakes EC2 would reject anyway
+func (s ENISnapshot) Validate() error {
+	if err := ValidateID("eni_id", s.ENIID, "eni-"); err != nil {
+		return err
+	}
+	if err := s.Config().Validate(); err != nil {
+		return err
+	}
+	if s.InterfaceType == types.NetworkInterfaceCreationTypeEfaOnly {
+		if len(s.Addresses()) > 0 {
+			return fmt.Errorf("efa-only interfaces have no IP addresses")
+		}
+	} else if s.PrivateIPAddress == "" {
+		return fmt.Errorf("private_ip_address is required")
+	}
+	if err := ValidateAddresses(s.SecondaryPrivateIPs, false); err != nil {
+		return fmt.Errorf("secondary_private_ips: %w", err)
+	}
+	if err := ValidateAddresses(s.IPv6Addresses, true); err != nil {
+		return fmt.Errorf("ipv6_addresses: %w", err)
+	}
+	if s.Attachment != nil {
+		if err := ValidateID("attachment.instance_id", s.Attachment.InstanceID, "i-"); err != nil {
+			return err
+		}
+		if s.Attachment.DeviceIndex < 1 || s.Attachment.NetworkCardIndex < 0 {
+			return fmt.Errorf("attachment device_index must be at least 1 and network_card_index not negative")
+		}
+	}
+	return nil
+}
+
+// LoadSnapshot reads a snapshot file written by WriteSnapshot
+func LoadSnapshot(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshot Snapshot
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&snapshot); errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("snapshot file %s is empty", path)
+	} else if err != nil {
+		return nil, fmt.Errorf("invalid snapshot file %s: %w", path, err)
+	}
+	for _, eni := range snapshot.ENIs {
+		if err := eni.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: ENI %s: %w", path, eni.ENIID, err)
+		}
+	}
+	return &snapshot, nil
+}
+
+// WriteSnapshot writes snapshot in the format LoadSnapshot reads
+func WriteSnapshot(w io.Writer, snapshot *Snapshot) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	if err := enc.Encode(snapshot); err != nil {
+		return err
+	}
+	return enc.Close()
+}
+
+// TakeSnapshot captures the ENIs in a subnet or attached to an instance,
+// depending on the prefix of source. ENIs that can't be recreated, such as
+// an instance's primary ENI or ones AWS services manage, are skipped.
+func (m *ENIManager) TakeSnapshot(ctx context.Context, source string) (*Snapshot, error) {
+	var filter string
+	switch {
+	case strings.HasPrefix(source, "subnet-"):
+		filter = "subnet-id"
+	case strings.HasPrefix(source, "i-"):
+		filter = "attachment.instance-id"
+	default:
+		return nil, fmt.Errorf("snapshot source %q must be a subnet or instance ID", source)
+	}
+
+	enis, err := m.DescribeENIs(ctx, []types.Filter{
+		{Name: aws.String(filter), Values: []string{source}},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := &Snapshot{Source: source, TakenAt: time.Now().UTC()}
+	for _, eni := range enis.NetworkInterfaces {
+		eniID := aws.ToString(eni.NetworkInterfaceId)
+		if reason := snapshotSkipReason(eni); reason != "" {
+			if snapshot.Skipped == nil {
+				snapshot.Skipped = make(map[string]string)
+			}
+			snapshot.Skipped[eniID] = reason
+			continue
+		}
+		snapshot.ENIs = append(snapshot.ENIs, SnapshotENI(eni))
+	}
+	return snapshot, nil
+}
+
+// snapshotSkipReason returns why eni can't be recreated, or "" if it can
+func snapshotSkipReason(eni types.NetworkInterface) string {
+	if aws.ToBool(eni.RequesterManaged) {
+		return "managed by " + aws.ToString(eni.RequesterId)
+	}
+	if eni.Attachment != nil && aws.ToInt32(eni.Attachment.DeviceIndex) == 0 {
+		return "primary ENI of " + aws.ToString(eni.Attachment.InstanceId)
+	}
+	switch types.NetworkInterfaceCreationType(eni.InterfaceType) {
+	case "", types.NetworkInterfaceCreationType(types.NetworkInterfaceTypeInterface),
+		types.NetworkInterfaceCreationTypeEfa, types.NetworkInterfaceCreationTypeEfaOnly,
+		types.NetworkInterfaceCreationTypeTrunk:
+		return ""
+	}
+	return fmt.Sprintf("interface type %s can't be created", eni.InterfaceType)
+}
+
+// SnapshotENI captures the configuration of an ENI
+func SnapshotENI(eni types.NetworkInterface) ENISnapshot {
+	snapshot := ENISnapshot{
+		ENIID:            aws.ToString(eni.NetworkInterfaceId),
+		SubnetID:         aws.ToString(eni.SubnetId),
+		Description:      aws.ToString(eni.Description),
+		SecurityGroupIDs: sortedGroups(eni),
+		PrivateIPAddress: aws.ToString(eni.PrivateIpAddress),
+	}
+	if tags := userTags(eni); len(tags) > 0 {
+		snapshot.Tags = tags
+	}
+	if eni.InterfaceType != types.NetworkInterfaceTypeInterface {
+		snapshot.InterfaceType = types.NetworkInterfaceCreationType(eni.InterfaceType)
+	}
+	for _, ip := range eni.PrivateIpAddresses {
+		if address := aws.ToString(ip.PrivateIpAddress); !aws.ToBool(ip.Primary) && address != "" {
+			snapshot.SecondaryPrivateIPs = append(snapshot.SecondaryPrivateIPs, address)
+		}
+	}
+	for _, ip := range eni.Ipv6Addresses {
+		snapshot.IPv6Addresses = append(snapshot.IPv6Addresses, aws.ToString(ip.Ipv6Address))
+	}
+	if eni.Attachment != nil && eni.Attachment.InstanceId != nil {
+		snapshot.Attachment = &SnapshotAttachment{
+			InstanceID:       aws.ToString(eni.Attachment.InstanceId),
+			DeviceIndex:      aws.ToInt32(eni.Attachment.DeviceIndex),
+			NetworkCardIndex: aws.ToInt32(eni.Attachment.NetworkCardIndex),
+		}
+	}
+	return snapshot
+}
+
+// UnreclaimedAddress is a snapshot address a restored ENI didn't get back
+type UnreclaimedAddress struct {
+	Address string `json:"address"`
+	// Reason is the AWS error code of the failed assignment
+	Reason string `json:"reason"`
+}
+
+// RestoreResult is the outcome of recreating one snapshotted ENI
+type RestoreResult struct {
+	OriginalENIID string `json:"original_eni_id"`
+	// ENIID is empty if the ENI couldn't be created
+	ENIID        string               `json:"eni_id,omitempty"`
+	AttachmentID string               `json:"attachment_id,omitempty"`
+	Unreclaimed  []UnreclaimedAddress `json:"unreclaimed,omitempty"`
+	Error        string               `json:"error,omitempty"`
+}
+
+// RestoreSnapshot recreates the ENIs of a snapshot with the same addresses
+// and reattaches them, once the original ENIs have been deleted. An address
+// that is taken by now doesn't fail the restore: the ENI gets another
+// primary IP or goes without the secondary one, and the address is
+// reported in Unreclaimed. The returned error joins the ENIs that couldn't
+// be created or reattached; an ENI that fails to reattach is kept, since it
+// holds the reclaimed addresses.
+func (m *ENIManager) RestoreSnapshot(ctx context.Context, snapshot *Snapshot) ([]RestoreResult, error) {
+	results := make([]RestoreResult, 0, len(snapshot.ENIs))
+	var errs []error
+	for _, eni := range snapshot.ENIs {
+		result, err := m.restoreENI(ctx, eni)
+		if err != nil {
+			result.Error = err.Error()
+			errs = append(errs, fmt.Errorf("%s: %w", eni.ENIID, err))
+		}
+		results = append(results, result)
+	}
+	return results, errors.Join(errs...)
+}
+
+func (m *ENIManager) restoreENI(ctx context.Context, snapshot ENISnapshot) (RestoreResult, error) {
+	result := RestoreResult{OriginalENIID: snapshot.ENIID}
+	unreclaimed := func(address string, err error) {
+		result.Unreclaimed = append(result.Unreclaimed, UnreclaimedAddress{Address: address, Reason: ErrorCode(err)})
+	}
+
+	config := snapshot.Config()
+	output, err := m.CreateENI(ctx, config)
+	if err != nil && config.PrivateIPAddress != "" && ErrorCode(err) == "InvalidIPAddress.InUse" {
+		unreclaimed(config.PrivateIPAddress, err)
+		config.PrivateIPAddress = ""
+		output, err = m.CreateENI(ctx, config)
+	}
+	if err != nil {
+		addresses := snapshot.Addresses()
+		if len(result.Unreclaimed) > 0 {
+			// The primary IP is already reported
+			addresses = addresses[1:]
+		}
+		for _, address := range addresses {
+			unreclaimed(address, err)
+		}
+		return result, fmt.Errorf("failed to create ENI: %w", err)
+	}
+	result.ENIID = aws.ToString(output.NetworkInterface.NetworkInterfaceId)
+
+	// One address per call, so a taken address doesn't cost the others
+	for _, address := range snapshot.SecondaryPrivateIPs {
+		if err := m.AssignPrivateIPs(ctx, result.ENIID, 0, []string{address}); err != nil {
+			unreclaimed(address, err)
+		}
+	}
+	for _, address := range snapshot.IPv6Addresses {
+		if err := m.AssignIPv6Addresses(ctx, result.ENIID, []string{address}, nil); err != nil {
+			unreclaimed(address, err)
+		}
+	}
+
+	if attachment := snapshot.Attachment; attachment != nil {
+		attachmentID, err := m.AttachENIToCard(ctx, result.ENIID, attachment.InstanceID, attachment.DeviceIndex, attachment.NetworkCardIndex)
+		if err != nil {
+			return result, fmt.Errorf("failed to reattach %s to %s: %w", result.ENIID, attachment.InstanceID, err)
+		}
+		result.AttachmentID = aws.ToString(attachmentID)
+	}
+	return result, nil
+}
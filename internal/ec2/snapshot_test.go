@@ -0,0 +1,248 @@
+package ec2
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var snapshotENI = ENISnapshot{
+	ENIID:               "eni-12345678",
+	SubnetID:            "subnet-12345678",
+	Description:         "web ENI",
+	SecurityGroupIDs:    []string{"sg-aaaaaaaa"},
+	Tags:                map[string]string{"Name": "web"},
+	PrivateIPAddress:    "10.0.1.10",
+	SecondaryPrivateIPs: []string{"10.0.1.11", "10.0.1.12"},
+	IPv6Addresses:       []string{"2001:db8::10"},
+	Attachment:          &SnapshotAttachment{InstanceID: "i-12345678", DeviceIndex: 1},
+}
+
+func TestTakeSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []types.NetworkInterface{
+			{
+				NetworkInterfaceId: aws.String("eni-12345678"),
+				SubnetId:           aws.String("subnet-12345678"),
+				Description:        aws.String("web ENI"),
+				InterfaceType:      types.NetworkInterfaceTypeInterface,
+				Groups:             []types.GroupIdentifier{{GroupId: aws.String("sg-aaaaaaaa")}},
+				TagSet: []types.Tag{
+					{Key: aws.String("Name"), Value: aws.String("web")},
+					{Key: aws.String("aws:cloudformation:stack-name"), Value: aws.String("stack")},
+				},
+				PrivateIpAddress: aws.String("10.0.1.10"),
+				PrivateIpAddresses: []types.NetworkInterfacePrivateIpAddress{
+					{PrivateIpAddress: aws.String("10.0.1.10"), Primary: aws.Bool(true)},
+					{PrivateIpAddress: aws.String("10.0.1.11"), Primary: aws.Bool(false)},
+					{PrivateIpAddress: aws.String("10.0.1.12"), Primary: aws.Bool(false)},
+				},
+				Ipv6Addresses: []types.NetworkInterfaceIpv6Address{{Ipv6Address: aws.String("2001:db8::10")}},
+				Attachment: &types.NetworkInterfaceAttachment{
+					InstanceId:       aws.String("i-12345678"),
+					DeviceIndex:      aws.Int32(1),
+					NetworkCardIndex: aws.Int32(0),
+				},
+			},
+			{
+				NetworkInterfaceId: aws.String("eni-primary"),
+				Attachment:         &types.NetworkInterfaceAttachment{InstanceId: aws.String("i-12345678"), DeviceIndex: aws.Int32(0)},
+			},
+			{
+				NetworkInterfaceId: aws.String("eni-elb"),
+				RequesterManaged:   aws.Bool(true),
+				RequesterId:        aws.String("amazon-elb"),
+			},
+			{
+				NetworkInterfaceId: aws.String("eni-nat"),
+				InterfaceType:      types.NetworkInterfaceTypeNatGateway,
+			},
+		}}, nil)
+
+	snapshot, err := manager.TakeSnapshot(context.Background(), "subnet-12345678")
+	assert.NoError(t, err)
+	assert.Equal(t, []ENISnapshot{snapshotENI}, snapshot.ENIs)
+	assert.Equal(t, map[string]string{
+		"eni-primary": "primary ENI of i-12345678",
+		"eni-elb":     "managed by amazon-elb",
+		"eni-nat":     "interface type natGateway can't be created",
+	}, snapshot.Skipped)
+
+	_, err = manager.TakeSnapshot(context.Background(), "vpc-12345678")
+	assert.ErrorContains(t, err, "must be a subnet or instance ID")
+}
+
+func TestRestoreSnapshot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+	inUse := &smithy.GenericAPIError{Code: "InvalidIPAddress.InUse"}
+
+	// The primary IP is taken, so the ENI is created with another one
+	gomock.InOrder(
+		mockClient.EXPECT().CreateNetworkInterface(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, input *ec2.CreateNetworkInterfaceInput, _ ...func(*ec2.Options)) (*ec2.CreateNetworkInterfaceOutput, error) {
+				assert.Equal(t, "10.0.1.10", aws.ToString(input.PrivateIpAddress))
+				return nil, inUse
+			}),
+		mockClient.EXPECT().CreateNetworkInterface(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, input *ec2.CreateNetworkInterfaceInput, _ ...func(*ec2.Options)) (*ec2.CreateNetworkInterfaceOutput, error) {
+				assert.Nil(t, input.PrivateIpAddress)
+				return &ec2.CreateNetworkInterfaceOutput{NetworkInterface: &types.NetworkInterface{
+					NetworkInterfaceId: aws.String("eni-87654321"),
+				}}, nil
+			}),
+	)
+	mockClient.EXPECT().AssignPrivateIpAddresses(gomock.Any(), gomock.Eq(&ec2.AssignPrivateIpAddressesInput{
+		NetworkInterfaceId: aws.String("eni-87654321"),
+		PrivateIpAddresses: []string{"10.0.1.11"},
+	})).Return(&ec2.AssignPrivateIpAddressesOutput{}, nil)
+	mockClient.EXPECT().AssignPrivateIpAddresses(gomock.Any(), gomock.Eq(&ec2.AssignPrivateIpAddressesInput{
+		NetworkInterfaceId: aws.String("eni-87654321"),
+		PrivateIpAddresses: []string{"10.0.1.12"},
+	})).Return(nil, inUse)
+	mockClient.EXPECT().AssignIpv6Addresses(gomock.Any(), gomock.Eq(&ec2.AssignIpv6AddressesInput{
+		NetworkInterfaceId: aws.String("eni-87654321"),
+		Ipv6Addresses:      []string{"2001:db8::10"},
+	})).Return(&ec2.AssignIpv6AddressesOutput{}, nil)
+	mockClient.EXPECT().AttachNetworkInterface(gomock.Any(), gomock.Eq(&ec2.AttachNetworkInterfaceInput{
+		NetworkInterfaceId: aws.String("eni-87654321"),
+		InstanceId:         aws.String("i-12345678"),
+		DeviceIndex:        aws.Int32(1),
+		NetworkCardIndex:   aws.Int32(0),
+	})).Return(&ec2.AttachNetworkInterfaceOutput{AttachmentId: aws.String("eni-attach-12345678")}, nil)
+
+	results, err := manager.RestoreSnapshot(context.Background(), &Snapshot{ENIs: []ENISnapshot{snapshotENI}})
+	assert.NoError(t, err)
+	assert.Equal(t, []RestoreResult{{
+		OriginalENIID: "eni-12345678",
+		ENIID:         "eni-87654321",
+		AttachmentID:  "eni-attach-12345678",
+		Unreclaimed: []UnreclaimedAddress{
+			{Address: "10.0.1.10", Reason: "InvalidIPAddress.InUse"},
+			{Address: "10.0.1.12", Reason: "InvalidIPAddress.InUse"},
+		},
+	}}, results)
+}
+
+func TestRestoreSnapshot_CreateFails(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	mockClient.EXPECT().CreateNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(nil, &smithy.GenericAPIError{Code: "InvalidSubnetID.NotFound"})
+
+	results, err := manager.RestoreSnapshot(context.Background(), &Snapshot{ENIs: []ENISnapshot{snapshotENI}})
+	assert.ErrorContains(t, err, "eni-12345678: failed to create ENI")
+	if assert.Len(t, results, 1) {
+		assert.Empty(t, results[0].ENIID)
+		assert.Len(t, results[0].Unreclaimed, 4)
+		assert.NotEmpty(t, results[0].Error)
+	}
+}
+
+func TestSnapshot_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "snapshot.yaml")
+	f, err := os.Create(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	snapshot := &Snapshot{Source: "subnet-12345678", ENIs: []ENISnapshot{snapshotENI}}
+	assert.NoError(t, WriteSnapshot(f, snapshot))
+	f.Close()
+
+	loaded, err := LoadSnapshot(path)
+	assert.NoError(t, err)
+	assert.Equal(t, snapshot.ENIs, loaded.ENIs)
+
+	assert.NoError(t, os.WriteFile(path, []byte("enis:\n  - eni_id: eni-1\n    subnet_id: subnet-1\n    security_group_ids: [sg-1]\n    private_ip_address: 10.0.1.10\n    ipv6_addresses: [10.0.1.11]\n"), 0o644))
+	_, err = LoadSnapshot(path)
+	assert.ErrorContains(t, err, "is not an IPv6 address")
+
+	assert.NoError(t, os.WriteFile(path, nil, 0o644))
+	_, err = LoadSnapshot(path)
+	assert.ErrorContains(t, err, "is empty")
+}
+
+func TestSnapshot_EFAOnlyRoundTrip(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	// efa-only interfaces have no IP addresses at all
+	mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []types.NetworkInterface{{
+			NetworkInterfaceId: aws.String("eni-12345678"),
+			SubnetId:           aws.String("subnet-12345678"),
+			InterfaceType:      types.NetworkInterfaceTypeEfaOnly,
+			Groups:             []types.GroupIdentifier{{GroupId: aws.String("sg-aaaaaaaa")}},
+			Attachment: &types.NetworkInterfaceAttachment{
+				InstanceId:       aws.String("i-12345678"),
+				DeviceIndex:      aws.Int32(1),
+				NetworkCardIndex: aws.Int32(1),
+			},
+		}}}, nil)
+	snapshot, err := manager.TakeSnapshot(context.Background(), "i-12345678")
+	if !assert.NoError(t, err) || !assert.Len(t, snapshot.ENIs, 1) {
+		return
+	}
+	assert.Empty(t, snapshot.ENIs[0].Addresses())
+
+	path := filepath.Join(t.TempDir(), "snapshot.yaml")
+	f, err := os.Create(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.NoError(t, WriteSnapshot(f, snapshot))
+	f.Close()
+	loaded, err := LoadSnapshot(path)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, snapshot.ENIs, loaded.ENIs)
+
+	mockClient.EXPECT().CreateNetworkInterface(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *ec2.CreateNetworkInterfaceInput, _ ...func(*ec2.Options)) (*ec2.CreateNetworkInterfaceOutput, error) {
+			assert.Equal(t, types.NetworkInterfaceCreationTypeEfaOnly, input.InterfaceType)
+			assert.Nil(t, input.PrivateIpAddress)
+			return &ec2.CreateNetworkInterfaceOutput{NetworkInterface: &types.NetworkInterface{
+				NetworkInterfaceId: aws.String("eni-87654321"),
+			}}, nil
+		})
+	mockClient.EXPECT().AttachNetworkInterface(gomock.Any(), gomock.Eq(&ec2.AttachNetworkInterfaceInput{
+		NetworkInterfaceId: aws.String("eni-87654321"),
+		InstanceId:         aws.String("i-12345678"),
+		DeviceIndex:        aws.Int32(1),
+		NetworkCardIndex:   aws.Int32(1),
+	})).Return(&ec2.AttachNetworkInterfaceOutput{AttachmentId: aws.String("eni-attach-12345678")}, nil)
+
+	results, err := manager.RestoreSnapshot(context.Background(), loaded)
+	assert.NoError(t, err)
+	assert.Equal(t, []RestoreResult{{
+		OriginalENIID: "eni-12345678",
+		ENIID:         "eni-87654321",
+		AttachmentID:  "eni-attach-12345678",
+	}}, results)
+}
@@ -0,0 +1,274 @@
+package ec2
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/netip"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// reservedSubnetIPs are the addresses AWS reserves in every subnet
+const reservedSubnetIPs = 5
+
+// ipv4PrefixSize is the number of addresses in an ENI's /28 IPv4 prefix
+const ipv4PrefixSize = 16
+
+// UntaggedValue is the ByTag key of ENIs without the breakdown tag
+const UntaggedValue = "(untagged)"
+
+// SubnetUsage is the IPv4 address usage of a subnet, broken down by the ENIs
+// that hold the addresses. Each breakdown counts addresses, with an IPv4
+// prefix counting as all 16 of its addresses.
+type SubnetUsage struct {
+	SubnetID         string `json:"subnet_id"`
+	CIDR             string `json:"cidr"`
+	AvailabilityZone string `json:"availability_zone"`
+	// Total excludes the 5 addresses AWS reserves
+	Total     int32 `json:"total"`
+	Available int32 `json:"available"`
+	// ENIs is the number of ENIs in the subnet
+	ENIs int `json:"enis"`
+	// ByOwner is keyed by the requester of ENIs AWS services created, such
+	// as ELB or Lambda, and by the owning account otherwise
+	ByOwner         map[string]int32 `json:"by_owner"`
+	ByInterfaceType map[string]int32 `json:"by_interface_type"`
+	// ByTag is keyed by the value of the tag the usage was broken down by
+	ByTag map[string]int32 `json:"by_tag,omitempty"`
+}
+
+// Used returns the addresses in use, including ones held by resources
+// other than ENIs
+func (u *SubnetUsage) Used() int32 {
+	return u.Total - u.Available
+}
+
+// UsedPercent returns Used as a percentage of Total
+func (u *SubnetUsage) UsedPercent() float64 {
+	if u.Total == 0 {
+		return 0
+	}
+	return 100 * float64(u.Used()) / float64(u.Total)
+}
+
+// SubnetUsage reports the address usage of a subnet from DescribeSubnet and
+// DescribeENIs. If tagKey is set, usage is also broken down by that tag.
+func (m *ENIManager) SubnetUsage(ctx context.Context, subnetID, tagKey string) (*SubnetUsage, error) {
+	subnets, err := m.DescribeSubnet(ctx, subnetID)
+	if err != nil {
+		return nil, err
+	}
+	if len(subnets.Subnets) == 0 {
+		return nil, fmt.Errorf("subnet %s not found", subnetID)
+	}
+	subnet := subnets.Subnets[0]
+
+	usage := &SubnetUsage{
+		SubnetID:         subnetID,
+		CIDR:             aws.ToString(subnet.CidrBlock),
+		AvailabilityZone: aws.ToString(subnet.AvailabilityZone),
+		Available:        aws.ToInt32(subnet.AvailableIpAddressCount),
+		ByOwner:          make(map[string]int32),
+		ByInterfaceType:  make(map[string]int32),
+	}
+	if prefix, err := netip.ParsePrefix(usage.CIDR); err == nil {
+		usage.Total = int32(1)<<(32-prefix.Bits()) - reservedSubnetIPs
+	}
+
+	enis, err := m.DescribeENIs(ctx, []types.Filter{
+		{Name: aws.String("subnet-id"), Values: []string{subnetID}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if tagKey != "" {
+		usage.ByTag = make(map[string]int32)
+	}
+	for _, eni := range enis.NetworkInterfaces {
+		addresses := int32(len(eni.PrivateIpAddresses) + ipv4PrefixSize*len(eni.Ipv4Prefixes))
+		if len(eni.PrivateIpAddresses) == 0 && eni.PrivateIpAddress != nil {
+			addresses++
+		}
+		usage.ENIs++
+
+		owner := aws.ToString(eni.RequesterId)
+		if owner == "" {
+			owner = aws.ToString(eni.OwnerId)
+		}
+		usage.ByOwner[owner] += addresses
+
+		interfaceType := string(eni.InterfaceType)
+		if interfaceType == "" {
+			interfaceType = string(types.NetworkInterfaceTypeInterface)
+		}
+		usage.ByInterfaceType[interfaceType] += addresses
+
+		if tagKey != "" {
+			value := UntaggedValue
+			for _, tag := range eni.TagSet {
+				if aws.ToString(tag.Key) == tagKey {
+					value = aws.ToString(tag.Value)
+				}
+			}
+			usage.ByTag[value] += addresses
+		}
+	}
+	return usage, nil
+}
+
+// SubnetSample is one line of the subnet history file
+type SubnetSample struct {
+	Time      time.Time `json:"time"`
+	SubnetID  string    `json:"subnet_id"`
+	Total     int32     `json:"total"`
+	Available int32     `json:"available"`
+}
+
+// Sample returns the usage as a history sample taken at t
+func (u *SubnetUsage) Sample(t time.Time) SubnetSample {
+	return SubnetSample{Time: t, SubnetID: u.SubnetID, Total: u.Total, Available: u.Available}
+}
+
+// AppendSubnetSamples appends samples to the history file at path, creating
+// it if needed
+func AppendSubnetSamples(path string, samples ...SubnetSample) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	for _, sample := range samples {
+		if err := enc.Encode(sample); err != nil {
+			return err
+		}
+	}
+	_, err = file.Write(buf.Bytes())
+	return err
+}
+
+// ReadSubnetSamples returns the samples of subnetID in r in file order
+func ReadSubnetSamples(r io.Reader, subnetID string) ([]SubnetSample, error) {
+	var samples []SubnetSample
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+		var sample SubnetSample
+		if err := json.Unmarshal(scanner.Bytes(), &sample); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if sample.SubnetID == subnetID {
+			samples = append(samples, sample)
+		}
+	}
+	return samples, scanner.Err()
+}
+
+// SubnetForecast projects when a subnet runs out of addresses
+type SubnetForecast struct {
+	Samples int `json:"samples"`
+	// RatePerHour is the addresses consumed per hour; negative when the
+	// subnet is freeing addresses
+	RatePerHour float64 `json:"rate_per_hour"`
+	// ExhaustedAt is when no addresses will be left at the current rate;
+	// zero if the subnet isn't filling up
+	ExhaustedAt time.Time `json:"exhausted_at,omitempty"`
+}
+
+// TimeLeft returns the time from now until ExhaustedAt, and false if the
+// subnet isn't filling up
+func (f SubnetForecast) TimeLeft(now time.Time) (time.Duration, bool) {
+	if f.ExhaustedAt.IsZero() {
+		return 0, false
+	}
+	return max(f.ExhaustedAt.Sub(now), 0), true
+}
+
+// ForecastExhaustion fits a least-squares line to the available address
+// counts of samples and extends it to zero. It needs at least two samples
+// taken at different times.
+func ForecastExhaustion(samples []SubnetSample) (SubnetForecast, error) {
+	forecast := SubnetForecast{Samples: len(samples)}
+	if len(samples) < 2 {
+		return forecast, fmt.Errorf("need at least 2 samples to forecast, have %d", len(samples))
+	}
+
+	// Hours since the first sample keep the sums well conditioned
+	origin := samples[0].Time
+	var sumX, sumY, sumXX, sumXY float64
+	for _, s := range samples {
+		x := s.Time.Sub(origin).Hours()
+		y := float64(s.Available)
+		sumX += x
+		sumY += y
+		sumXX += x * x
+		sumXY += x * y
+	}
+	n := float64(len(samples))
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return forecast, fmt.Errorf("samples were all taken at the same time")
+	}
+	slope := (n*sumXY - sumX*sumY) / denominator
+	forecast.RatePerHour = -slope
+
+	if slope < 0 {
+		// Extend from the latest sample rather than the fitted line, so the
+		// forecast starts from what is actually available now
+		last := samples[len(samples)-1]
+		for _, s := range samples {
+			if s.Time.After(last.Time) {
+				last = s
+			}
+		}
+		hours := float64(last.Available) / -slope
+		if hours < math.MaxInt64/float64(time.Hour) {
+			forecast.ExhaustedAt = last.Time.Add(time.Duration(hours * float64(time.Hour)))
+		}
+	}
+	return forecast, nil
+}
+
+// SubnetThresholds are the alert thresholds for a subnet. A zero threshold
+// is disabled.
+type SubnetThresholds struct {
+	// MaxUsedPercent alerts when more of the subnet than this is in use
+	MaxUsedPercent float64 `yaml:"max_used_percent,omitempty"`
+	// MinAvailable alerts when fewer addresses than this are left
+	MinAvailable int32 `yaml:"min_available,omitempty"`
+	// MinTimeLeft alerts when the forecast runs out sooner than this
+	MinTimeLeft time.Duration `yaml:"min_time_left,omitempty"`
+}
+
+// Alerts returns a message for every threshold usage or forecast crosses.
+// forecast may be nil if there isn't enough history.
+func (t SubnetThresholds) Alerts(usage *SubnetUsage, forecast *SubnetForecast, now time.Time) []string {
+	var alerts []string
+	if t.MaxUsedPercent > 0 && usage.UsedPercent() > t.MaxUsedPercent {
+		alerts = append(alerts, fmt.Sprintf("%.1f%% of %s in use, above %.1f%%",
+			usage.UsedPercent(), usage.SubnetID, t.MaxUsedPercent))
+	}
+	if t.MinAvailable > 0 && usage.Available < t.MinAvailable {
+		alerts = append(alerts, fmt.Sprintf("%d addresses left in %s, below %d",
+			usage.Available, usage.SubnetID, t.MinAvailable))
+	}
+	if t.MinTimeLeft > 0 && forecast != nil {
+		if left, ok := forecast.TimeLeft(now); ok && left < t.MinTimeLeft {
+			alerts = append(alerts, fmt.Sprintf("%s runs out of addresses in %s, sooner than %s",
+				usage.SubnetID, left.Round(time.Minute), t.MinTimeLeft))
+		}
+	}
+	return alerts
+}
@@ -0,0 +1,123 @@
+package ec2
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubnetUsage(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	mockClient.EXPECT().DescribeSubnets(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeSubnetsOutput{Subnets: []types.Subnet{{
+			CidrBlock:               aws.String("10.0.1.0/24"),
+			AvailableIpAddressCount: aws.Int32(200),
+		}}}, nil)
+	mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []types.NetworkInterface{
+			{
+				OwnerId:            aws.String("123456789012"),
+				PrivateIpAddresses: make([]types.NetworkInterfacePrivateIpAddress, 3),
+				Ipv4Prefixes:       make([]types.Ipv4PrefixSpecification, 2),
+				TagSet:             []types.Tag{{Key: aws.String("Team"), Value: aws.String("payments")}},
+			},
+			{
+				OwnerId:            aws.String("123456789012"),
+				RequesterId:        aws.String("amazon-elb"),
+				PrivateIpAddresses: make([]types.NetworkInterfacePrivateIpAddress, 1),
+			},
+			{
+				OwnerId:            aws.String("123456789012"),
+				InterfaceType:      types.NetworkInterfaceTypeBranch,
+				PrivateIpAddresses: make([]types.NetworkInterfacePrivateIpAddress, 2),
+				TagSet:             []types.Tag{{Key: aws.String("Team"), Value: aws.String("payments")}},
+			},
+		}}, nil)
+
+	usage, err := manager.SubnetUsage(context.Background(), "subnet-12345678", "Team")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(251), usage.Total)
+	assert.Equal(t, int32(51), usage.Used())
+	assert.Equal(t, 3, usage.ENIs)
+	assert.Equal(t, map[string]int32{"123456789012": 37, "amazon-elb": 1}, usage.ByOwner)
+	assert.Equal(t, map[string]int32{"interface": 36, "branch": 2}, usage.ByInterfaceType)
+	assert.Equal(t, map[string]int32{"payments": 37, UntaggedValue: 1}, usage.ByTag)
+}
+
+func TestSubnetSamples_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.NoError(t, AppendSubnetSamples(path,
+		SubnetSample{Time: start, SubnetID: "subnet-a", Total: 251, Available: 100},
+		SubnetSample{Time: start, SubnetID: "subnet-b", Total: 251, Available: 50},
+	))
+	assert.NoError(t, AppendSubnetSamples(path, SubnetSample{Time: start.Add(time.Hour), SubnetID: "subnet-a", Total: 251, Available: 90}))
+
+	f, err := os.Open(path)
+	if assert.NoError(t, err) {
+		defer f.Close()
+		samples, err := ReadSubnetSamples(f, "subnet-a")
+		assert.NoError(t, err)
+		if assert.Len(t, samples, 2) {
+			assert.Equal(t, int32(90), samples[1].Available)
+			assert.True(t, samples[1].Time.Equal(start.Add(time.Hour)))
+		}
+	}
+}
+
+func TestForecastExhaustion(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	sample := func(hours, available int) SubnetSample {
+		return SubnetSample{Time: start.Add(time.Duration(hours) * time.Hour), SubnetID: "subnet-a", Available: int32(available)}
+	}
+
+	forecast, err := ForecastExhaustion([]SubnetSample{sample(0, 100), sample(1, 90), sample(2, 80)})
+	assert.NoError(t, err)
+	assert.InDelta(t, 10, forecast.RatePerHour, 1e-9)
+	assert.Equal(t, start.Add(10*time.Hour), forecast.ExhaustedAt)
+	left, ok := forecast.TimeLeft(start.Add(4 * time.Hour))
+	assert.True(t, ok)
+	assert.Equal(t, 6*time.Hour, left)
+
+	forecast, err = ForecastExhaustion([]SubnetSample{sample(0, 80), sample(1, 90)})
+	assert.NoError(t, err)
+	assert.True(t, forecast.ExhaustedAt.IsZero())
+	_, ok = forecast.TimeLeft(start)
+	assert.False(t, ok)
+
+	_, err = ForecastExhaustion([]SubnetSample{sample(0, 80)})
+	assert.ErrorContains(t, err, "at least 2 samples")
+	_, err = ForecastExhaustion([]SubnetSample{sample(0, 80), sample(0, 70)})
+	assert.ErrorContains(t, err, "same time")
+}
+
+func TestSubnetThresholds_Alerts(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	usage := &SubnetUsage{SubnetID: "subnet-a", Total: 100, Available: 15}
+	forecast := &SubnetForecast{ExhaustedAt: now.Add(12 * time.Hour)}
+
+	thresholds := SubnetThresholds{MaxUsedPercent: 80, MinAvailable: 10, MinTimeLeft: 24 * time.Hour}
+	alerts := thresholds.Alerts(usage, forecast, now)
+	if assert.Len(t, alerts, 2) {
+		assert.Contains(t, alerts[0], "85.0% of subnet-a in use")
+		assert.Contains(t, alerts[1], "runs out of addresses in 12h0m0s")
+	}
+
+	assert.Empty(t, SubnetThresholds{}.Alerts(usage, forecast, now))
+	assert.Empty(t, thresholds.Alerts(&SubnetUsage{Total: 100, Available: 50}, nil, now))
+}
@@ -0,0 +1,291 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"reflect"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "eni-project/internal/ec2"
+
+// Span attribute keys set by ENIManager and TracingClient
+const (
+	AttrENIID           = attribute.Key("aws.ec2.network_interface_id")
+	AttrInstanceID      = attribute.Key("aws.ec2.instance_id")
+	AttrSubnetID        = attribute.Key("aws.ec2.subnet_id")
+	AttrAttachmentID    = attribute.Key("aws.ec2.attachment_id")
+	AttrSecurityGroupID = attribute.Key("aws.ec2.security_group_id")
+	AttrTrunkENIID      = attribute.Key("aws.ec2.trunk_interface_id")
+	AttrAssociationID   = attribute.Key("aws.ec2.association_id")
+	AttrRequestID       = attribute.Key("aws.request_id")
+	AttrErrorCode       = attribute.Key("aws.error_code")
+)
+
+// endSpan records err on span, if any, and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		span.SetAttributes(AttrErrorCode.String(ErrorCode(err)))
+	}
+	span.End()
+}
+
+// RequestID returns the AWS request ID of an EC2 API call from either its
+// output or its error, or "" if there is none.
+func RequestID(output any, err error) string {
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.ServiceRequestID()
+	}
+
+	// Every EC2 output struct carries ResultMetadata
+	v := reflect.ValueOf(output)
+	if !v.IsValid() || v.Kind() != reflect.Pointer || v.IsNil() {
+		return ""
+	}
+	field := v.Elem().FieldByName("ResultMetadata")
+	if !field.IsValid() {
+		return ""
+	}
+	metadata, ok := field.Interface().(middleware.Metadata)
+	if !ok {
+		return ""
+	}
+	requestID, _ := awsmiddleware.GetRequestIDMetadata(metadata)
+	return requestID
+}
+
+// TracingClient is an EC2ClientAPI that wraps every call in a client span
+// carrying the resource IDs involved and the AWS request ID.
+type TracingClient struct {
+	client EC2ClientAPI
+	tracer trace.Tracer
+}
+
+var _ EC2ClientAPI = (*TracingClient)(nil)
+
+// NewTracingClient wraps client; a nil provider uses the global one
+func NewTracingClient(client EC2ClientAPI, provider trace.TracerProvider) *TracingClient {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return &TracingClient{client: client, tracer: provider.Tracer(tracerName)}
+}
+
+func (c *TracingClient) start(ctx context.Context, operation string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		attribute.String("rpc.system", "aws-api"),
+		attribute.String("rpc.service", "EC2"),
+		attribute.String("rpc.method", operation),
+	)
+	return c.tracer.Start(ctx, "EC2."+operation, trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+}
+
+func (c *TracingClient) end(span trace.Span, output any, err error) {
+	if requestID := RequestID(output, err); requestID != "" {
+		span.SetAttributes(AttrRequestID.String(requestID))
+	}
+	endSpan(span, err)
+}
+
+func (c *TracingClient) CreateNetworkInterface(ctx context.Context, input *ec2.CreateNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.CreateNetworkInterfaceOutput, error) {
+	ctx, span := c.start(ctx, "CreateNetworkInterface", AttrSubnetID.String(aws.ToString(input.SubnetId)))
+	output, err := c.client.CreateNetworkInterface(ctx, input, opts...)
+	if err == nil && output.NetworkInterface != nil {
+		span.SetAttributes(AttrENIID.String(aws.ToString(output.NetworkInterface.NetworkInterfaceId)))
+	}
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) DescribeInstances(ctx context.Context, input *ec2.DescribeInstancesInput, opts ...func(*ec2.Options)) (*ec2.DescribeInstancesOutput, error) {
+	ctx, span := c.start(ctx, "DescribeInstances", AttrInstanceID.StringSlice(input.InstanceIds))
+	output, err := c.client.DescribeInstances(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) DescribeInstanceTypes(ctx context.Context, input *ec2.DescribeInstanceTypesInput, opts ...func(*ec2.Options)) (*ec2.DescribeInstanceTypesOutput, error) {
+	ctx, span := c.start(ctx, "DescribeInstanceTypes")
+	output, err := c.client.DescribeInstanceTypes(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) AttachNetworkInterface(ctx context.Context, input *ec2.AttachNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.AttachNetworkInterfaceOutput, error) {
+	ctx, span := c.start(ctx, "AttachNetworkInterface",
+		AttrENIID.String(aws.ToString(input.NetworkInterfaceId)),
+		AttrInstanceID.String(aws.ToString(input.InstanceId)))
+	output, err := c.client.AttachNetworkInterface(ctx, input, opts...)
+	if err == nil {
+		span.SetAttributes(AttrAttachmentID.String(aws.ToString(output.AttachmentId)))
+	}
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) DeleteNetworkInterface(ctx context.Context, input *ec2.DeleteNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DeleteNetworkInterfaceOutput, error) {
+	ctx, span := c.start(ctx, "DeleteNetworkInterface", AttrENIID.String(aws.ToString(input.NetworkInterfaceId)))
+	output, err := c.client.DeleteNetworkInterface(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) DetachNetworkInterface(ctx context.Context, input *ec2.DetachNetworkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DetachNetworkInterfaceOutput, error) {
+	ctx, span := c.start(ctx, "DetachNetworkInterface", AttrAttachmentID.String(aws.ToString(input.AttachmentId)))
+	output, err := c.client.DetachNetworkInterface(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) AssignPrivateIpAddresses(ctx context.Context, input *ec2.AssignPrivateIpAddressesInput, opts ...func(*ec2.Options)) (*ec2.AssignPrivateIpAddressesOutput, error) {
+	ctx, span := c.start(ctx, "AssignPrivateIpAddresses", AttrENIID.String(aws.ToString(input.NetworkInterfaceId)))
+	output, err := c.client.AssignPrivateIpAddresses(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) UnassignPrivateIpAddresses(ctx context.Context, input *ec2.UnassignPrivateIpAddressesInput, opts ...func(*ec2.Options)) (*ec2.UnassignPrivateIpAddressesOutput, error) {
+	ctx, span := c.start(ctx, "UnassignPrivateIpAddresses", AttrENIID.String(aws.ToString(input.NetworkInterfaceId)))
+	output, err := c.client.UnassignPrivateIpAddresses(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) AssignIpv6Addresses(ctx context.Context, input *ec2.AssignIpv6AddressesInput, opts ...func(*ec2.Options)) (*ec2.AssignIpv6AddressesOutput, error) {
+	ctx, span := c.start(ctx, "AssignIpv6Addresses", AttrENIID.String(aws.ToString(input.NetworkInterfaceId)))
+	output, err := c.client.AssignIpv6Addresses(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) UnassignIpv6Addresses(ctx context.Context, input *ec2.UnassignIpv6AddressesInput, opts ...func(*ec2.Options)) (*ec2.UnassignIpv6AddressesOutput, error) {
+	ctx, span := c.start(ctx, "UnassignIpv6Addresses", AttrENIID.String(aws.ToString(input.NetworkInterfaceId)))
+	output, err := c.client.UnassignIpv6Addresses(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) DescribeNetworkInterfaces(ctx context.Context, input *ec2.DescribeNetworkInterfacesInput, opts ...func(*ec2.Options)) (*ec2.DescribeNetworkInterfacesOutput, error) {
+	ctx, span := c.start(ctx, "DescribeNetworkInterfaces", AttrENIID.StringSlice(input.NetworkInterfaceIds))
+	output, err := c.client.DescribeNetworkInterfaces(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) ModifyNetworkInterfaceAttribute(ctx context.Context, input *ec2.ModifyNetworkInterfaceAttributeInput, opts ...func(*ec2.Options)) (*ec2.ModifyNetworkInterfaceAttributeOutput, error) {
+	ctx, span := c.start(ctx, "ModifyNetworkInterfaceAttribute", AttrENIID.String(aws.ToString(input.NetworkInterfaceId)))
+	output, err := c.client.ModifyNetworkInterfaceAttribute(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) CreateTags(ctx context.Context, input *ec2.CreateTagsInput, opts ...func(*ec2.Options)) (*ec2.CreateTagsOutput, error) {
+	ctx, span := c.start(ctx, "CreateTags", attribute.StringSlice("aws.ec2.resource_ids", input.Resources))
+	output, err := c.client.CreateTags(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) DeleteTags(ctx context.Context, input *ec2.DeleteTagsInput, opts ...func(*ec2.Options)) (*ec2.DeleteTagsOutput, error) {
+	ctx, span := c.start(ctx, "DeleteTags", attribute.StringSlice("aws.ec2.resource_ids", input.Resources))
+	output, err := c.client.DeleteTags(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) DescribeSubnets(ctx context.Context, input *ec2.DescribeSubnetsInput, opts ...func(*ec2.Options)) (*ec2.DescribeSubnetsOutput, error) {
+	ctx, span := c.start(ctx, "DescribeSubnets", AttrSubnetID.StringSlice(input.SubnetIds))
+	output, err := c.client.DescribeSubnets(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) CreateSecurityGroup(ctx context.Context, input *ec2.CreateSecurityGroupInput, opts ...func(*ec2.Options)) (*ec2.CreateSecurityGroupOutput, error) {
+	ctx, span := c.start(ctx, "CreateSecurityGroup", attribute.String("aws.ec2.vpc_id", aws.ToString(input.VpcId)))
+	output, err := c.client.CreateSecurityGroup(ctx, input, opts...)
+	if err == nil {
+		span.SetAttributes(AttrSecurityGroupID.String(aws.ToString(output.GroupId)))
+	}
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) DescribeSecurityGroups(ctx context.Context, input *ec2.DescribeSecurityGroupsInput, opts ...func(*ec2.Options)) (*ec2.DescribeSecurityGroupsOutput, error) {
+	ctx, span := c.start(ctx, "DescribeSecurityGroups", AttrSecurityGroupID.StringSlice(input.GroupIds))
+	output, err := c.client.DescribeSecurityGroups(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) AuthorizeSecurityGroupIngress(ctx context.Context, input *ec2.AuthorizeSecurityGroupIngressInput, opts ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupIngressOutput, error) {
+	ctx, span := c.start(ctx, "AuthorizeSecurityGroupIngress", AttrSecurityGroupID.String(aws.ToString(input.GroupId)))
+	output, err := c.client.AuthorizeSecurityGroupIngress(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) AuthorizeSecurityGroupEgress(ctx context.Context, input *ec2.AuthorizeSecurityGroupEgressInput, opts ...func(*ec2.Options)) (*ec2.AuthorizeSecurityGroupEgressOutput, error) {
+	ctx, span := c.start(ctx, "AuthorizeSecurityGroupEgress", AttrSecurityGroupID.String(aws.ToString(input.GroupId)))
+	output, err := c.client.AuthorizeSecurityGroupEgress(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) RevokeSecurityGroupIngress(ctx context.Context, input *ec2.RevokeSecurityGroupIngressInput, opts ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupIngressOutput, error) {
+	ctx, span := c.start(ctx, "RevokeSecurityGroupIngress", AttrSecurityGroupID.String(aws.ToString(input.GroupId)))
+	output, err := c.client.RevokeSecurityGroupIngress(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) RevokeSecurityGroupEgress(ctx context.Context, input *ec2.RevokeSecurityGroupEgressInput, opts ...func(*ec2.Options)) (*ec2.RevokeSecurityGroupEgressOutput, error) {
+	ctx, span := c.start(ctx, "RevokeSecurityGroupEgress", AttrSecurityGroupID.String(aws.ToString(input.GroupId)))
+	output, err := c.client.RevokeSecurityGroupEgress(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) DeleteSecurityGroup(ctx context.Context, input *ec2.DeleteSecurityGroupInput, opts ...func(*ec2.Options)) (*ec2.DeleteSecurityGroupOutput, error) {
+	ctx, span := c.start(ctx, "DeleteSecurityGroup", AttrSecurityGroupID.String(aws.ToString(input.GroupId)))
+	output, err := c.client.DeleteSecurityGroup(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) AssociateTrunkInterface(ctx context.Context, input *ec2.AssociateTrunkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.AssociateTrunkInterfaceOutput, error) {
+	ctx, span := c.start(ctx, "AssociateTrunkInterface",
+		AttrTrunkENIID.String(aws.ToString(input.TrunkInterfaceId)),
+		AttrENIID.String(aws.ToString(input.BranchInterfaceId)))
+	output, err := c.client.AssociateTrunkInterface(ctx, input, opts...)
+	if err == nil && output.InterfaceAssociation != nil {
+		span.SetAttributes(AttrAssociationID.String(aws.ToString(output.InterfaceAssociation.AssociationId)))
+	}
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) DisassociateTrunkInterface(ctx context.Context, input *ec2.DisassociateTrunkInterfaceInput, opts ...func(*ec2.Options)) (*ec2.DisassociateTrunkInterfaceOutput, error) {
+	ctx, span := c.start(ctx, "DisassociateTrunkInterface", AttrAssociationID.String(aws.ToString(input.AssociationId)))
+	output, err := c.client.DisassociateTrunkInterface(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
+
+func (c *TracingClient) DescribeTrunkInterfaceAssociations(ctx context.Context, input *ec2.DescribeTrunkInterfaceAssociationsInput, opts ...func(*ec2.Options)) (*ec2.DescribeTrunkInterfaceAssociationsOutput, error) {
+	ctx, span := c.start(ctx, "DescribeTrunkInterfaceAssociations", AttrAssociationID.StringSlice(input.AssociationIds))
+	output, err := c.client.DescribeTrunkInterfaceAssociations(ctx, input, opts...)
+	c.end(span, output, err)
+	return output, err
+}
@@ -0,0 +1,90 @@
+// internal/ec2/tracing_test.go
+package ec2
+
+import (
+	"context"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/smithy-go"
+	"github.com/aws/smithy-go/middleware"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func newTracedManager(t *testing.T) (*mocks.MockEC2ClientAPI, *ENIManager, *tracetest.InMemoryExporter) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(NewTracingClient(mockClient, provider), WithTracerProvider(provider))
+	return mockClient, manager, exporter
+}
+
+func spanAttr(span tracetest.SpanStub, key attribute.Key) string {
+	for _, kv := range span.Attributes {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestTracing_AttachENI(t *testing.T) {
+	mockClient, manager, exporter := newTracedManager(t)
+
+	var metadata middleware.Metadata
+	awsmiddleware.SetRequestIDMetadata(&metadata, "req-12345678")
+
+	mockClient.EXPECT().
+		AttachNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(&ec2.AttachNetworkInterfaceOutput{
+			AttachmentId:   aws.String("eni-attach-12345678"),
+			ResultMetadata: metadata,
+		}, nil)
+
+	_, err := manager.AttachENI(context.Background(), "eni-12345678", "i-12345678", 1)
+	assert.NoError(t, err)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+
+	// The EC2 call ends first and is a child of the ENIManager operation
+	call, op := spans[0], spans[1]
+	assert.Equal(t, "EC2.AttachNetworkInterface", call.Name)
+	assert.Equal(t, "ENIManager.AttachENI", op.Name)
+	assert.Equal(t, op.SpanContext.SpanID(), call.Parent.SpanID())
+
+	assert.Equal(t, "req-12345678", spanAttr(call, AttrRequestID))
+	assert.Equal(t, "eni-12345678", spanAttr(call, AttrENIID))
+	assert.Equal(t, "i-12345678", spanAttr(op, AttrInstanceID))
+	assert.Equal(t, "eni-attach-12345678", spanAttr(op, AttrAttachmentID))
+}
+
+func TestTracing_Error(t *testing.T) {
+	mockClient, manager, exporter := newTracedManager(t)
+
+	mockClient.EXPECT().
+		DeleteNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(nil, &smithy.GenericAPIError{Code: "InvalidNetworkInterfaceID.NotFound"})
+
+	err := manager.DeleteENI(context.Background(), "eni-12345678")
+	assert.Error(t, err)
+
+	spans := exporter.GetSpans()
+	assert.Len(t, spans, 2)
+	for _, span := range spans {
+		assert.Equal(t, codes.Error, span.Status.Code)
+		assert.Equal(t, "InvalidNetworkInterfaceID.NotFound", spanAttr(span, AttrErrorCode))
+	}
+}
@@ -0,0 +1,239 @@
+package ec2
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// DefaultBranchLimits are the branch interfaces a trunk ENI supports on
+// common trunking-capable instance types, as published for EKS security
+// groups for pods. Other types can be added with WithBranchLimits.
+var DefaultBranchLimits = map[string]int32{
+	"c5.large": 9, "c5.xlarge": 18, "c5.2xlarge": 38, "c5.4xlarge": 54,
+	"c5.9xlarge": 54, "c5.12xlarge": 54, "c5.18xlarge": 107, "c5.24xlarge": 107,
+	"m5.large": 9, "m5.xlarge": 18, "m5.2xlarge": 38, "m5.4xlarge": 54,
+	"m5.8xlarge": 54, "m5.12xlarge": 54, "m5.16xlarge": 107, "m5.24xlarge": 107,
+	"r5.large": 9, "r5.xlarge": 18, "r5.2xlarge": 38, "r5.4xlarge": 54,
+	"r5.8xlarge": 54, "r5.12xlarge": 54, "r5.16xlarge": 107, "r5.24xlarge": 107,
+}
+
+// WithBranchLimits sets the branch interface limit of instance types missing
+// from DefaultBranchLimits, or overrides it
+func WithBranchLimits(limits map[string]int32) Option {
+	return func(m *ENIManager) {
+		if m.branchLimits == nil {
+			m.branchLimits = make(map[string]int32)
+		}
+		for instanceType, limit := range limits {
+			m.branchLimits[instanceType] = limit
+		}
+	}
+}
+
+func (m *ENIManager) branchLimit(instanceType string) (int32, bool) {
+	if limit, ok := m.branchLimits[instanceType]; ok {
+		return limit, true
+	}
+	limit, ok := DefaultBranchLimits[instanceType]
+	return limit, ok
+}
+
+// ValidateVLAN checks that id is a usable 802.1Q VLAN ID
+func ValidateVLAN(id int32) error {
+	if id < 1 || id > 4094 {
+		return fmt.Errorf("VLAN ID %d must be between 1 and 4094", id)
+	}
+	return nil
+}
+
+// CodeBranchLimitExceeded is the ErrorCode of a BranchCapacityError
+const CodeBranchLimitExceeded = "BranchLimitExceeded"
+
+// BranchCapacityError is returned when a trunk ENI has no room for another
+// branch, or the requested VLAN is already in use on it
+type BranchCapacityError struct {
+	TrunkENIID   string
+	InstanceType string
+	Limit        int32
+	Used         int32
+	// VLANInUse is set when the VLAN, not the count, is the problem
+	VLANInUse int32
+}
+
+func (e *BranchCapacityError) Error() string {
+	if e.VLANInUse != 0 {
+		return fmt.Sprintf("trunk %s already has a branch on VLAN %d", e.TrunkENIID, e.VLANInUse)
+	}
+	return fmt.Sprintf("trunk %s is full: %s supports %d branch interfaces and %d are in use",
+		e.TrunkENIID, e.InstanceType, e.Limit, e.Used)
+}
+
+// TrunkCapacity is the branch capacity of one trunk ENI
+type TrunkCapacity struct {
+	TrunkENIID   string
+	InstanceID   string
+	InstanceType string
+	Limit        int32
+	Used         int32
+	// VLANs are the VLAN IDs in use, ascending
+	VLANs []int32
+}
+
+// TrunkCapacity reports how many branches a trunk ENI has and can have. The
+// trunk must be attached, since the limit depends on the instance type.
+func (m *ENIManager) TrunkCapacity(ctx context.Context, trunkENIID string) (*TrunkCapacity, error) {
+	enis, err := m.DescribeENIs(ctx, []types.Filter{
+		{Name: aws.String("network-interface-id"), Values: []string{trunkENIID}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(enis.NetworkInterfaces) == 0 {
+		return nil, fmt.Errorf("trunk ENI %s not found", trunkENIID)
+	}
+	trunk := enis.NetworkInterfaces[0]
+	if trunk.InterfaceType != types.NetworkInterfaceTypeTrunk {
+		return nil, fmt.Errorf("ENI %s is not a trunk interface", trunkENIID)
+	}
+	if trunk.Attachment == nil || trunk.Attachment.InstanceId == nil {
+		return nil, fmt.Errorf("trunk ENI %s is not attached to an instance", trunkENIID)
+	}
+
+	capacity := &TrunkCapacity{TrunkENIID: trunkENIID, InstanceID: aws.ToString(trunk.Attachment.InstanceId)}
+	instance, err := m.DescribeInstance(ctx, capacity.InstanceID)
+	if err != nil {
+		return nil, err
+	}
+	capacity.InstanceType = string(instance.InstanceType)
+	limit, ok := m.branchLimit(capacity.InstanceType)
+	if !ok {
+		return nil, fmt.Errorf("branch interface limit of instance type %q is unknown", capacity.InstanceType)
+	}
+	capacity.Limit = limit
+
+	associations, err := m.DescribeTrunkAssociations(ctx, trunkENIID)
+	if err != nil {
+		return nil, err
+	}
+	for _, association := range associations {
+		capacity.Used++
+		if association.VlanId != nil {
+			capacity.VLANs = append(capacity.VLANs, *association.VlanId)
+		}
+	}
+	sort.Slice(capacity.VLANs, func(i, j int) bool { return capacity.VLANs[i] < capacity.VLANs[j] })
+	return capacity, nil
+}
+
+// AssociateBranchENI associates a branch ENI with a trunk ENI on vlanID and
+// returns the association ID. The association is refused if the trunk is at
+// its instance type's branch limit or already uses the VLAN; offline dry
+// runs can't see the trunk, so they skip the capacity check.
+func (m *ENIManager) AssociateBranchENI(ctx context.Context, trunkENIID, branchENIID string, vlanID int32) (_ string, err error) {
+	ctx, op := m.begin(ctx, "AssociateBranchENI", AttrTrunkENIID.String(trunkENIID), AttrENIID.String(branchENIID))
+	defer op.end(&err)
+	if err = ValidateVLAN(vlanID); err != nil {
+		return "", err
+	}
+
+	if m.dryRun != DryRunOffline {
+		capacity, err := m.TrunkCapacity(ctx, trunkENIID)
+		if err != nil {
+			return "", err
+		}
+		op.set(AttrInstanceID.String(capacity.InstanceID))
+		capErr := &BranchCapacityError{
+			TrunkENIID:   trunkENIID,
+			InstanceType: capacity.InstanceType,
+			Limit:        capacity.Limit,
+			Used:         capacity.Used,
+		}
+		if capacity.Used >= capacity.Limit {
+			return "", capErr
+		}
+		for _, vlan := range capacity.VLANs {
+			if vlan == vlanID {
+				capErr.VLANInUse = vlanID
+				return "", capErr
+			}
+		}
+	}
+	if err = op.before(); err != nil {
+		return "", err
+	}
+
+	input := &ec2.AssociateTrunkInterfaceInput{
+		TrunkInterfaceId:  aws.String(trunkENIID),
+		BranchInterfaceId: aws.String(branchENIID),
+		VlanId:            aws.Int32(vlanID),
+	}
+
+	output, err := m.client.AssociateTrunkInterface(ctx, input)
+	op.record(input, output)
+	if err != nil {
+		return "", fmt.Errorf("failed to associate branch ENI: %w", err)
+	}
+
+	associationID := ""
+	if output.InterfaceAssociation != nil {
+		associationID = aws.ToString(output.InterfaceAssociation.AssociationId)
+		op.set(AttrAssociationID.String(associationID))
+	}
+	return associationID, nil
+}
+
+func (m *ENIManager) DisassociateBranchENI(ctx context.Context, associationID string) (err error) {
+	ctx, op := m.begin(ctx, "DisassociateBranchENI", AttrAssociationID.String(associationID))
+	defer op.end(&err)
+	if err = op.before(); err != nil {
+		return err
+	}
+
+	input := &ec2.DisassociateTrunkInterfaceInput{
+		AssociationId: aws.String(associationID),
+	}
+
+	output, err := m.client.DisassociateTrunkInterface(ctx, input)
+	op.record(input, output)
+	if err != nil {
+		return fmt.Errorf("failed to disassociate branch ENI: %w", err)
+	}
+
+	return nil
+}
+
+// DescribeTrunkAssociations returns the branch associations of the given
+// trunk ENIs, or of every trunk if none are given. EC2 can't filter
+// associations by trunk, so all pages are read and filtered here.
+func (m *ENIManager) DescribeTrunkAssociations(ctx context.Context, trunkENIIDs ...string) (_ []types.TrunkInterfaceAssociation, err error) {
+	ctx, op := m.begin(ctx, "DescribeTrunkAssociations")
+	defer op.end(&err)
+
+	wanted := make(map[string]bool, len(trunkENIIDs))
+	for _, id := range trunkENIIDs {
+		wanted[id] = true
+	}
+
+	var associations []types.TrunkInterfaceAssociation
+	input := &ec2.DescribeTrunkInterfaceAssociationsInput{}
+	for {
+		output, err := m.client.DescribeTrunkInterfaceAssociations(ctx, input)
+		if err != nil {
+			return nil, err
+		}
+		for _, association := range output.InterfaceAssociations {
+			if len(wanted) == 0 || wanted[aws.ToString(association.TrunkInterfaceId)] {
+				associations = append(associations, association)
+			}
+		}
+		if aws.ToString(output.NextToken) == "" {
+			return associations, nil
+		}
+		input.NextToken = output.NextToken
+	}
+}
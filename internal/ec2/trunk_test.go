@@ -0,0 +1,122 @@
+package ec2
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// expectTrunk sets up a trunk ENI attached to an instance of instanceType
+// with branches on the given VLANs, split over two pages alongside a branch
+// of another trunk
+func expectTrunk(mockClient *mocks.MockEC2ClientAPI, instanceType string, vlans ...int32) {
+	mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []types.NetworkInterface{{
+			NetworkInterfaceId: aws.String("eni-trunk"),
+			InterfaceType:      types.NetworkInterfaceTypeTrunk,
+			Attachment:         &types.NetworkInterfaceAttachment{InstanceId: aws.String("i-12345678")},
+		}}}, nil)
+	mockClient.EXPECT().DescribeInstances(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeInstancesOutput{Reservations: []types.Reservation{{
+			Instances: []types.Instance{{InstanceType: types.InstanceType(instanceType)}},
+		}}}, nil)
+
+	var associations []types.TrunkInterfaceAssociation
+	for _, vlan := range vlans {
+		associations = append(associations, types.TrunkInterfaceAssociation{
+			TrunkInterfaceId: aws.String("eni-trunk"),
+			VlanId:           aws.Int32(vlan),
+		})
+	}
+	first := &ec2.DescribeTrunkInterfaceAssociationsOutput{
+		InterfaceAssociations: []types.TrunkInterfaceAssociation{
+			{TrunkInterfaceId: aws.String("eni-other"), VlanId: aws.Int32(100)},
+		},
+		NextToken: aws.String("page-2"),
+	}
+	gomock.InOrder(
+		mockClient.EXPECT().DescribeTrunkInterfaceAssociations(gomock.Any(), gomock.Any()).Return(first, nil),
+		mockClient.EXPECT().DescribeTrunkInterfaceAssociations(gomock.Any(), gomock.Any()).
+			Return(&ec2.DescribeTrunkInterfaceAssociationsOutput{InterfaceAssociations: associations}, nil),
+	)
+}
+
+func TestAssociateBranchENI(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	expectTrunk(mockClient, "m5.large", 10, 11)
+	mockClient.EXPECT().AssociateTrunkInterface(gomock.Any(), gomock.Eq(&ec2.AssociateTrunkInterfaceInput{
+		TrunkInterfaceId:  aws.String("eni-trunk"),
+		BranchInterfaceId: aws.String("eni-branch"),
+		VlanId:            aws.Int32(12),
+	})).Return(&ec2.AssociateTrunkInterfaceOutput{
+		InterfaceAssociation: &types.TrunkInterfaceAssociation{AssociationId: aws.String("trunk-assoc-12345678")},
+	}, nil)
+
+	associationID, err := manager.AssociateBranchENI(context.Background(), "eni-trunk", "eni-branch", 12)
+	assert.NoError(t, err)
+	assert.Equal(t, "trunk-assoc-12345678", associationID)
+}
+
+func TestAssociateBranchENI_TrunkFull(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient, WithBranchLimits(map[string]int32{"m6i.large": 2}))
+
+	expectTrunk(mockClient, "m6i.large", 10, 11)
+
+	_, err := manager.AssociateBranchENI(context.Background(), "eni-trunk", "eni-branch", 12)
+	var capErr *BranchCapacityError
+	if assert.True(t, errors.As(err, &capErr)) {
+		assert.Equal(t, int32(2), capErr.Limit)
+		assert.Equal(t, int32(2), capErr.Used)
+	}
+	assert.Equal(t, CodeBranchLimitExceeded, ErrorCode(err))
+}
+
+func TestAssociateBranchENI_VLANInUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	expectTrunk(mockClient, "c5.xlarge", 10)
+
+	_, err := manager.AssociateBranchENI(context.Background(), "eni-trunk", "eni-branch", 10)
+	assert.ErrorContains(t, err, "already has a branch on VLAN 10")
+}
+
+func TestAssociateBranchENI_UnknownInstanceType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	mockClient.EXPECT().DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []types.NetworkInterface{{
+			InterfaceType: types.NetworkInterfaceTypeTrunk,
+			Attachment:    &types.NetworkInterfaceAttachment{InstanceId: aws.String("i-12345678")},
+		}}}, nil)
+	mockClient.EXPECT().DescribeInstances(gomock.Any(), gomock.Any()).
+		Return(&ec2.DescribeInstancesOutput{Reservations: []types.Reservation{{
+			Instances: []types.Instance{{InstanceType: "t3.micro"}},
+		}}}, nil)
+
+	_, err := manager.AssociateBranchENI(context.Background(), "eni-trunk", "eni-branch", 10)
+	assert.ErrorContains(t, err, `instance type "t3.micro" is unknown`)
+}
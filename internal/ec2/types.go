@@ -1,13 +1,30 @@
 package ec2
 
+import (
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// ManagedByTagKey and ManagedByTagValue mark ENIs created and owned by this tool
+const (
+	ManagedByTagKey   = "ManagedBy"
+	ManagedByTagValue = "eni-manager"
+)
+
 // ENIConfig represents configuration for creating a network interface
 type ENIConfig struct {
 	SubnetID         string
 	Description      string
 	SecurityGroupIDs []string
+	// PrivateIPAddress requests a specific primary private IP; empty lets
+	// EC2 pick one from the subnet
+	PrivateIPAddress string
 	PrivateIPCount   int32
 	IPv6AddressCount int32
 	Tags             map[string]string
+	// InterfaceType creates a special-purpose ENI, e.g. a trunk or branch
+	// interface for VLAN-based pod networking; empty for a standard ENI
+	InterfaceType types.NetworkInterfaceCreationType
 }
 
 // ENIModifyConfig represents configuration for modifying a network interface
@@ -15,3 +32,13 @@ type ENIModifyConfig struct {
 	Description      *string
 	SecurityGroupIDs []string
 }
+
+// ManagedENIFilters returns the DescribeENIs filters that select managed ENIs
+func ManagedENIFilters() []types.Filter {
+	return []types.Filter{
+		{
+			Name:   aws.String("tag:" + ManagedByTagKey),
+			Values: []string{ManagedByTagValue},
+		},
+	}
+}
@@ -0,0 +1,77 @@
+package ec2
+
+import (
+	"fmt"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+// ValidateID checks that id is set and carries the EC2 resource prefix,
+// e.g. "eni-" or "subnet-"
+func ValidateID(field, id, prefix string) error {
+	if id == "" {
+		return fmt.Errorf("%s is required", field)
+	}
+	if !strings.HasPrefix(id, prefix) {
+		return fmt.Errorf("%s %q must start with %q", field, id, prefix)
+	}
+	return nil
+}
+
+// ValidateIDs applies ValidateID to every element of ids
+func ValidateIDs(field string, ids []string, prefix string) error {
+	for _, id := range ids {
+		if err := ValidateID(field, id, prefix); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ValidateAddresses checks that every address parses and is of the expected family
+func ValidateAddresses(addresses []string, ipv6 bool) error {
+	for _, address := range addresses {
+		addr, err := netip.ParseAddr(address)
+		if err != nil {
+			return fmt.Errorf("invalid address %q", address)
+		}
+		if addr.Is6() != ipv6 {
+			if ipv6 {
+				return fmt.Errorf("%q is not an IPv6 address", address)
+			}
+			return fmt.Errorf("%q is not an IPv4 address", address)
+		}
+	}
+	return nil
+}
+
+// Validate checks config for mistakes EC2 would reject anyway
+func (c ENIConfig) Validate() error {
+	if err := ValidateID("subnet_id", c.SubnetID, "subnet-"); err != nil {
+		return err
+	}
+	if err := ValidateIDs("security_group_ids", c.SecurityGroupIDs, "sg-"); err != nil {
+		return err
+	}
+	if c.PrivateIPAddress != "" {
+		if err := ValidateAddresses([]string{c.PrivateIPAddress}, false); err != nil {
+			return fmt.Errorf("private_ip_address: %w", err)
+		}
+	}
+	if c.PrivateIPCount < 0 || c.IPv6AddressCount < 0 {
+		return fmt.Errorf("private_ip_count and ipv6_address_count must not be negative")
+	}
+	if c.InterfaceType != "" && !slices.Contains(c.InterfaceType.Values(), c.InterfaceType) {
+		return fmt.Errorf("invalid interface_type %q", c.InterfaceType)
+	}
+	return nil
+}
+
+// Validate checks that config changes something
+func (c ENIModifyConfig) Validate() error {
+	if c.Description == nil && len(c.SecurityGroupIDs) == 0 {
+		return fmt.Errorf("at least one of description or security_group_ids is required")
+	}
+	return ValidateIDs("security_group_ids", c.SecurityGroupIDs, "sg-")
+}
@@ -0,0 +1,264 @@
+package ec2
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// EventType identifies the kind of change a watch Event reports
+type EventType string
+
+const (
+	// EventSync reports the full current state of an ENI, on the first poll
+	// and on every resync, so consumers can reconcile without diffing
+	EventSync                  EventType = "Sync"
+	EventCreated               EventType = "Created"
+	EventDeleted               EventType = "Deleted"
+	EventAttached              EventType = "Attached"
+	EventDetached              EventType = "Detached"
+	EventStatusChanged         EventType = "StatusChanged"
+	EventIPsAdded              EventType = "IPsAdded"
+	EventIPsRemoved            EventType = "IPsRemoved"
+	EventTagsChanged           EventType = "TagsChanged"
+	EventSecurityGroupsChanged EventType = "SecurityGroupsChanged"
+)
+
+// Event is a single change observed by Watch. ENI is the latest known state
+// (the last seen state for Deleted) and Previous the state before the change.
+// Added and Removed list the IPs, "key=value" tags or security group IDs
+// involved in the IP, tag and security group events.
+type Event struct {
+	Type     EventType
+	ENI      types.NetworkInterface
+	Previous *types.NetworkInterface
+	Added    []string
+	Removed  []string
+	Time     time.Time
+}
+
+// WatchConfig represents configuration for Watch
+type WatchConfig struct {
+	// Interval between polls of DescribeNetworkInterfaces
+	Interval time.Duration
+	// ResyncInterval, if set, re-emits a Sync event for every ENI this often
+	ResyncInterval time.Duration
+	// BufferSize is the capacity of the event channel
+	BufferSize int
+}
+
+// DefaultWatchConfig polls every 30 seconds and resyncs every 10 minutes
+func DefaultWatchConfig() WatchConfig {
+	return WatchConfig{
+		Interval:       30 * time.Second,
+		ResyncInterval: 10 * time.Minute,
+		BufferSize:     100,
+	}
+}
+
+// Watch polls the ENIs matching filters and emits an Event for every change
+// between successive snapshots. The channel is closed when ctx is done.
+//
+// A slow consumer applies backpressure: polling pauses while the channel is
+// full, and changes that happen meanwhile are coalesced into the next diff
+// rather than dropped. Failed polls are logged and retried on the next tick
+// against the last good snapshot.
+func (m *ENIManager) Watch(ctx context.Context, filters []types.Filter, config WatchConfig) <-chan Event {
+	if config.Interval <= 0 {
+		config.Interval = DefaultWatchConfig().Interval
+	}
+	events := make(chan Event, config.BufferSize)
+
+	go func() {
+		defer close(events)
+
+		ticker := time.NewTicker(config.Interval)
+		defer ticker.Stop()
+
+		var known map[string]types.NetworkInterface
+		var lastSync time.Time
+		for {
+			output, err := m.DescribeENIs(ctx, filters)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				if m.logger != nil {
+					m.logger.WarnContext(ctx, "watch poll failed", "error_code", ErrorCode(err), "error", err)
+				}
+			} else {
+				now := time.Now()
+				current := make(map[string]types.NetworkInterface, len(output.NetworkInterfaces))
+				for _, eni := range output.NetworkInterfaces {
+					current[aws.ToString(eni.NetworkInterfaceId)] = eni
+				}
+
+				var batch []Event
+				if known == nil || (config.ResyncInterval > 0 && now.Sub(lastSync) >= config.ResyncInterval) {
+					batch = append(batch, diffSnapshots(known, current, now)...)
+					batch = append(batch, syncEvents(current, now)...)
+					lastSync = now
+				} else {
+					batch = diffSnapshots(known, current, now)
+				}
+				known = current
+
+				for _, event := range batch {
+					select {
+					case events <- event:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return events
+}
+
+func syncEvents(current map[string]types.NetworkInterface, now time.Time) []Event {
+	var events []Event
+	for _, id := range sortedKeys(current) {
+		events = append(events, Event{Type: EventSync, ENI: current[id], Time: now})
+	}
+	return events
+}
+
+// diffSnapshots returns the events that turn known into current. A nil known
+// means there is no baseline yet, so nothing has changed.
+func diffSnapshots(known, current map[string]types.NetworkInterface, now time.Time) []Event {
+	if known == nil {
+		return nil
+	}
+
+	var events []Event
+	for _, id := range sortedKeys(current) {
+		eni := current[id]
+		prev, ok := known[id]
+		if !ok {
+			events = append(events, Event{Type: EventCreated, ENI: eni, Time: now})
+			continue
+		}
+		events = append(events, diffENI(prev, eni, now)...)
+	}
+	for _, id := range sortedKeys(known) {
+		if _, ok := current[id]; !ok {
+			events = append(events, Event{Type: EventDeleted, ENI: known[id], Time: now})
+		}
+	}
+	return events
+}
+
+func diffENI(prev, cur types.NetworkInterface, now time.Time) []Event {
+	var events []Event
+	emit := func(t EventType, added, removed []string) {
+		p := prev
+		events = append(events, Event{Type: t, ENI: cur, Previous: &p, Added: added, Removed: removed, Time: now})
+	}
+
+	prevAttachment, curAttachment := attachmentID(prev), attachmentID(cur)
+	if prevAttachment != curAttachment {
+		if prevAttachment != "" {
+			emit(EventDetached, nil, nil)
+		}
+		if curAttachment != "" {
+			emit(EventAttached, nil, nil)
+		}
+	}
+
+	if prev.Status != cur.Status {
+		emit(EventStatusChanged, nil, nil)
+	}
+
+	added, removed := diffSets(eniIPs(prev), eniIPs(cur))
+	if len(added) > 0 {
+		emit(EventIPsAdded, added, nil)
+	}
+	if len(removed) > 0 {
+		emit(EventIPsRemoved, nil, removed)
+	}
+
+	added, removed = diffSets(eniTags(prev), eniTags(cur))
+	if len(added) > 0 || len(removed) > 0 {
+		emit(EventTagsChanged, added, removed)
+	}
+
+	added, removed = diffSets(eniGroups(prev), eniGroups(cur))
+	if len(added) > 0 || len(removed) > 0 {
+		emit(EventSecurityGroupsChanged, added, removed)
+	}
+
+	return events
+}
+
+// attachmentID returns the ENI's attachment ID, or "" once it is detached
+func attachmentID(eni types.NetworkInterface) string {
+	if eni.Attachment == nil || eni.Attachment.Status == types.AttachmentStatusDetached {
+		return ""
+	}
+	return aws.ToString(eni.Attachment.AttachmentId)
+}
+
+func eniIPs(eni types.NetworkInterface) []string {
+	var ips []string
+	for _, ip := range eni.PrivateIpAddresses {
+		ips = append(ips, aws.ToString(ip.PrivateIpAddress))
+	}
+	for _, ip := range eni.Ipv6Addresses {
+		ips = append(ips, aws.ToString(ip.Ipv6Address))
+	}
+	return ips
+}
+
+func eniTags(eni types.NetworkInterface) []string {
+	var tags []string
+	for _, tag := range eni.TagSet {
+		tags = append(tags, aws.ToString(tag.Key)+"="+aws.ToString(tag.Value))
+	}
+	return tags
+}
+
+func eniGroups(eni types.NetworkInterface) []string {
+	var groups []string
+	for _, group := range eni.Groups {
+		groups = append(groups, aws.ToString(group.GroupId))
+	}
+	return groups
+}
+
+// diffSets returns the sorted elements only in cur and only in prev
+func diffSets(prev, cur []string) (added, removed []string) {
+	for _, v := range cur {
+		if !slices.Contains(prev, v) {
+			added = append(added, v)
+		}
+	}
+	for _, v := range prev {
+		if !slices.Contains(cur, v) {
+			removed = append(removed, v)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+func sortedKeys(m map[string]types.NetworkInterface) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
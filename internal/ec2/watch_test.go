@@ -0,0 +1,135 @@
+// internal/ec2/watch_test.go
+package ec2
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"eni-project/internal/ec2/mocks"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	prev := map[string]types.NetworkInterface{
+		"eni-1": {
+			NetworkInterfaceId: aws.String("eni-1"),
+			Status:             types.NetworkInterfaceStatusAvailable,
+			PrivateIpAddresses: []types.NetworkInterfacePrivateIpAddress{
+				{PrivateIpAddress: aws.String("10.0.0.10")},
+				{PrivateIpAddress: aws.String("10.0.0.11")},
+			},
+			TagSet: []types.Tag{{Key: aws.String("Name"), Value: aws.String("old")}},
+			Groups: []types.GroupIdentifier{{GroupId: aws.String("sg-1")}},
+		},
+		"eni-gone": {NetworkInterfaceId: aws.String("eni-gone")},
+	}
+	cur := map[string]types.NetworkInterface{
+		"eni-1": {
+			NetworkInterfaceId: aws.String("eni-1"),
+			Status:             types.NetworkInterfaceStatusInUse,
+			Attachment: &types.NetworkInterfaceAttachment{
+				AttachmentId: aws.String("eni-attach-1"),
+				Status:       types.AttachmentStatusAttached,
+			},
+			PrivateIpAddresses: []types.NetworkInterfacePrivateIpAddress{
+				{PrivateIpAddress: aws.String("10.0.0.10")},
+				{PrivateIpAddress: aws.String("10.0.0.12")},
+			},
+			Ipv6Addresses: []types.NetworkInterfaceIpv6Address{{Ipv6Address: aws.String("2600:1f14::1")}},
+			TagSet:        []types.Tag{{Key: aws.String("Name"), Value: aws.String("new")}},
+			Groups:        []types.GroupIdentifier{{GroupId: aws.String("sg-2")}},
+		},
+		"eni-new": {NetworkInterfaceId: aws.String("eni-new")},
+	}
+
+	events := diffSnapshots(prev, cur, time.Now())
+
+	var got []EventType
+	for _, e := range events {
+		got = append(got, e.Type)
+	}
+	assert.Equal(t, []EventType{
+		EventAttached,
+		EventStatusChanged,
+		EventIPsAdded,
+		EventIPsRemoved,
+		EventTagsChanged,
+		EventSecurityGroupsChanged,
+		EventCreated,
+		EventDeleted,
+	}, got)
+
+	assert.Equal(t, []string{"10.0.0.12", "2600:1f14::1"}, events[2].Added)
+	assert.Equal(t, []string{"10.0.0.11"}, events[3].Removed)
+	assert.Equal(t, []string{"Name=new"}, events[4].Added)
+	assert.Equal(t, []string{"Name=old"}, events[4].Removed)
+	assert.Equal(t, types.NetworkInterfaceStatusAvailable, events[1].Previous.Status)
+	assert.Equal(t, "eni-new", *events[6].ENI.NetworkInterfaceId)
+	assert.Equal(t, "eni-gone", *events[7].ENI.NetworkInterfaceId)
+}
+
+func TestDiffSnapshots_Detached(t *testing.T) {
+	attached := types.NetworkInterface{
+		NetworkInterfaceId: aws.String("eni-1"),
+		Attachment: &types.NetworkInterfaceAttachment{
+			AttachmentId: aws.String("eni-attach-1"),
+			Status:       types.AttachmentStatusAttached,
+		},
+	}
+	detached := types.NetworkInterface{NetworkInterfaceId: aws.String("eni-1")}
+
+	events := diffSnapshots(
+		map[string]types.NetworkInterface{"eni-1": attached},
+		map[string]types.NetworkInterface{"eni-1": detached},
+		time.Now())
+	assert.Len(t, events, 1)
+	assert.Equal(t, EventDetached, events[0].Type)
+}
+
+func TestENIManager_Watch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	manager := NewENIManager(mockClient)
+
+	eni := types.NetworkInterface{
+		NetworkInterfaceId: aws.String("eni-1"),
+		Status:             types.NetworkInterfaceStatusAvailable,
+	}
+	gomock.InOrder(
+		mockClient.EXPECT().
+			DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+			Return(&ec2.DescribeNetworkInterfacesOutput{NetworkInterfaces: []types.NetworkInterface{eni}}, nil),
+		// A failed poll keeps the last snapshot rather than reporting a delete
+		mockClient.EXPECT().
+			DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("throttled")),
+		mockClient.EXPECT().
+			DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+			Return(&ec2.DescribeNetworkInterfacesOutput{}, nil).
+			AnyTimes(),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	events := manager.Watch(ctx, nil, WatchConfig{Interval: 10 * time.Millisecond})
+
+	sync := <-events
+	assert.Equal(t, EventSync, sync.Type)
+	assert.Equal(t, "eni-1", *sync.ENI.NetworkInterfaceId)
+
+	deleted := <-events
+	assert.Equal(t, EventDeleted, deleted.Type)
+	assert.Equal(t, "eni-1", *deleted.ENI.NetworkInterfaceId)
+
+	cancel()
+	for range events {
+	}
+}
@@ -0,0 +1,156 @@
+package rpc
+
+import (
+	"fmt"
+	"strings"
+
+	"eni-project/internal/ec2"
+	eniv1 "eni-project/proto/eni/v1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// errorDomain identifies AWS error codes in ErrorInfo details
+const errorDomain = "ec2.amazonaws.com"
+
+// codeFor maps an AWS error code to the closest gRPC code
+func codeFor(awsCode string) codes.Code {
+	switch {
+	case strings.HasSuffix(awsCode, ".NotFound"):
+		return codes.NotFound
+	case strings.HasSuffix(awsCode, ".Malformed"),
+		strings.HasPrefix(awsCode, "InvalidParameter"),
+		strings.HasPrefix(awsCode, "MissingParameter"):
+		return codes.InvalidArgument
+	case awsCode == "UnauthorizedOperation", awsCode == "AuthFailure", awsCode == ec2.CodeHookVetoed,
+		awsCode == ec2.CodePolicyViolation:
+		return codes.PermissionDenied
+	case awsCode == "RequestLimitExceeded", awsCode == "Throttling",
+		strings.HasSuffix(awsCode, "LimitExceeded"),
+		awsCode == "InsufficientFreeAddressesInSubnet":
+		return codes.ResourceExhausted
+	case awsCode == "DependencyViolation",
+		awsCode == "IncorrectState",
+		awsCode == "InvalidNetworkInterface.InUse":
+		return codes.FailedPrecondition
+	case awsCode == "Canceled":
+		return codes.Canceled
+	case awsCode == "DeadlineExceeded":
+		return codes.DeadlineExceeded
+	default:
+		return codes.Unavailable
+	}
+}
+
+// toStatus converts an ENIManager error to a gRPC status carrying the AWS
+// error code and request ID as ErrorInfo
+func toStatus(err error) error {
+	awsCode := ec2.ErrorCode(err)
+	st := status.New(codeFor(awsCode), err.Error())
+	info := &errdetails.ErrorInfo{Reason: awsCode, Domain: errorDomain}
+	if requestID := ec2.RequestID(nil, err); requestID != "" {
+		info.Metadata = map[string]string{"request_id": requestID}
+	}
+	if detailed, derr := st.WithDetails(info); derr == nil {
+		st = detailed
+	}
+	return st.Err()
+}
+
+func invalidArgument(err error) error {
+	return status.Error(codes.InvalidArgument, err.Error())
+}
+
+func validateAssign(req *eniv1.AssignIPsRequest, ipv6 bool) error {
+	if err := ec2.ValidateID("eni_id", req.GetEniId(), "eni-"); err != nil {
+		return err
+	}
+	if (req.GetCount() > 0) == (len(req.GetAddresses()) > 0) {
+		return fmt.Errorf("exactly one of count or addresses is required")
+	}
+	return ec2.ValidateAddresses(req.GetAddresses(), ipv6)
+}
+
+func validateUnassign(req *eniv1.UnassignIPsRequest, ipv6 bool) error {
+	if err := ec2.ValidateID("eni_id", req.GetEniId(), "eni-"); err != nil {
+		return err
+	}
+	if len(req.GetAddresses()) == 0 {
+		return fmt.Errorf("addresses is required")
+	}
+	return ec2.ValidateAddresses(req.GetAddresses(), ipv6)
+}
+
+func toFilters(filters []*eniv1.Filter) []types.Filter {
+	var out []types.Filter
+	for _, f := range filters {
+		out = append(out, types.Filter{Name: aws.String(f.GetName()), Values: f.GetValues()})
+	}
+	return out
+}
+
+func newENI(eni types.NetworkInterface) *eniv1.ENI {
+	out := &eniv1.ENI{
+		Id:               aws.ToString(eni.NetworkInterfaceId),
+		SubnetId:         aws.ToString(eni.SubnetId),
+		VpcId:            aws.ToString(eni.VpcId),
+		AvailabilityZone: aws.ToString(eni.AvailabilityZone),
+		Description:      aws.ToString(eni.Description),
+		Status:           string(eni.Status),
+		InterfaceType:    string(eni.InterfaceType),
+		PrivateIp:        aws.ToString(eni.PrivateIpAddress),
+		Tags:             map[string]string{},
+	}
+	for _, ip := range eni.PrivateIpAddresses {
+		out.PrivateIps = append(out.PrivateIps, aws.ToString(ip.PrivateIpAddress))
+	}
+	for _, ip := range eni.Ipv6Addresses {
+		out.Ipv6Addresses = append(out.Ipv6Addresses, aws.ToString(ip.Ipv6Address))
+	}
+	for _, group := range eni.Groups {
+		out.SecurityGroupIds = append(out.SecurityGroupIds, aws.ToString(group.GroupId))
+	}
+	for _, tag := range eni.TagSet {
+		out.Tags[aws.ToString(tag.Key)] = aws.ToString(tag.Value)
+	}
+	if eni.Attachment != nil {
+		out.Attachment = &eniv1.Attachment{
+			Id:          aws.ToString(eni.Attachment.AttachmentId),
+			InstanceId:  aws.ToString(eni.Attachment.InstanceId),
+			DeviceIndex: aws.ToInt32(eni.Attachment.DeviceIndex),
+			Status:      string(eni.Attachment.Status),
+		}
+	}
+	return out
+}
+
+var eventTypes = map[ec2.EventType]eniv1.ENIEvent_Type{
+	ec2.EventSync:                  eniv1.ENIEvent_TYPE_SYNC,
+	ec2.EventCreated:               eniv1.ENIEvent_TYPE_CREATED,
+	ec2.EventDeleted:               eniv1.ENIEvent_TYPE_DELETED,
+	ec2.EventAttached:              eniv1.ENIEvent_TYPE_ATTACHED,
+	ec2.EventDetached:              eniv1.ENIEvent_TYPE_DETACHED,
+	ec2.EventStatusChanged:         eniv1.ENIEvent_TYPE_STATUS_CHANGED,
+	ec2.EventIPsAdded:              eniv1.ENIEvent_TYPE_IPS_ADDED,
+	ec2.EventIPsRemoved:            eniv1.ENIEvent_TYPE_IPS_REMOVED,
+	ec2.EventTagsChanged:           eniv1.ENIEvent_TYPE_TAGS_CHANGED,
+	ec2.EventSecurityGroupsChanged: eniv1.ENIEvent_TYPE_SECURITY_GROUPS_CHANGED,
+}
+
+func newEvent(event ec2.Event) *eniv1.ENIEvent {
+	out := &eniv1.ENIEvent{
+		Type:    eventTypes[event.Type],
+		Eni:     newENI(event.ENI),
+		Time:    timestamppb.New(event.Time),
+		Added:   event.Added,
+		Removed: event.Removed,
+	}
+	if event.Previous != nil {
+		out.Previous = newENI(*event.Previous)
+	}
+	return out
+}
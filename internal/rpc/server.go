@@ -0,0 +1,258 @@
+// Package rpc implements the eni.v1.ENIService gRPC service on top of ENIManager.
+package rpc
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"eni-project/internal/ec2"
+	eniv1 "eni-project/proto/eni/v1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// defaultWatchInterval applies when a watch request leaves the interval unset
+const defaultWatchInterval = 30 * time.Second
+
+// Server serves ENIService backed by an ENIManager
+type Server struct {
+	eniv1.UnimplementedENIServiceServer
+
+	manager *ec2.ENIManager
+}
+
+var _ eniv1.ENIServiceServer = (*Server)(nil)
+
+func NewServer(manager *ec2.ENIManager) *Server {
+	return &Server{manager: manager}
+}
+
+// Register adds the service to s
+func (srv *Server) Register(s grpc.ServiceRegistrar) {
+	eniv1.RegisterENIServiceServer(s, srv)
+}
+
+// CallerInterceptor attributes unary calls to the connecting peer's address
+// in the audit log
+func CallerInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	if p, ok := peer.FromContext(ctx); ok {
+		host, _, err := net.SplitHostPort(p.Addr.String())
+		if err != nil {
+			host = p.Addr.String()
+		}
+		ctx = ec2.WithCaller(ctx, "grpc:"+host)
+	}
+	return handler(ctx, req)
+}
+
+func (srv *Server) CreateENI(ctx context.Context, req *eniv1.CreateENIRequest) (*eniv1.ENI, error) {
+	config := ec2.ENIConfig{
+		SubnetID:         req.GetSubnetId(),
+		Description:      req.GetDescription(),
+		SecurityGroupIDs: req.GetSecurityGroupIds(),
+		PrivateIPCount:   req.GetPrivateIpCount(),
+		IPv6AddressCount: req.GetIpv6AddressCount(),
+		Tags:             req.GetTags(),
+		InterfaceType:    types.NetworkInterfaceCreationType(req.GetInterfaceType()),
+	}
+	if err := config.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	output, err := srv.manager.CreateENI(ctx, config)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return newENI(*output.NetworkInterface), nil
+}
+
+func (srv *Server) AttachENI(ctx context.Context, req *eniv1.AttachENIRequest) (*eniv1.AttachENIResponse, error) {
+	if err := ec2.ValidateID("eni_id", req.GetEniId(), "eni-"); err != nil {
+		return nil, invalidArgument(err)
+	}
+	if err := ec2.ValidateID("instance_id", req.GetInstanceId(), "i-"); err != nil {
+		return nil, invalidArgument(err)
+	}
+	// Device 0 is always the instance's primary interface
+	if req.GetDeviceIndex() < 1 {
+		return nil, status.Error(codes.InvalidArgument, "device_index must be at least 1")
+	}
+	if req.NetworkCardIndex != nil && req.GetNetworkCardIndex() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "network_card_index must not be negative")
+	}
+
+	var attachmentID *string
+	var err error
+	if req.NetworkCardIndex != nil {
+		attachmentID, err = srv.manager.AttachENIToCard(ctx, req.GetEniId(), req.GetInstanceId(), req.GetDeviceIndex(), req.GetNetworkCardIndex())
+	} else {
+		attachmentID, err = srv.manager.AttachENI(ctx, req.GetEniId(), req.GetInstanceId(), req.GetDeviceIndex())
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &eniv1.AttachENIResponse{AttachmentId: aws.ToString(attachmentID)}, nil
+}
+
+func (srv *Server) DetachENI(ctx context.Context, req *eniv1.DetachENIRequest) (*eniv1.DetachENIResponse, error) {
+	if err := ec2.ValidateID("attachment_id", req.GetAttachmentId(), "eni-attach-"); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := srv.manager.DetachENI(ctx, req.GetAttachmentId(), req.GetForce()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &eniv1.DetachENIResponse{}, nil
+}
+
+func (srv *Server) DeleteENI(ctx context.Context, req *eniv1.DeleteENIRequest) (*eniv1.DeleteENIResponse, error) {
+	if err := ec2.ValidateID("eni_id", req.GetEniId(), "eni-"); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := srv.manager.DeleteENI(ctx, req.GetEniId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &eniv1.DeleteENIResponse{}, nil
+}
+
+func (srv *Server) ModifyENI(ctx context.Context, req *eniv1.ModifyENIRequest) (*eniv1.ModifyENIResponse, error) {
+	if err := ec2.ValidateID("eni_id", req.GetEniId(), "eni-"); err != nil {
+		return nil, invalidArgument(err)
+	}
+	config := ec2.ENIModifyConfig{
+		Description:      req.Description,
+		SecurityGroupIDs: req.GetSecurityGroupIds(),
+	}
+	if err := config.Validate(); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := srv.manager.ModifyENIAttribute(ctx, req.GetEniId(), config); err != nil {
+		return nil, toStatus(err)
+	}
+	return &eniv1.ModifyENIResponse{}, nil
+}
+
+func (srv *Server) AssignPrivateIPs(ctx context.Context, req *eniv1.AssignIPsRequest) (*eniv1.AssignIPsResponse, error) {
+	if err := validateAssign(req, false); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := srv.manager.AssignPrivateIPs(ctx, req.GetEniId(), req.GetCount(), req.GetAddresses()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &eniv1.AssignIPsResponse{}, nil
+}
+
+func (srv *Server) UnassignPrivateIPs(ctx context.Context, req *eniv1.UnassignIPsRequest) (*eniv1.UnassignIPsResponse, error) {
+	if err := validateUnassign(req, false); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := srv.manager.UnassignPrivateIPs(ctx, req.GetEniId(), req.GetAddresses()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &eniv1.UnassignIPsResponse{}, nil
+}
+
+func (srv *Server) AssignIPv6Addresses(ctx context.Context, req *eniv1.AssignIPsRequest) (*eniv1.AssignIPsResponse, error) {
+	if err := validateAssign(req, true); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	var count *int32
+	if req.GetCount() > 0 {
+		count = aws.Int32(req.GetCount())
+	}
+	if err := srv.manager.AssignIPv6Addresses(ctx, req.GetEniId(), req.GetAddresses(), count); err != nil {
+		return nil, toStatus(err)
+	}
+	return &eniv1.AssignIPsResponse{}, nil
+}
+
+func (srv *Server) UnassignIPv6Addresses(ctx context.Context, req *eniv1.UnassignIPsRequest) (*eniv1.UnassignIPsResponse, error) {
+	if err := validateUnassign(req, true); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	if err := srv.manager.UnassignIPv6Addresses(ctx, req.GetEniId(), req.GetAddresses()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &eniv1.UnassignIPsResponse{}, nil
+}
+
+func (srv *Server) GetENI(ctx context.Context, req *eniv1.GetENIRequest) (*eniv1.ENI, error) {
+	if err := ec2.ValidateID("eni_id", req.GetEniId(), "eni-"); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	output, err := srv.manager.DescribeENIs(ctx, []types.Filter{
+		{Name: aws.String("network-interface-id"), Values: []string{req.GetEniId()}},
+	})
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if len(output.NetworkInterfaces) == 0 {
+		return nil, status.Errorf(codes.NotFound, "ENI %s does not exist", req.GetEniId())
+	}
+	return newENI(output.NetworkInterfaces[0]), nil
+}
+
+func (srv *Server) ListENIs(req *eniv1.ListENIsRequest, stream grpc.ServerStreamingServer[eniv1.ENI]) error {
+	output, err := srv.manager.DescribeENIs(stream.Context(), toFilters(req.GetFilters()))
+	if err != nil {
+		return toStatus(err)
+	}
+
+	for _, eni := range output.NetworkInterfaces {
+		if err := stream.Send(newENI(eni)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (srv *Server) WatchENIs(req *eniv1.WatchENIsRequest, stream grpc.ServerStreamingServer[eniv1.ENIEvent]) error {
+	config := ec2.DefaultWatchConfig()
+	config.Interval = defaultWatchInterval
+	if req.GetIntervalSeconds() > 0 {
+		config.Interval = time.Duration(req.GetIntervalSeconds()) * time.Second
+	}
+	config.ResyncInterval = time.Duration(req.GetResyncIntervalSeconds()) * time.Second
+
+	for event := range srv.manager.Watch(stream.Context(), toFilters(req.GetFilters()), config) {
+		if err := stream.Send(newEvent(event)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (srv *Server) DescribeSubnet(ctx context.Context, req *eniv1.DescribeSubnetRequest) (*eniv1.Subnet, error) {
+	if err := ec2.ValidateID("subnet_id", req.GetSubnetId(), "subnet-"); err != nil {
+		return nil, invalidArgument(err)
+	}
+
+	output, err := srv.manager.DescribeSubnet(ctx, req.GetSubnetId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	if len(output.Subnets) == 0 {
+		return nil, status.Errorf(codes.NotFound, "subnet %s does not exist", req.GetSubnetId())
+	}
+
+	subnet := output.Subnets[0]
+	return &eniv1.Subnet{
+		Id:                      aws.ToString(subnet.SubnetId),
+		VpcId:                   aws.ToString(subnet.VpcId),
+		AvailabilityZone:        aws.ToString(subnet.AvailabilityZone),
+		CidrBlock:               aws.ToString(subnet.CidrBlock),
+		AvailableIpAddressCount: aws.ToInt32(subnet.AvailableIpAddressCount),
+	}, nil
+}
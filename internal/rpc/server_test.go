@@ -0,0 +1,211 @@
+// internal/rpc/server_test.go
+package rpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"eni-project/internal/ec2"
+	"eni-project/internal/ec2/mocks"
+	eniv1 "eni-project/proto/eni/v1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/aws/smithy-go"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// newTestClient serves ENIService on a local listener and dials it
+func newTestClient(t *testing.T) (*mocks.MockEC2ClientAPI, eniv1.ENIServiceClient) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockClient := mocks.NewMockEC2ClientAPI(ctrl)
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+
+	server := grpc.NewServer()
+	NewServer(ec2.NewENIManager(mockClient)).Register(server)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(lis.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return mockClient, eniv1.NewENIServiceClient(conn)
+}
+
+func TestServer_CreateAndAttach(t *testing.T) {
+	mockClient, client := newTestClient(t)
+
+	mockClient.EXPECT().
+		CreateNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(&awsec2.CreateNetworkInterfaceOutput{
+			NetworkInterface: &types.NetworkInterface{
+				NetworkInterfaceId: aws.String("eni-12345678"),
+				SubnetId:           aws.String("subnet-12345678"),
+				Status:             types.NetworkInterfaceStatusAvailable,
+			},
+		}, nil)
+	mockClient.EXPECT().
+		AttachNetworkInterface(gomock.Any(), gomock.Eq(&awsec2.AttachNetworkInterfaceInput{
+			NetworkInterfaceId: aws.String("eni-12345678"),
+			InstanceId:         aws.String("i-12345678"),
+			DeviceIndex:        aws.Int32(1),
+		})).
+		Return(&awsec2.AttachNetworkInterfaceOutput{AttachmentId: aws.String("eni-attach-12345678")}, nil)
+
+	ctx := context.Background()
+	eni, err := client.CreateENI(ctx, &eniv1.CreateENIRequest{SubnetId: "subnet-12345678"})
+	assert.NoError(t, err)
+	assert.Equal(t, "eni-12345678", eni.GetId())
+	assert.Equal(t, "available", eni.GetStatus())
+
+	resp, err := client.AttachENI(ctx, &eniv1.AttachENIRequest{
+		EniId:       eni.GetId(),
+		InstanceId:  "i-12345678",
+		DeviceIndex: 1,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "eni-attach-12345678", resp.GetAttachmentId())
+}
+
+func TestServer_CreateAndAttachEFA(t *testing.T) {
+	mockClient, client := newTestClient(t)
+
+	mockClient.EXPECT().
+		CreateNetworkInterface(gomock.Any(), gomock.Eq(&awsec2.CreateNetworkInterfaceInput{
+			SubnetId:      aws.String("subnet-12345678"),
+			Description:   aws.String(""),
+			InterfaceType: types.NetworkInterfaceCreationTypeEfa,
+		})).
+		Return(&awsec2.CreateNetworkInterfaceOutput{
+			NetworkInterface: &types.NetworkInterface{
+				NetworkInterfaceId: aws.String("eni-12345678"),
+				InterfaceType:      types.NetworkInterfaceTypeEfa,
+			},
+		}, nil)
+	mockClient.EXPECT().
+		AttachNetworkInterface(gomock.Any(), gomock.Eq(&awsec2.AttachNetworkInterfaceInput{
+			NetworkInterfaceId: aws.String("eni-12345678"),
+			InstanceId:         aws.String("i-12345678"),
+			DeviceIndex:        aws.Int32(1),
+			NetworkCardIndex:   aws.Int32(1),
+		})).
+		Return(&awsec2.AttachNetworkInterfaceOutput{AttachmentId: aws.String("eni-attach-12345678")}, nil)
+
+	ctx := context.Background()
+	eni, err := client.CreateENI(ctx, &eniv1.CreateENIRequest{SubnetId: "subnet-12345678", InterfaceType: "efa"})
+	assert.NoError(t, err)
+	assert.Equal(t, "efa", eni.GetInterfaceType())
+
+	_, err = client.AttachENI(ctx, &eniv1.AttachENIRequest{
+		EniId:            eni.GetId(),
+		InstanceId:       "i-12345678",
+		DeviceIndex:      1,
+		NetworkCardIndex: aws.Int32(1),
+	})
+	assert.NoError(t, err)
+
+	_, err = client.CreateENI(ctx, &eniv1.CreateENIRequest{SubnetId: "subnet-12345678", InterfaceType: "bogus"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_Errors(t *testing.T) {
+	mockClient, client := newTestClient(t)
+
+	mockClient.EXPECT().
+		DeleteNetworkInterface(gomock.Any(), gomock.Any()).
+		Return(nil, &smithy.GenericAPIError{Code: "InvalidNetworkInterfaceID.NotFound"})
+
+	ctx := context.Background()
+	_, err := client.DeleteENI(ctx, &eniv1.DeleteENIRequest{EniId: "eni-12345678"})
+	st := status.Convert(err)
+	assert.Equal(t, codes.NotFound, st.Code())
+	assert.Len(t, st.Details(), 1)
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	assert.True(t, ok)
+	assert.Equal(t, "InvalidNetworkInterfaceID.NotFound", info.GetReason())
+
+	_, err = client.AssignPrivateIPs(ctx, &eniv1.AssignIPsRequest{EniId: "eni-12345678"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestServer_ListENIs(t *testing.T) {
+	mockClient, client := newTestClient(t)
+
+	mockClient.EXPECT().
+		DescribeNetworkInterfaces(gomock.Any(), gomock.Eq(&awsec2.DescribeNetworkInterfacesInput{
+			Filters: []types.Filter{{Name: aws.String("subnet-id"), Values: []string{"subnet-12345678"}}},
+		})).
+		Return(&awsec2.DescribeNetworkInterfacesOutput{
+			NetworkInterfaces: []types.NetworkInterface{
+				{NetworkInterfaceId: aws.String("eni-1")},
+				{NetworkInterfaceId: aws.String("eni-2")},
+			},
+		}, nil)
+
+	stream, err := client.ListENIs(context.Background(), &eniv1.ListENIsRequest{
+		Filters: []*eniv1.Filter{{Name: "subnet-id", Values: []string{"subnet-12345678"}}},
+	})
+	assert.NoError(t, err)
+
+	var ids []string
+	for {
+		eni, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		assert.NoError(t, err)
+		ids = append(ids, eni.GetId())
+	}
+	assert.Equal(t, []string{"eni-1", "eni-2"}, ids)
+}
+
+func TestServer_WatchENIs(t *testing.T) {
+	mockClient, client := newTestClient(t)
+
+	gomock.InOrder(
+		mockClient.EXPECT().
+			DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+			Return(&awsec2.DescribeNetworkInterfacesOutput{
+				NetworkInterfaces: []types.NetworkInterface{
+					{NetworkInterfaceId: aws.String("eni-1"), Status: types.NetworkInterfaceStatusAvailable},
+				},
+			}, nil),
+		mockClient.EXPECT().
+			DescribeNetworkInterfaces(gomock.Any(), gomock.Any()).
+			Return(&awsec2.DescribeNetworkInterfacesOutput{
+				NetworkInterfaces: []types.NetworkInterface{
+					{NetworkInterfaceId: aws.String("eni-1"), Status: types.NetworkInterfaceStatusInUse},
+				},
+			}, nil).
+			AnyTimes(),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.WatchENIs(ctx, &eniv1.WatchENIsRequest{IntervalSeconds: 1})
+	assert.NoError(t, err)
+
+	event, err := stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, eniv1.ENIEvent_TYPE_SYNC, event.GetType())
+	assert.Equal(t, "available", event.GetEni().GetStatus())
+
+	event, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, eniv1.ENIEvent_TYPE_STATUS_CHANGED, event.GetType())
+	assert.Equal(t, "in-use", event.GetEni().GetStatus())
+	assert.Equal(t, "available", event.GetPrevious().GetStatus())
+}
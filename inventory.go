@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"eni-project/internal/ec2"
+)
+
+// runInventory implements "inventory instance ID", which shows every ENI of
+// an instance with its addresses, subnet and remaining capacity
+func runInventory(args []string, eniManager *ec2.ENIManager, timeout time.Duration) {
+	if len(args) == 0 || args[0] != "instance" {
+		fmt.Fprintln(os.Stderr, "usage: inventory instance [-json] INSTANCE_ID")
+		exit(2)
+	}
+	fs := flag.NewFlagSet("inventory instance", flag.ExitOnError)
+	asJSON := fs.Bool("json", false, "print the inventory as JSON")
+	fs.Parse(args[1:])
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: inventory instance [-json] INSTANCE_ID")
+		exit(2)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	inventory, err := eniManager.InstanceInventory(ctx, fs.Arg(0))
+	if err != nil {
+		fatal("Failed to build instance inventory", err)
+	}
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(inventory)
+		return
+	}
+	printInventory(inventory)
+}
+
+func printInventory(inv *ec2.InstanceInventory) {
+	fmt.Printf("%s  %s  %s  interfaces %d/%d (%d free)\n\n", inv.InstanceID, inv.InstanceType,
+		inv.AvailabilityZone, len(inv.Interfaces), inv.MaxInterfaces, inv.FreeInterfaces)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "CARD\tDEVICE\tENI\tTYPE\tSUBNET\tAZ\tCIDR\tSECURITY GROUPS\tSRC/DST\tDEL ON TERM")
+	for _, iface := range inv.Interfaces {
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%s\t%s\t%s\t%t\t%t\n", iface.NetworkCardIndex, iface.DeviceIndex,
+			iface.ENIID, iface.InterfaceType, iface.SubnetID, iface.AvailabilityZone, iface.SubnetCIDR,
+			strings.Join(iface.SecurityGroupIDs, ","), iface.SourceDestCheck, iface.DeleteOnTermination)
+	}
+	w.Flush()
+
+	for _, iface := range inv.Interfaces {
+		fmt.Printf("\n%s (card %d, device %d)\n", iface.ENIID, iface.NetworkCardIndex, iface.DeviceIndex)
+		fmt.Printf("  primary ipv4:   %s\n", iface.PrimaryIPv4)
+		printList("secondary ipv4", iface.SecondaryIPv4)
+		printList("ipv6", iface.IPv6)
+		printList("ipv4 prefixes", iface.IPv4Prefixes)
+		printList("ipv6 prefixes", iface.IPv6Prefixes)
+		for _, ip := range iface.PublicIPs {
+			kind := "public ip"
+			if ip.AllocationID != "" {
+				kind = "elastic ip"
+			}
+			fmt.Printf("  %-15s %s -> %s %s\n", kind+":", ip.Address, ip.PrivateIP, ip.AllocationID)
+		}
+		fmt.Printf("  free:           %d ipv4, %d ipv6\n", iface.FreeIPv4, iface.FreeIPv6)
+	}
+}
+
+func printList(label string, values []string) {
+	if len(values) > 0 {
+		fmt.Printf("  %-15s %s\n", label+":", strings.Join(values, ", "))
+	}
+}
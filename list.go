@@ -0,0 +1,64 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"eni-project/internal/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// runList prints the ENIs in every configured account and region
+func runList(args []string, registry *ec2.Registry, timeout time.Duration) {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	managed := fs.Bool("managed", false, "only ENIs tagged as managed by this tool")
+	subnets := fs.String("subnet", "", "only ENIs in these comma-separated subnets")
+	fs.Parse(args)
+
+	var filters []types.Filter
+	if *managed {
+		filters = append(filters, ec2.ManagedENIFilters()...)
+	}
+	if ids := splitList(*subnets); len(ids) > 0 {
+		filters = append(filters, types.Filter{Name: aws.String("subnet-id"), Values: ids})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	enis, err := registry.ListENIs(ctx, filters)
+	printENIs(enis)
+	if err != nil {
+		fatal("Failed to list ENIs in some regions", err)
+	}
+}
+
+// runGC deletes unattached managed ENIs in every configured account and region
+func runGC(registry *ec2.Registry, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	deleted, err := registry.CollectGarbage(ctx)
+	printENIs(deleted)
+	slog.Info("Garbage collection finished", "deleted", len(deleted))
+	if err != nil {
+		fatal("Failed to collect garbage in some regions", err)
+	}
+}
+
+func printENIs(enis []ec2.OriginENI) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ACCOUNT\tREGION\tENI\tSTATUS\tSUBNET\tPRIVATE IP")
+	for _, e := range enis {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", e.Origin.Account, e.Origin.Region,
+			aws.ToString(e.ENI.NetworkInterfaceId), e.ENI.Status,
+			aws.ToString(e.ENI.SubnetId), aws.ToString(e.ENI.PrivateIpAddress))
+	}
+	w.Flush()
+}
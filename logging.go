@@ -0,0 +1,64 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// newLogger builds the CLI logger from the --log-format and --log-level flags
+func newLogger(w io.Writer, format, level string) (*slog.Logger, error) {
+	var lvl slog.Level
+	if err := lvl.UnmarshalText([]byte(level)); err != nil {
+		return nil, fmt.Errorf("invalid log level %q: %w", level, err)
+	}
+
+	opts := &slog.HandlerOptions{Level: lvl}
+	switch strings.ToLower(format) {
+	case "json":
+		return slog.New(slog.NewJSONHandler(w, opts)), nil
+	case "text":
+		return slog.New(slog.NewTextHandler(w, opts)), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q (want json or text)", format)
+	}
+}
+
+// newRunID returns a random correlation ID identifying one CLI invocation
+func newRunID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// cleanups are run by exit, most recently registered first
+var cleanups []func()
+
+// atExit registers f to run before the CLI exits, so that failures still
+// flush traces and close the audit log
+func atExit(f func()) {
+	cleanups = append(cleanups, f)
+}
+
+// exit runs the registered cleanups and exits with code
+func exit(code int) {
+	// Taken first so a cleanup that fails can't run them again
+	pending := cleanups
+	cleanups = nil
+	for i := len(pending) - 1; i >= 0; i-- {
+		pending[i]()
+	}
+	os.Exit(code)
+}
+
+// fatal logs msg with err and exits
+func fatal(msg string, err error) {
+	slog.Error(msg, "error", err)
+	exit(1)
+}
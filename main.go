@@ -2,123 +2,169 @@ package main
 
 import (
 	"context"
-	"log"
-	"time"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
 
 	"eni-project/internal/ec2"
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	awsec2 "github.com/aws/aws-sdk-go-v2/service/ec2"
-	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 )
 
 func main() {
-	// Load AWS configuration
-	cfg, err := config.LoadDefaultConfig(context.TODO())
-	if err != nil {
-		log.Fatalf("unable to load SDK config: %v", err)
-	}
-
-	// Create EC2 client
-	client := awsec2.NewFromConfig(cfg)
-
-	// Create ENI manager
-	eniManager := ec2.NewENIManager(client)
-
-	// Create context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
-	defer cancel()
-
-	// Example parameters
-	subnetID := "subnet-0a7bd03887dc3cbd5"
-	instanceID := "i-04890aa7cd8cf81f3"
-	securityGroupID := "sg-0f9acdf364ab834f2"
+	flags := registerSettingFlags(flag.CommandLine)
+	flag.Parse()
 
-	// Example: Create an ENI
-	eniConfig := ec2.ENIConfig{
-		SubnetID:         subnetID,
-		Description:      "Example ENI",
-		SecurityGroupIDs: []string{securityGroupID},
-		PrivateIPCount:   2,
-		IPv6AddressCount: 0,
-		Tags: map[string]string{
-			"Name":        "example-eni",
-			"Environment": "development",
-			"ManagedBy":   "eni-manager",
-		},
+	// Config inspection reports its own errors and never touches AWS
+	if args := flag.Args(); len(args) > 0 && args[0] == "config" {
+		runConfig(args[1:], flags)
+		return
 	}
 
-	log.Println("Creating ENI...")
-	eni, err := eniManager.CreateENI(ctx, eniConfig)
+	cfg, err := flags.resolve()
 	if err != nil {
-		log.Fatalf("Failed to create ENI: %v", err)
+		fmt.Fprintln(os.Stderr, err)
+		exit(2)
 	}
-	log.Printf("Created ENI: %s\n", *eni.NetworkInterface.NetworkInterfaceId)
 
-	// Example: Attach ENI to an instance
-	log.Printf("Attaching ENI to instance %s...\n", instanceID)
-	attachID, err := eniManager.AttachENI(ctx, *eni.NetworkInterface.NetworkInterfaceId, instanceID, 1)
+	// Every line carries the run's correlation ID
+	logger, err := newLogger(os.Stderr, cfg.LogFormat, cfg.LogLevel)
 	if err != nil {
-		log.Fatalf("Failed to attach ENI: %v", err)
+		fmt.Fprintln(os.Stderr, err)
+		exit(2)
+	}
+	logger = logger.With("run_id", newRunID())
+	slog.SetDefault(logger)
+	if cfg.Path != "" {
+		slog.Debug("Loaded config file", "path", cfg.Path, "profile", cfg.Profile)
 	}
-	log.Printf("Attached ENI with attachment ID: %s\n", *attachID)
 
-	// Example: Assign additional private IPs
-	log.Println("Assigning additional private IPs...")
-	err = eniManager.AssignPrivateIPs(ctx, *eni.NetworkInterface.NetworkInterfaceId, 2, nil)
+	dryRunMode, err := ec2.ParseDryRunMode(cfg.DryRun)
 	if err != nil {
-		log.Printf("Failed to assign private IPs: %v", err)
+		fmt.Fprintln(os.Stderr, err)
+		exit(2)
 	}
 
-	// Example: Describe ENIs in the subnet
-	log.Println("Describing ENIs...")
-	filters := []types.Filter{
-		{
-			Name:   aws.String("subnet-id"),
-			Values: []string{eniConfig.SubnetID},
-		},
+	// The first argument selects the command; with none we run the demo
+	command, args := "demo", flag.Args()
+	if len(args) > 0 {
+		command, args = args[0], args[1:]
 	}
 
-	enis, err := eniManager.DescribeENIs(ctx, filters)
-	if err != nil {
-		log.Printf("Failed to describe ENIs: %v", err)
-	} else {
-		for _, eni := range enis.NetworkInterfaces {
-			log.Printf("Found ENI: %s, Status: %s\n", *eni.NetworkInterfaceId, eni.Status)
-		}
+	// Audit log inspection works offline
+	if command == "audit" {
+		runAudit(args, cfg.AuditLog)
+		return
 	}
 
-	// Example: Modify ENI attributes
-	log.Println("Modifying ENI attributes...")
-	modifyConfig := ec2.ENIModifyConfig{
-		Description: aws.String("Updated description"),
-	}
-	err = eniManager.ModifyENIAttribute(ctx, *eni.NetworkInterface.NetworkInterfaceId, modifyConfig)
+	// Export traces if an OTLP endpoint is configured
+	shutdownTracing, err := setupTracing(context.TODO())
 	if err != nil {
-		log.Printf("Failed to modify ENI: %v", err)
+		fatal("unable to set up tracing", err)
 	}
+	atExit(func() {
+		if err := shutdownTracing(context.Background()); err != nil {
+			slog.Warn("Failed to flush traces", "error", err)
+		}
+	})
 
-	// Wait before cleanup
-	log.Println("Waiting for 5 seconds before cleanup...")
-	time.Sleep(5 * time.Second)
+	// Register metrics
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	metrics := ec2.NewMetrics(registry)
 
-	// Example: Detach ENI
-	log.Println("Detaching ENI...")
-	err = eniManager.DetachENI(ctx, *attachID, true)
-	if err != nil {
-		log.Fatalf("Failed to detach ENI: %v", err)
+	// Options shared by the ENI manager for every account and region
+	opts := []ec2.Option{ec2.WithMetrics(metrics), ec2.WithDryRun(dryRunMode, os.Stdout)}
+	if len(cfg.BranchLimits) > 0 {
+		opts = append(opts, ec2.WithBranchLimits(cfg.BranchLimits))
+	}
+	if cfg.AuditLog != "" {
+		auditLog, err := ec2.OpenAuditLog(cfg.AuditLog)
+		if err != nil {
+			fatal("unable to open audit log", err)
+		}
+		atExit(func() { auditLog.Close() })
+		auditLog.Caller = cliCaller()
+		opts = append(opts, ec2.WithAuditLog(auditLog))
+	}
+	if cfg.Policy != "" {
+		rules, err := ec2.LoadRules(cfg.Policy)
+		if err != nil {
+			fatal("unable to load policy", err)
+		}
+		opts = append(opts, ec2.WithPolicy(rules))
+	}
+	if cfg.Hooks != "" {
+		hooks, err := loadHooks(cfg.Hooks)
+		if err != nil {
+			fatal("unable to load hooks", err)
+		}
+		opts = append(opts, ec2.WithHooks(hooks...))
 	}
 
-	// Wait for detachment to complete
-	log.Println("Waiting for 5 seconds after detachment...")
-	time.Sleep(5 * time.Second)
-
-	// Example: Delete ENI
-	log.Println("Deleting ENI...")
-	err = eniManager.DeleteENI(ctx, *eni.NetworkInterface.NetworkInterfaceId)
+	// One manager per account and region; single-target commands use the
+	// first account and region given
+	sc := stackConfig{record: cfg.Record != "", rateLimit: cfg.RateLimit}
+	managers, stacks, err := loadRegistry(context.TODO(), accountsFor(cfg.AWSProfiles, cfg.RoleARNs), cfg.Regions, sc, opts)
 	if err != nil {
-		log.Fatalf("Failed to delete ENI: %v", err)
+		fatal("unable to load SDK config", err)
+	}
+	if cfg.Record != "" {
+		// A cassette replays one client's calls in order, so it can't mix
+		// accounts or regions
+		if len(stacks) > 1 {
+			fmt.Fprintln(os.Stderr, "--record needs a single account and region")
+			exit(2)
+		}
+		atExit(func() {
+			if err := stacks[0].recorder.Save(cfg.Record); err != nil {
+				slog.Warn("Failed to save cassette", "path", cfg.Record, "error", err)
+			}
+		})
+	}
+	eniManager, _ := managers.Get(stacks[0].origin)
+
+	switch command {
+	case "demo":
+		runDemo(eniManager, cfg.settings)
+	case "metrics":
+		runMetrics(args, eniManager, registry)
+	case "serve":
+		runServe(args, eniManager, registry)
+	case "openapi":
+		runOpenAPI(eniManager)
+	case "preflight":
+		runPreflight(args, eniManager, cfg.settings)
+	case "list":
+		runList(args, managers, cfg.Timeout)
+	case "gc":
+		runGC(managers, cfg.Timeout)
+	case "sg":
+		runSecurityGroups(args, eniManager, cfg.Timeout)
+	case "trunk":
+		runTrunk(args, eniManager, cfg.settings)
+	case "efa":
+		runEFA(args, eniManager, cfg.settings)
+	case "cards":
+		runCards(args, eniManager, cfg.settings)
+	case "inventory":
+		runInventory(args, eniManager, cfg.Timeout)
+	case "subnet":
+		runSubnet(args, eniManager, cfg.settings)
+	case "drift":
+		runDrift(args, eniManager, cfg.Timeout)
+	case "snapshot":
+		runSnapshot(args, eniManager, cfg.Timeout)
+	case "move":
+		runMove(args, eniManager, cfg.Timeout)
+	default:
+		slog.Error("Unknown command (want demo, metrics, serve, openapi, preflight, list, gc, sg, trunk, efa, cards, inventory, subnet, drift, snapshot, move, audit or config)", "command", command)
+		exit(2)
 	}
 
-	log.Println("ENI management operations completed successfully")
+	for _, stack := range stacks {
+		stack.logStats()
+	}
+	exit(0)
 }
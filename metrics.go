@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"log/slog"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"eni-project/internal/ec2"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// runMetrics serves /metrics until interrupted, refreshing the managed ENI
+// inventory gauges every interval.
+func runMetrics(args []string, eniManager *ec2.ENIManager, registry *prometheus.Registry) {
+	fs := flag.NewFlagSet("metrics", flag.ExitOnError)
+	listen := fs.String("listen", ":9100", "address to serve /metrics on")
+	interval := fs.Duration("interval", 30*time.Second, "how often to refresh the managed ENI inventory")
+	fs.Parse(args)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry}))
+	server := &http.Server{Addr: *listen, Handler: mux}
+
+	go func() {
+		slog.Info("Serving metrics", "address", *listen, "path", "/metrics")
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			fatal("Metrics server failed", err)
+		}
+	}()
+
+	ticker := time.NewTicker(*interval)
+	defer ticker.Stop()
+	for {
+		if err := eniManager.RefreshMetrics(ctx); err != nil && ctx.Err() == nil {
+			slog.Warn("Failed to refresh ENI inventory", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			slog.Info("Shutting down metrics server")
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := server.Shutdown(shutdownCtx); err != nil {
+				slog.Error("Failed to shut down metrics server", "error", err)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
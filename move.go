@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"eni-project/internal/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// runMove moves an attached ENI, with its addresses, to another instance,
+// rolling back to the original instance if the detach or target attach fails
+func runMove(args []string, eniManager *ec2.ENIManager, timeout time.Duration) {
+	config := ec2.DefaultMoveConfig()
+	fs := flag.NewFlagSet("move", flag.ExitOnError)
+	deviceIndex := fs.Int("device-index", -1, "device index on the target; -1 picks the lowest free one")
+	networkCard := fs.Int("network-card", -1, "network card on the target; -1 for any")
+	fs.BoolVar(&config.Force, "force", false, "force the detach from the source instance")
+	fs.DurationVar(&config.DetachTimeout, "detach-timeout", config.DetachTimeout, "how long to wait for the ENI to detach")
+	asJSON := fs.Bool("json", false, "print the result as JSON")
+	fs.Parse(args)
+	if fs.NArg() != 2 {
+		fmt.Fprintln(os.Stderr, "usage: move [-device-index N] [-network-card N] [-force] [-json] ENI_ID INSTANCE_ID")
+		exit(2)
+	}
+	if *deviceIndex >= 0 {
+		config.DeviceIndex = aws.Int32(int32(*deviceIndex))
+	}
+	if *networkCard >= 0 {
+		config.NetworkCardIndex = aws.Int32(int32(*networkCard))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	result, err := eniManager.MoveENI(ctx, fs.Arg(0), fs.Arg(1), config)
+	if result != nil {
+		if *asJSON {
+			json.NewEncoder(os.Stdout).Encode(result)
+		} else if result.RolledBack {
+			fmt.Printf("%s rolled back to %s at device %d (%s)\n", result.ENIID, result.FromInstanceID, result.FromDeviceIndex, result.AttachmentID)
+		} else if err == nil {
+			fmt.Printf("%s moved from %s to %s at card %d device %d (%s)\n", result.ENIID, result.FromInstanceID,
+				result.ToInstanceID, result.NetworkCardIndex, result.DeviceIndex, result.AttachmentID)
+		} else {
+			fmt.Printf("%s is detached, it was on %s at card %d device %d\n", result.ENIID, result.FromInstanceID,
+				result.FromNetworkCardIndex, result.FromDeviceIndex)
+		}
+	}
+	if err != nil {
+		fatal("Failed to move ENI", err)
+	}
+}
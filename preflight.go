@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"eni-project/internal/ec2"
+)
+
+// runPreflight checks the IAM permissions a planned workflow needs and exits
+// non-zero if any are denied. The subnet, security groups and instance
+// default to the configured ones.
+func runPreflight(args []string, eniManager *ec2.ENIManager, cfg settings) {
+	fs := flag.NewFlagSet("preflight", flag.ExitOnError)
+	operations := fs.String("operations", strings.Join(ec2.PreflightOperations, ","), "comma-separated ENIManager operations to check")
+	subnetID := fs.String("subnet", cfg.SubnetID, "subnet the ENIs will be created in")
+	securityGroups := fs.String("security-groups", strings.Join(cfg.SecurityGroupIDs, ","), "comma-separated security groups the ENIs will use")
+	eniID := fs.String("eni", "", "existing ENI to check ENI-level permissions against")
+	instanceID := fs.String("instance", cfg.InstanceID, "instance the ENIs will be attached to")
+	attachmentID := fs.String("attachment", "", "existing attachment to check detach permission against")
+	trunkENIID := fs.String("trunk", "", "existing trunk ENI to check branch association permissions against")
+	associationID := fs.String("association", "", "existing branch association to check disassociate permission against")
+	fs.Parse(args)
+
+	target := ec2.PreflightTarget{
+		SubnetID:      *subnetID,
+		ENIID:         *eniID,
+		InstanceID:    *instanceID,
+		AttachmentID:  *attachmentID,
+		TrunkENIID:    *trunkENIID,
+		AssociationID: *associationID,
+	}
+	if *securityGroups != "" {
+		target.SecurityGroupIDs = strings.Split(*securityGroups, ",")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	checks, err := eniManager.Preflight(ctx, strings.Split(*operations, ","), target)
+	if err != nil {
+		fatal("Preflight failed", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "STATUS\tACTION\tOPERATIONS\tDETAIL")
+	denied := false
+	for _, check := range checks {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", check.Status, check.Action, strings.Join(check.Operations, ","), check.Error)
+		denied = denied || check.Status == ec2.PermissionDenied
+	}
+	w.Flush()
+
+	if denied {
+		exit(1)
+	}
+}
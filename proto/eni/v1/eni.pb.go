@@ -0,0 +1,1741 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.35.1
+// 	protoc        (unknown)
+// source: eni/v1/eni.proto
+
+package eniv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ENIEvent_Type int32
+
+const (
+	ENIEvent_TYPE_UNSPECIFIED             ENIEvent_Type = 0
+	ENIEvent_TYPE_CREATED                 ENIEvent_Type = 1
+	ENIEvent_TYPE_DELETED                 ENIEvent_Type = 2
+	ENIEvent_TYPE_STATUS_CHANGED          ENIEvent_Type = 3
+	ENIEvent_TYPE_SYNC                    ENIEvent_Type = 4
+	ENIEvent_TYPE_ATTACHED                ENIEvent_Type = 5
+	ENIEvent_TYPE_DETACHED                ENIEvent_Type = 6
+	ENIEvent_TYPE_IPS_ADDED               ENIEvent_Type = 7
+	ENIEvent_TYPE_IPS_REMOVED             ENIEvent_Type = 8
+	ENIEvent_TYPE_TAGS_CHANGED            ENIEvent_Type = 9
+	ENIEvent_TYPE_SECURITY_GROUPS_CHANGED ENIEvent_Type = 10
+)
+
+// Enum value maps for ENIEvent_Type.
+var (
+	ENIEvent_Type_name = map[int32]string{
+		0:  "TYPE_UNSPECIFIED",
+		1:  "TYPE_CREATED",
+		2:  "TYPE_DELETED",
+		3:  "TYPE_STATUS_CHANGED",
+		4:  "TYPE_SYNC",
+		5:  "TYPE_ATTACHED",
+		6:  "TYPE_DETACHED",
+		7:  "TYPE_IPS_ADDED",
+		8:  "TYPE_IPS_REMOVED",
+		9:  "TYPE_TAGS_CHANGED",
+		10: "TYPE_SECURITY_GROUPS_CHANGED",
+	}
+	ENIEvent_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED":             0,
+		"TYPE_CREATED":                 1,
+		"TYPE_DELETED":                 2,
+		"TYPE_STATUS_CHANGED":          3,
+		"TYPE_SYNC":                    4,
+		"TYPE_ATTACHED":                5,
+		"TYPE_DETACHED":                6,
+		"TYPE_IPS_ADDED":               7,
+		"TYPE_IPS_REMOVED":             8,
+		"TYPE_TAGS_CHANGED":            9,
+		"TYPE_SECURITY_GROUPS_CHANGED": 10,
+	}
+)
+
+func (x ENIEvent_Type) Enum() *ENIEvent_Type {
+	p := new(ENIEvent_Type)
+	*p = x
+	return p
+}
+
+func (x ENIEvent_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ENIEvent_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_eni_v1_eni_proto_enumTypes[0].Descriptor()
+}
+
+func (ENIEvent_Type) Type() protoreflect.EnumType {
+	return &file_eni_v1_eni_proto_enumTypes[0]
+}
+
+func (x ENIEvent_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ENIEvent_Type.Descriptor instead.
+func (ENIEvent_Type) EnumDescriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{20, 0}
+}
+
+// Filter is an EC2 DescribeNetworkInterfaces filter, e.g. subnet-id.
+type Filter struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name   string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+}
+
+func (x *Filter) Reset() {
+	*x = Filter{}
+	mi := &file_eni_v1_eni_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Filter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Filter) ProtoMessage() {}
+
+func (x *Filter) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Filter.ProtoReflect.Descriptor instead.
+func (*Filter) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Filter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Filter) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type Attachment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	InstanceId  string `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	DeviceIndex int32  `protobuf:"varint,3,opt,name=device_index,json=deviceIndex,proto3" json:"device_index,omitempty"`
+	Status      string `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+}
+
+func (x *Attachment) Reset() {
+	*x = Attachment{}
+	mi := &file_eni_v1_eni_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Attachment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Attachment) ProtoMessage() {}
+
+func (x *Attachment) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Attachment.ProtoReflect.Descriptor instead.
+func (*Attachment) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Attachment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Attachment) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *Attachment) GetDeviceIndex() int32 {
+	if x != nil {
+		return x.DeviceIndex
+	}
+	return 0
+}
+
+func (x *Attachment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type ENI struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id               string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	SubnetId         string            `protobuf:"bytes,2,opt,name=subnet_id,json=subnetId,proto3" json:"subnet_id,omitempty"`
+	VpcId            string            `protobuf:"bytes,3,opt,name=vpc_id,json=vpcId,proto3" json:"vpc_id,omitempty"`
+	AvailabilityZone string            `protobuf:"bytes,4,opt,name=availability_zone,json=availabilityZone,proto3" json:"availability_zone,omitempty"`
+	Description      string            `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	Status           string            `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	InterfaceType    string            `protobuf:"bytes,7,opt,name=interface_type,json=interfaceType,proto3" json:"interface_type,omitempty"`
+	PrivateIp        string            `protobuf:"bytes,8,opt,name=private_ip,json=privateIp,proto3" json:"private_ip,omitempty"`
+	PrivateIps       []string          `protobuf:"bytes,9,rep,name=private_ips,json=privateIps,proto3" json:"private_ips,omitempty"`
+	Ipv6Addresses    []string          `protobuf:"bytes,10,rep,name=ipv6_addresses,json=ipv6Addresses,proto3" json:"ipv6_addresses,omitempty"`
+	SecurityGroupIds []string          `protobuf:"bytes,11,rep,name=security_group_ids,json=securityGroupIds,proto3" json:"security_group_ids,omitempty"`
+	Tags             map[string]string `protobuf:"bytes,12,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Attachment       *Attachment       `protobuf:"bytes,13,opt,name=attachment,proto3" json:"attachment,omitempty"`
+}
+
+func (x *ENI) Reset() {
+	*x = ENI{}
+	mi := &file_eni_v1_eni_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ENI) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ENI) ProtoMessage() {}
+
+func (x *ENI) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ENI.ProtoReflect.Descriptor instead.
+func (*ENI) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ENI) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ENI) GetSubnetId() string {
+	if x != nil {
+		return x.SubnetId
+	}
+	return ""
+}
+
+func (x *ENI) GetVpcId() string {
+	if x != nil {
+		return x.VpcId
+	}
+	return ""
+}
+
+func (x *ENI) GetAvailabilityZone() string {
+	if x != nil {
+		return x.AvailabilityZone
+	}
+	return ""
+}
+
+func (x *ENI) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ENI) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ENI) GetInterfaceType() string {
+	if x != nil {
+		return x.InterfaceType
+	}
+	return ""
+}
+
+func (x *ENI) GetPrivateIp() string {
+	if x != nil {
+		return x.PrivateIp
+	}
+	return ""
+}
+
+func (x *ENI) GetPrivateIps() []string {
+	if x != nil {
+		return x.PrivateIps
+	}
+	return nil
+}
+
+func (x *ENI) GetIpv6Addresses() []string {
+	if x != nil {
+		return x.Ipv6Addresses
+	}
+	return nil
+}
+
+func (x *ENI) GetSecurityGroupIds() []string {
+	if x != nil {
+		return x.SecurityGroupIds
+	}
+	return nil
+}
+
+func (x *ENI) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ENI) GetAttachment() *Attachment {
+	if x != nil {
+		return x.Attachment
+	}
+	return nil
+}
+
+type Subnet struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id                      string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	VpcId                   string `protobuf:"bytes,2,opt,name=vpc_id,json=vpcId,proto3" json:"vpc_id,omitempty"`
+	AvailabilityZone        string `protobuf:"bytes,3,opt,name=availability_zone,json=availabilityZone,proto3" json:"availability_zone,omitempty"`
+	CidrBlock               string `protobuf:"bytes,4,opt,name=cidr_block,json=cidrBlock,proto3" json:"cidr_block,omitempty"`
+	AvailableIpAddressCount int32  `protobuf:"varint,5,opt,name=available_ip_address_count,json=availableIpAddressCount,proto3" json:"available_ip_address_count,omitempty"`
+}
+
+func (x *Subnet) Reset() {
+	*x = Subnet{}
+	mi := &file_eni_v1_eni_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Subnet) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Subnet) ProtoMessage() {}
+
+func (x *Subnet) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Subnet.ProtoReflect.Descriptor instead.
+func (*Subnet) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Subnet) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Subnet) GetVpcId() string {
+	if x != nil {
+		return x.VpcId
+	}
+	return ""
+}
+
+func (x *Subnet) GetAvailabilityZone() string {
+	if x != nil {
+		return x.AvailabilityZone
+	}
+	return ""
+}
+
+func (x *Subnet) GetCidrBlock() string {
+	if x != nil {
+		return x.CidrBlock
+	}
+	return ""
+}
+
+func (x *Subnet) GetAvailableIpAddressCount() int32 {
+	if x != nil {
+		return x.AvailableIpAddressCount
+	}
+	return 0
+}
+
+type CreateENIRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubnetId         string            `protobuf:"bytes,1,opt,name=subnet_id,json=subnetId,proto3" json:"subnet_id,omitempty"`
+	Description      string            `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	SecurityGroupIds []string          `protobuf:"bytes,3,rep,name=security_group_ids,json=securityGroupIds,proto3" json:"security_group_ids,omitempty"`
+	PrivateIpCount   int32             `protobuf:"varint,4,opt,name=private_ip_count,json=privateIpCount,proto3" json:"private_ip_count,omitempty"`
+	Ipv6AddressCount int32             `protobuf:"varint,5,opt,name=ipv6_address_count,json=ipv6AddressCount,proto3" json:"ipv6_address_count,omitempty"`
+	Tags             map[string]string `protobuf:"bytes,6,rep,name=tags,proto3" json:"tags,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// efa, efa-only, trunk or branch; empty for a standard ENI.
+	InterfaceType string `protobuf:"bytes,7,opt,name=interface_type,json=interfaceType,proto3" json:"interface_type,omitempty"`
+}
+
+func (x *CreateENIRequest) Reset() {
+	*x = CreateENIRequest{}
+	mi := &file_eni_v1_eni_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateENIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateENIRequest) ProtoMessage() {}
+
+func (x *CreateENIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateENIRequest.ProtoReflect.Descriptor instead.
+func (*CreateENIRequest) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CreateENIRequest) GetSubnetId() string {
+	if x != nil {
+		return x.SubnetId
+	}
+	return ""
+}
+
+func (x *CreateENIRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *CreateENIRequest) GetSecurityGroupIds() []string {
+	if x != nil {
+		return x.SecurityGroupIds
+	}
+	return nil
+}
+
+func (x *CreateENIRequest) GetPrivateIpCount() int32 {
+	if x != nil {
+		return x.PrivateIpCount
+	}
+	return 0
+}
+
+func (x *CreateENIRequest) GetIpv6AddressCount() int32 {
+	if x != nil {
+		return x.Ipv6AddressCount
+	}
+	return 0
+}
+
+func (x *CreateENIRequest) GetTags() map[string]string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *CreateENIRequest) GetInterfaceType() string {
+	if x != nil {
+		return x.InterfaceType
+	}
+	return ""
+}
+
+type AttachENIRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EniId       string `protobuf:"bytes,1,opt,name=eni_id,json=eniId,proto3" json:"eni_id,omitempty"`
+	InstanceId  string `protobuf:"bytes,2,opt,name=instance_id,json=instanceId,proto3" json:"instance_id,omitempty"`
+	DeviceIndex int32  `protobuf:"varint,3,opt,name=device_index,json=deviceIndex,proto3" json:"device_index,omitempty"`
+	// The network card on multi-card instances.
+	NetworkCardIndex *int32 `protobuf:"varint,4,opt,name=network_card_index,json=networkCardIndex,proto3,oneof" json:"network_card_index,omitempty"`
+}
+
+func (x *AttachENIRequest) Reset() {
+	*x = AttachENIRequest{}
+	mi := &file_eni_v1_eni_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachENIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachENIRequest) ProtoMessage() {}
+
+func (x *AttachENIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachENIRequest.ProtoReflect.Descriptor instead.
+func (*AttachENIRequest) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *AttachENIRequest) GetEniId() string {
+	if x != nil {
+		return x.EniId
+	}
+	return ""
+}
+
+func (x *AttachENIRequest) GetInstanceId() string {
+	if x != nil {
+		return x.InstanceId
+	}
+	return ""
+}
+
+func (x *AttachENIRequest) GetDeviceIndex() int32 {
+	if x != nil {
+		return x.DeviceIndex
+	}
+	return 0
+}
+
+func (x *AttachENIRequest) GetNetworkCardIndex() int32 {
+	if x != nil && x.NetworkCardIndex != nil {
+		return *x.NetworkCardIndex
+	}
+	return 0
+}
+
+type AttachENIResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AttachmentId string `protobuf:"bytes,1,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+}
+
+func (x *AttachENIResponse) Reset() {
+	*x = AttachENIResponse{}
+	mi := &file_eni_v1_eni_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AttachENIResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AttachENIResponse) ProtoMessage() {}
+
+func (x *AttachENIResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AttachENIResponse.ProtoReflect.Descriptor instead.
+func (*AttachENIResponse) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *AttachENIResponse) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+type DetachENIRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AttachmentId string `protobuf:"bytes,1,opt,name=attachment_id,json=attachmentId,proto3" json:"attachment_id,omitempty"`
+	Force        bool   `protobuf:"varint,2,opt,name=force,proto3" json:"force,omitempty"`
+}
+
+func (x *DetachENIRequest) Reset() {
+	*x = DetachENIRequest{}
+	mi := &file_eni_v1_eni_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetachENIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetachENIRequest) ProtoMessage() {}
+
+func (x *DetachENIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetachENIRequest.ProtoReflect.Descriptor instead.
+func (*DetachENIRequest) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DetachENIRequest) GetAttachmentId() string {
+	if x != nil {
+		return x.AttachmentId
+	}
+	return ""
+}
+
+func (x *DetachENIRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type DetachENIResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DetachENIResponse) Reset() {
+	*x = DetachENIResponse{}
+	mi := &file_eni_v1_eni_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DetachENIResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DetachENIResponse) ProtoMessage() {}
+
+func (x *DetachENIResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DetachENIResponse.ProtoReflect.Descriptor instead.
+func (*DetachENIResponse) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{8}
+}
+
+type DeleteENIRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EniId string `protobuf:"bytes,1,opt,name=eni_id,json=eniId,proto3" json:"eni_id,omitempty"`
+}
+
+func (x *DeleteENIRequest) Reset() {
+	*x = DeleteENIRequest{}
+	mi := &file_eni_v1_eni_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteENIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteENIRequest) ProtoMessage() {}
+
+func (x *DeleteENIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteENIRequest.ProtoReflect.Descriptor instead.
+func (*DeleteENIRequest) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteENIRequest) GetEniId() string {
+	if x != nil {
+		return x.EniId
+	}
+	return ""
+}
+
+type DeleteENIResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *DeleteENIResponse) Reset() {
+	*x = DeleteENIResponse{}
+	mi := &file_eni_v1_eni_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteENIResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteENIResponse) ProtoMessage() {}
+
+func (x *DeleteENIResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteENIResponse.ProtoReflect.Descriptor instead.
+func (*DeleteENIResponse) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{10}
+}
+
+type ModifyENIRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EniId            string   `protobuf:"bytes,1,opt,name=eni_id,json=eniId,proto3" json:"eni_id,omitempty"`
+	Description      *string  `protobuf:"bytes,2,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	SecurityGroupIds []string `protobuf:"bytes,3,rep,name=security_group_ids,json=securityGroupIds,proto3" json:"security_group_ids,omitempty"`
+}
+
+func (x *ModifyENIRequest) Reset() {
+	*x = ModifyENIRequest{}
+	mi := &file_eni_v1_eni_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModifyENIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifyENIRequest) ProtoMessage() {}
+
+func (x *ModifyENIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifyENIRequest.ProtoReflect.Descriptor instead.
+func (*ModifyENIRequest) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ModifyENIRequest) GetEniId() string {
+	if x != nil {
+		return x.EniId
+	}
+	return ""
+}
+
+func (x *ModifyENIRequest) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *ModifyENIRequest) GetSecurityGroupIds() []string {
+	if x != nil {
+		return x.SecurityGroupIds
+	}
+	return nil
+}
+
+type ModifyENIResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ModifyENIResponse) Reset() {
+	*x = ModifyENIResponse{}
+	mi := &file_eni_v1_eni_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ModifyENIResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ModifyENIResponse) ProtoMessage() {}
+
+func (x *ModifyENIResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ModifyENIResponse.ProtoReflect.Descriptor instead.
+func (*ModifyENIResponse) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{12}
+}
+
+// AssignIPsRequest sets exactly one of count or addresses.
+type AssignIPsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EniId     string   `protobuf:"bytes,1,opt,name=eni_id,json=eniId,proto3" json:"eni_id,omitempty"`
+	Count     int32    `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Addresses []string `protobuf:"bytes,3,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (x *AssignIPsRequest) Reset() {
+	*x = AssignIPsRequest{}
+	mi := &file_eni_v1_eni_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignIPsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignIPsRequest) ProtoMessage() {}
+
+func (x *AssignIPsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignIPsRequest.ProtoReflect.Descriptor instead.
+func (*AssignIPsRequest) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *AssignIPsRequest) GetEniId() string {
+	if x != nil {
+		return x.EniId
+	}
+	return ""
+}
+
+func (x *AssignIPsRequest) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *AssignIPsRequest) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type AssignIPsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *AssignIPsResponse) Reset() {
+	*x = AssignIPsResponse{}
+	mi := &file_eni_v1_eni_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AssignIPsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AssignIPsResponse) ProtoMessage() {}
+
+func (x *AssignIPsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AssignIPsResponse.ProtoReflect.Descriptor instead.
+func (*AssignIPsResponse) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{14}
+}
+
+type UnassignIPsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EniId     string   `protobuf:"bytes,1,opt,name=eni_id,json=eniId,proto3" json:"eni_id,omitempty"`
+	Addresses []string `protobuf:"bytes,2,rep,name=addresses,proto3" json:"addresses,omitempty"`
+}
+
+func (x *UnassignIPsRequest) Reset() {
+	*x = UnassignIPsRequest{}
+	mi := &file_eni_v1_eni_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignIPsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignIPsRequest) ProtoMessage() {}
+
+func (x *UnassignIPsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignIPsRequest.ProtoReflect.Descriptor instead.
+func (*UnassignIPsRequest) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *UnassignIPsRequest) GetEniId() string {
+	if x != nil {
+		return x.EniId
+	}
+	return ""
+}
+
+func (x *UnassignIPsRequest) GetAddresses() []string {
+	if x != nil {
+		return x.Addresses
+	}
+	return nil
+}
+
+type UnassignIPsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *UnassignIPsResponse) Reset() {
+	*x = UnassignIPsResponse{}
+	mi := &file_eni_v1_eni_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnassignIPsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnassignIPsResponse) ProtoMessage() {}
+
+func (x *UnassignIPsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnassignIPsResponse.ProtoReflect.Descriptor instead.
+func (*UnassignIPsResponse) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{16}
+}
+
+type GetENIRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	EniId string `protobuf:"bytes,1,opt,name=eni_id,json=eniId,proto3" json:"eni_id,omitempty"`
+}
+
+func (x *GetENIRequest) Reset() {
+	*x = GetENIRequest{}
+	mi := &file_eni_v1_eni_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetENIRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetENIRequest) ProtoMessage() {}
+
+func (x *GetENIRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetENIRequest.ProtoReflect.Descriptor instead.
+func (*GetENIRequest) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GetENIRequest) GetEniId() string {
+	if x != nil {
+		return x.EniId
+	}
+	return ""
+}
+
+type ListENIsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filters []*Filter `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+}
+
+func (x *ListENIsRequest) Reset() {
+	*x = ListENIsRequest{}
+	mi := &file_eni_v1_eni_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListENIsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListENIsRequest) ProtoMessage() {}
+
+func (x *ListENIsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListENIsRequest.ProtoReflect.Descriptor instead.
+func (*ListENIsRequest) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListENIsRequest) GetFilters() []*Filter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+type WatchENIsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Filters []*Filter `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+	// How often to poll EC2, in seconds. Defaults to 30.
+	IntervalSeconds int32 `protobuf:"varint,2,opt,name=interval_seconds,json=intervalSeconds,proto3" json:"interval_seconds,omitempty"`
+	// How often to re-send a SYNC event for every ENI, in seconds. Zero
+	// disables resync; the initial state is always sent.
+	ResyncIntervalSeconds int32 `protobuf:"varint,3,opt,name=resync_interval_seconds,json=resyncIntervalSeconds,proto3" json:"resync_interval_seconds,omitempty"`
+}
+
+func (x *WatchENIsRequest) Reset() {
+	*x = WatchENIsRequest{}
+	mi := &file_eni_v1_eni_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchENIsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchENIsRequest) ProtoMessage() {}
+
+func (x *WatchENIsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchENIsRequest.ProtoReflect.Descriptor instead.
+func (*WatchENIsRequest) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *WatchENIsRequest) GetFilters() []*Filter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *WatchENIsRequest) GetIntervalSeconds() int32 {
+	if x != nil {
+		return x.IntervalSeconds
+	}
+	return 0
+}
+
+func (x *WatchENIsRequest) GetResyncIntervalSeconds() int32 {
+	if x != nil {
+		return x.ResyncIntervalSeconds
+	}
+	return 0
+}
+
+type ENIEvent struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Type ENIEvent_Type `protobuf:"varint,1,opt,name=type,proto3,enum=eni.v1.ENIEvent_Type" json:"type,omitempty"`
+	// The latest state, or the last seen state for TYPE_DELETED.
+	Eni  *ENI                   `protobuf:"bytes,2,opt,name=eni,proto3" json:"eni,omitempty"`
+	Time *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// The state before the change, for changes to an existing ENI.
+	Previous *ENI `protobuf:"bytes,4,opt,name=previous,proto3" json:"previous,omitempty"`
+	// IPs, "key=value" tags or security group IDs added and removed.
+	Added   []string `protobuf:"bytes,5,rep,name=added,proto3" json:"added,omitempty"`
+	Removed []string `protobuf:"bytes,6,rep,name=removed,proto3" json:"removed,omitempty"`
+}
+
+func (x *ENIEvent) Reset() {
+	*x = ENIEvent{}
+	mi := &file_eni_v1_eni_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ENIEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ENIEvent) ProtoMessage() {}
+
+func (x *ENIEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ENIEvent.ProtoReflect.Descriptor instead.
+func (*ENIEvent) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ENIEvent) GetType() ENIEvent_Type {
+	if x != nil {
+		return x.Type
+	}
+	return ENIEvent_TYPE_UNSPECIFIED
+}
+
+func (x *ENIEvent) GetEni() *ENI {
+	if x != nil {
+		return x.Eni
+	}
+	return nil
+}
+
+func (x *ENIEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ENIEvent) GetPrevious() *ENI {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+func (x *ENIEvent) GetAdded() []string {
+	if x != nil {
+		return x.Added
+	}
+	return nil
+}
+
+func (x *ENIEvent) GetRemoved() []string {
+	if x != nil {
+		return x.Removed
+	}
+	return nil
+}
+
+type DescribeSubnetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SubnetId string `protobuf:"bytes,1,opt,name=subnet_id,json=subnetId,proto3" json:"subnet_id,omitempty"`
+}
+
+func (x *DescribeSubnetRequest) Reset() {
+	*x = DescribeSubnetRequest{}
+	mi := &file_eni_v1_eni_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DescribeSubnetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DescribeSubnetRequest) ProtoMessage() {}
+
+func (x *DescribeSubnetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_eni_v1_eni_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DescribeSubnetRequest.ProtoReflect.Descriptor instead.
+func (*DescribeSubnetRequest) Descriptor() ([]byte, []int) {
+	return file_eni_v1_eni_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DescribeSubnetRequest) GetSubnetId() string {
+	if x != nil {
+		return x.SubnetId
+	}
+	return ""
+}
+
+var File_eni_v1_eni_proto protoreflect.FileDescriptor
+
+var file_eni_v1_eni_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x65, 0x6e, 0x69, 0x2f, 0x76, 0x31, 0x2f, 0x65, 0x6e, 0x69, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x06, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x34, 0x0a, 0x06, 0x46,
+	0x69, 0x6c, 0x74, 0x65, 0x72, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x22, 0x78, 0x0a, 0x0a, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x12,
+	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
+	0x1f, 0x0a, 0x0b, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64,
+	0x12, 0x21, 0x0a, 0x0c, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e,
+	0x64, 0x65, 0x78, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x22, 0x84, 0x04, 0x0a, 0x03,
+	0x45, 0x4e, 0x49, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x02, 0x69, 0x64, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x49, 0x64,
+	0x12, 0x15, 0x0a, 0x06, 0x76, 0x70, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x70, 0x63, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x10, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79,
+	0x5a, 0x6f, 0x6e, 0x65, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x25,
+	0x0a, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70, 0x65,
+	0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63,
+	0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x5f, 0x69, 0x70, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x70, 0x72, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x49, 0x70, 0x12, 0x1f, 0x0a, 0x0b, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f,
+	0x69, 0x70, 0x73, 0x18, 0x09, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x70, 0x72, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x49, 0x70, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x69, 0x70, 0x76, 0x36, 0x5f, 0x61, 0x64,
+	0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0d, 0x69,
+	0x70, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x2c, 0x0a, 0x12,
+	0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69,
+	0x64, 0x73, 0x18, 0x0b, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69,
+	0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x73, 0x12, 0x29, 0x0a, 0x04, 0x74, 0x61,
+	0x67, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x4e, 0x49, 0x2e, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52,
+	0x04, 0x74, 0x61, 0x67, 0x73, 0x12, 0x32, 0x0a, 0x0a, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d,
+	0x65, 0x6e, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x65, 0x6e, 0x69, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x0a, 0x61,
+	0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0xb8, 0x01, 0x0a, 0x06, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x15, 0x0a,
+	0x06, 0x76, 0x70, 0x63, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76,
+	0x70, 0x63, 0x49, 0x64, 0x12, 0x2b, 0x0a, 0x11, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69,
+	0x6c, 0x69, 0x74, 0x79, 0x5f, 0x7a, 0x6f, 0x6e, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x10, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x69, 0x6c, 0x69, 0x74, 0x79, 0x5a, 0x6f, 0x6e,
+	0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x69, 0x64, 0x72, 0x5f, 0x62, 0x6c, 0x6f, 0x63, 0x6b, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x69, 0x64, 0x72, 0x42, 0x6c, 0x6f, 0x63, 0x6b,
+	0x12, 0x3b, 0x0a, 0x1a, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x5f, 0x69, 0x70,
+	0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x17, 0x61, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x49,
+	0x70, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x22, 0xef, 0x02,
+	0x0a, 0x10, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x4e, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x49, 0x64, 0x12,
+	0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x73,
+	0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x73, 0x12,
+	0x28, 0x0a, 0x10, 0x70, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x5f, 0x69, 0x70, 0x5f, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x70, 0x72, 0x69, 0x76, 0x61,
+	0x74, 0x65, 0x49, 0x70, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x2c, 0x0a, 0x12, 0x69, 0x70, 0x76,
+	0x36, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10, 0x69, 0x70, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x36, 0x0a, 0x04, 0x74, 0x61, 0x67, 0x73, 0x18,
+	0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43,
+	0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x4e, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
+	0x54, 0x61, 0x67, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x74, 0x61, 0x67, 0x73, 0x12,
+	0x25, 0x0a, 0x0e, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61, 0x63, 0x65, 0x5f, 0x74, 0x79, 0x70,
+	0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x66, 0x61,
+	0x63, 0x65, 0x54, 0x79, 0x70, 0x65, 0x1a, 0x37, 0x0a, 0x09, 0x54, 0x61, 0x67, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0xb7, 0x01, 0x0a, 0x10, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x45, 0x4e, 0x49, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x65, 0x6e, 0x69, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6e, 0x69, 0x49, 0x64, 0x12, 0x1f, 0x0a, 0x0b, 0x69,
+	0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0a, 0x69, 0x6e, 0x73, 0x74, 0x61, 0x6e, 0x63, 0x65, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c,
+	0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x03, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x0b, 0x64, 0x65, 0x76, 0x69, 0x63, 0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12,
+	0x31, 0x0a, 0x12, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x63, 0x61, 0x72, 0x64, 0x5f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x48, 0x00, 0x52, 0x10, 0x6e,
+	0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x43, 0x61, 0x72, 0x64, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x88,
+	0x01, 0x01, 0x42, 0x15, 0x0a, 0x13, 0x5f, 0x6e, 0x65, 0x74, 0x77, 0x6f, 0x72, 0x6b, 0x5f, 0x63,
+	0x61, 0x72, 0x64, 0x5f, 0x69, 0x6e, 0x64, 0x65, 0x78, 0x22, 0x38, 0x0a, 0x11, 0x41, 0x74, 0x74,
+	0x61, 0x63, 0x68, 0x45, 0x4e, 0x49, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23,
+	0x0a, 0x0d, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e,
+	0x74, 0x49, 0x64, 0x22, 0x4d, 0x0a, 0x10, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x45, 0x4e, 0x49,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x74, 0x74, 0x61, 0x63,
+	0x68, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x61, 0x74, 0x74, 0x61, 0x63, 0x68, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05,
+	0x66, 0x6f, 0x72, 0x63, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x05, 0x66, 0x6f, 0x72,
+	0x63, 0x65, 0x22, 0x13, 0x0a, 0x11, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x45, 0x4e, 0x49, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x29, 0x0a, 0x10, 0x44, 0x65, 0x6c, 0x65, 0x74,
+	0x65, 0x45, 0x4e, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x65,
+	0x6e, 0x69, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6e, 0x69,
+	0x49, 0x64, 0x22, 0x13, 0x0a, 0x11, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x4e, 0x49, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x8e, 0x01, 0x0a, 0x10, 0x4d, 0x6f, 0x64, 0x69,
+	0x66, 0x79, 0x45, 0x4e, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06,
+	0x65, 0x6e, 0x69, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6e,
+	0x69, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63,
+	0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x88, 0x01, 0x01, 0x12, 0x2c, 0x0a, 0x12, 0x73, 0x65,
+	0x63, 0x75, 0x72, 0x69, 0x74, 0x79, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x69, 0x64, 0x73,
+	0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52, 0x10, 0x73, 0x65, 0x63, 0x75, 0x72, 0x69, 0x74, 0x79,
+	0x47, 0x72, 0x6f, 0x75, 0x70, 0x49, 0x64, 0x73, 0x42, 0x0e, 0x0a, 0x0c, 0x5f, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x22, 0x13, 0x0a, 0x11, 0x4d, 0x6f, 0x64, 0x69,
+	0x66, 0x79, 0x45, 0x4e, 0x49, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5d, 0x0a,
+	0x10, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x49, 0x50, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x15, 0x0a, 0x06, 0x65, 0x6e, 0x69, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x6e, 0x69, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1c,
+	0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x13, 0x0a, 0x11,
+	0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x49, 0x50, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x22, 0x49, 0x0a, 0x12, 0x55, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x49, 0x50, 0x73,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x65, 0x6e, 0x69, 0x5f, 0x69,
+	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6e, 0x69, 0x49, 0x64, 0x12, 0x1c,
+	0x0a, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x09, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x22, 0x15, 0x0a, 0x13,
+	0x55, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x49, 0x50, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x22, 0x26, 0x0a, 0x0d, 0x47, 0x65, 0x74, 0x45, 0x4e, 0x49, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x65, 0x6e, 0x69, 0x5f, 0x69, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x6e, 0x69, 0x49, 0x64, 0x22, 0x3b, 0x0a, 0x0f, 0x4c,
+	0x69, 0x73, 0x74, 0x45, 0x4e, 0x49, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28,
+	0x0a, 0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32,
+	0x0e, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52,
+	0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x22, 0x9f, 0x01, 0x0a, 0x10, 0x57, 0x61, 0x74,
+	0x63, 0x68, 0x45, 0x4e, 0x49, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x28, 0x0a,
+	0x07, 0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e,
+	0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x46, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x52, 0x07,
+	0x66, 0x69, 0x6c, 0x74, 0x65, 0x72, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e,
+	0x64, 0x73, 0x12, 0x36, 0x0a, 0x17, 0x72, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x5f, 0x69, 0x6e, 0x74,
+	0x65, 0x72, 0x76, 0x61, 0x6c, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x05, 0x52, 0x15, 0x72, 0x65, 0x73, 0x79, 0x6e, 0x63, 0x49, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x22, 0xd1, 0x03, 0x0a, 0x08, 0x45,
+	0x4e, 0x49, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x29, 0x0a, 0x04, 0x74, 0x79, 0x70, 0x65, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45,
+	0x4e, 0x49, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x2e, 0x54, 0x79, 0x70, 0x65, 0x52, 0x04, 0x74, 0x79,
+	0x70, 0x65, 0x12, 0x1d, 0x0a, 0x03, 0x65, 0x6e, 0x69, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0b, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4e, 0x49, 0x52, 0x03, 0x65, 0x6e,
+	0x69, 0x12, 0x2e, 0x0a, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x04, 0x74, 0x69, 0x6d,
+	0x65, 0x12, 0x27, 0x0a, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4e, 0x49,
+	0x52, 0x08, 0x70, 0x72, 0x65, 0x76, 0x69, 0x6f, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x61, 0x64,
+	0x64, 0x65, 0x64, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x61, 0x64, 0x64, 0x65, 0x64,
+	0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x09, 0x52, 0x07, 0x72, 0x65, 0x6d, 0x6f, 0x76, 0x65, 0x64, 0x22, 0xf1, 0x01, 0x0a, 0x04, 0x54,
+	0x79, 0x70, 0x65, 0x12, 0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x10, 0x0a, 0x0c, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x17, 0x0a,
+	0x13, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x43, 0x48, 0x41,
+	0x4e, 0x47, 0x45, 0x44, 0x10, 0x03, 0x12, 0x0d, 0x0a, 0x09, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x53,
+	0x59, 0x4e, 0x43, 0x10, 0x04, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x41, 0x54,
+	0x54, 0x41, 0x43, 0x48, 0x45, 0x44, 0x10, 0x05, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x59, 0x50, 0x45,
+	0x5f, 0x44, 0x45, 0x54, 0x41, 0x43, 0x48, 0x45, 0x44, 0x10, 0x06, 0x12, 0x12, 0x0a, 0x0e, 0x54,
+	0x59, 0x50, 0x45, 0x5f, 0x49, 0x50, 0x53, 0x5f, 0x41, 0x44, 0x44, 0x45, 0x44, 0x10, 0x07, 0x12,
+	0x14, 0x0a, 0x10, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x49, 0x50, 0x53, 0x5f, 0x52, 0x45, 0x4d, 0x4f,
+	0x56, 0x45, 0x44, 0x10, 0x08, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x41,
+	0x47, 0x53, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x09, 0x12, 0x20, 0x0a, 0x1c,
+	0x54, 0x59, 0x50, 0x45, 0x5f, 0x53, 0x45, 0x43, 0x55, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x47, 0x52,
+	0x4f, 0x55, 0x50, 0x53, 0x5f, 0x43, 0x48, 0x41, 0x4e, 0x47, 0x45, 0x44, 0x10, 0x0a, 0x22, 0x34,
+	0x0a, 0x15, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1b, 0x0a, 0x09, 0x73, 0x75, 0x62, 0x6e, 0x65,
+	0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x73, 0x75, 0x62, 0x6e,
+	0x65, 0x74, 0x49, 0x64, 0x32, 0xdc, 0x06, 0x0a, 0x0a, 0x45, 0x4e, 0x49, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x12, 0x32, 0x0a, 0x09, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x45, 0x4e, 0x49,
+	0x12, 0x18, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x45, 0x4e, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x65, 0x6e, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4e, 0x49, 0x12, 0x40, 0x0a, 0x09, 0x41, 0x74, 0x74, 0x61, 0x63,
+	0x68, 0x45, 0x4e, 0x49, 0x12, 0x18, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74,
+	0x74, 0x61, 0x63, 0x68, 0x45, 0x4e, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x74, 0x74, 0x61, 0x63, 0x68, 0x45, 0x4e,
+	0x49, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x44, 0x65, 0x74,
+	0x61, 0x63, 0x68, 0x45, 0x4e, 0x49, 0x12, 0x18, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x65, 0x74, 0x61, 0x63, 0x68, 0x45, 0x4e, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x19, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x74, 0x61, 0x63, 0x68,
+	0x45, 0x4e, 0x49, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a, 0x09, 0x44,
+	0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x4e, 0x49, 0x12, 0x18, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x45, 0x4e, 0x49, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x19, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x6c, 0x65,
+	0x74, 0x65, 0x45, 0x4e, 0x49, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x40, 0x0a,
+	0x09, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x45, 0x4e, 0x49, 0x12, 0x18, 0x2e, 0x65, 0x6e, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f, 0x64, 0x69, 0x66, 0x79, 0x45, 0x4e, 0x49, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x6f,
+	0x64, 0x69, 0x66, 0x79, 0x45, 0x4e, 0x49, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x47, 0x0a, 0x10, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65,
+	0x49, 0x50, 0x73, 0x12, 0x18, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x73, 0x73,
+	0x69, 0x67, 0x6e, 0x49, 0x50, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e,
+	0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x49, 0x50, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4d, 0x0a, 0x12, 0x55, 0x6e, 0x61, 0x73,
+	0x73, 0x69, 0x67, 0x6e, 0x50, 0x72, 0x69, 0x76, 0x61, 0x74, 0x65, 0x49, 0x50, 0x73, 0x12, 0x1a,
+	0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e,
+	0x49, 0x50, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x65, 0x6e, 0x69,
+	0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x49, 0x50, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x4a, 0x0a, 0x13, 0x41, 0x73, 0x73, 0x69, 0x67,
+	0x6e, 0x49, 0x50, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x18,
+	0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x49, 0x50,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x41, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x49, 0x50, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x50, 0x0a, 0x15, 0x55, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x49,
+	0x50, 0x76, 0x36, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x65, 0x73, 0x12, 0x1a, 0x2e, 0x65,
+	0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x55, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x49, 0x50,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x55, 0x6e, 0x61, 0x73, 0x73, 0x69, 0x67, 0x6e, 0x49, 0x50, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x45, 0x4e, 0x49, 0x12,
+	0x15, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x45, 0x4e, 0x49, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e,
+	0x45, 0x4e, 0x49, 0x12, 0x32, 0x0a, 0x08, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x4e, 0x49, 0x73, 0x12,
+	0x17, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x45, 0x4e, 0x49,
+	0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x0b, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76,
+	0x31, 0x2e, 0x45, 0x4e, 0x49, 0x30, 0x01, 0x12, 0x39, 0x0a, 0x09, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x45, 0x4e, 0x49, 0x73, 0x12, 0x18, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61,
+	0x74, 0x63, 0x68, 0x45, 0x4e, 0x49, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x10,
+	0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x4e, 0x49, 0x45, 0x76, 0x65, 0x6e, 0x74,
+	0x30, 0x01, 0x12, 0x3f, 0x0a, 0x0e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x75,
+	0x62, 0x6e, 0x65, 0x74, 0x12, 0x1d, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x53, 0x75, 0x62, 0x6e, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x0e, 0x2e, 0x65, 0x6e, 0x69, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x62,
+	0x6e, 0x65, 0x74, 0x42, 0x20, 0x5a, 0x1e, 0x65, 0x6e, 0x69, 0x2d, 0x70, 0x72, 0x6f, 0x6a, 0x65,
+	0x63, 0x74, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x6e, 0x69, 0x2f, 0x76, 0x31, 0x3b,
+	0x65, 0x6e, 0x69, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_eni_v1_eni_proto_rawDescOnce sync.Once
+	file_eni_v1_eni_proto_rawDescData = file_eni_v1_eni_proto_rawDesc
+)
+
+func file_eni_v1_eni_proto_rawDescGZIP() []byte {
+	file_eni_v1_eni_proto_rawDescOnce.Do(func() {
+		file_eni_v1_eni_proto_rawDescData = protoimpl.X.CompressGZIP(file_eni_v1_eni_proto_rawDescData)
+	})
+	return file_eni_v1_eni_proto_rawDescData
+}
+
+var file_eni_v1_eni_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_eni_v1_eni_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_eni_v1_eni_proto_goTypes = []any{
+	(ENIEvent_Type)(0),            // 0: eni.v1.ENIEvent.Type
+	(*Filter)(nil),                // 1: eni.v1.Filter
+	(*Attachment)(nil),            // 2: eni.v1.Attachment
+	(*ENI)(nil),                   // 3: eni.v1.ENI
+	(*Subnet)(nil),                // 4: eni.v1.Subnet
+	(*CreateENIRequest)(nil),      // 5: eni.v1.CreateENIRequest
+	(*AttachENIRequest)(nil),      // 6: eni.v1.AttachENIRequest
+	(*AttachENIResponse)(nil),     // 7: eni.v1.AttachENIResponse
+	(*DetachENIRequest)(nil),      // 8: eni.v1.DetachENIRequest
+	(*DetachENIResponse)(nil),     // 9: eni.v1.DetachENIResponse
+	(*DeleteENIRequest)(nil),      // 10: eni.v1.DeleteENIRequest
+	(*DeleteENIResponse)(nil),     // 11: eni.v1.DeleteENIResponse
+	(*ModifyENIRequest)(nil),      // 12: eni.v1.ModifyENIRequest
+	(*ModifyENIResponse)(nil),     // 13: eni.v1.ModifyENIResponse
+	(*AssignIPsRequest)(nil),      // 14: eni.v1.AssignIPsRequest
+	(*AssignIPsResponse)(nil),     // 15: eni.v1.AssignIPsResponse
+	(*UnassignIPsRequest)(nil),    // 16: eni.v1.UnassignIPsRequest
+	(*UnassignIPsResponse)(nil),   // 17: eni.v1.UnassignIPsResponse
+	(*GetENIRequest)(nil),         // 18: eni.v1.GetENIRequest
+	(*ListENIsRequest)(nil),       // 19: eni.v1.ListENIsRequest
+	(*WatchENIsRequest)(nil),      // 20: eni.v1.WatchENIsRequest
+	(*ENIEvent)(nil),              // 21: eni.v1.ENIEvent
+	(*DescribeSubnetRequest)(nil), // 22: eni.v1.DescribeSubnetRequest
+	nil,                           // 23: eni.v1.ENI.TagsEntry
+	nil,                           // 24: eni.v1.CreateENIRequest.TagsEntry
+	(*timestamppb.Timestamp)(nil), // 25: google.protobuf.Timestamp
+}
+var file_eni_v1_eni_proto_depIdxs = []int32{
+	23, // 0: eni.v1.ENI.tags:type_name -> eni.v1.ENI.TagsEntry
+	2,  // 1: eni.v1.ENI.attachment:type_name -> eni.v1.Attachment
+	24, // 2: eni.v1.CreateENIRequest.tags:type_name -> eni.v1.CreateENIRequest.TagsEntry
+	1,  // 3: eni.v1.ListENIsRequest.filters:type_name -> eni.v1.Filter
+	1,  // 4: eni.v1.WatchENIsRequest.filters:type_name -> eni.v1.Filter
+	0,  // 5: eni.v1.ENIEvent.type:type_name -> eni.v1.ENIEvent.Type
+	3,  // 6: eni.v1.ENIEvent.eni:type_name -> eni.v1.ENI
+	25, // 7: eni.v1.ENIEvent.time:type_name -> google.protobuf.Timestamp
+	3,  // 8: eni.v1.ENIEvent.previous:type_name -> eni.v1.ENI
+	5,  // 9: eni.v1.ENIService.CreateENI:input_type -> eni.v1.CreateENIRequest
+	6,  // 10: eni.v1.ENIService.AttachENI:input_type -> eni.v1.AttachENIRequest
+	8,  // 11: eni.v1.ENIService.DetachENI:input_type -> eni.v1.DetachENIRequest
+	10, // 12: eni.v1.ENIService.DeleteENI:input_type -> eni.v1.DeleteENIRequest
+	12, // 13: eni.v1.ENIService.ModifyENI:input_type -> eni.v1.ModifyENIRequest
+	14, // 14: eni.v1.ENIService.AssignPrivateIPs:input_type -> eni.v1.AssignIPsRequest
+	16, // 15: eni.v1.ENIService.UnassignPrivateIPs:input_type -> eni.v1.UnassignIPsRequest
+	14, // 16: eni.v1.ENIService.AssignIPv6Addresses:input_type -> eni.v1.AssignIPsRequest
+	16, // 17: eni.v1.ENIService.UnassignIPv6Addresses:input_type -> eni.v1.UnassignIPsRequest
+	18, // 18: eni.v1.ENIService.GetENI:input_type -> eni.v1.GetENIRequest
+	19, // 19: eni.v1.ENIService.ListENIs:input_type -> eni.v1.ListENIsRequest
+	20, // 20: eni.v1.ENIService.WatchENIs:input_type -> eni.v1.WatchENIsRequest
+	22, // 21: eni.v1.ENIService.DescribeSubnet:input_type -> eni.v1.DescribeSubnetRequest
+	3,  // 22: eni.v1.ENIService.CreateENI:output_type -> eni.v1.ENI
+	7,  // 23: eni.v1.ENIService.AttachENI:output_type -> eni.v1.AttachENIResponse
+	9,  // 24: eni.v1.ENIService.DetachENI:output_type -> eni.v1.DetachENIResponse
+	11, // 25: eni.v1.ENIService.DeleteENI:output_type -> eni.v1.DeleteENIResponse
+	13, // 26: eni.v1.ENIService.ModifyENI:output_type -> eni.v1.ModifyENIResponse
+	15, // 27: eni.v1.ENIService.AssignPrivateIPs:output_type -> eni.v1.AssignIPsResponse
+	17, // 28: eni.v1.ENIService.UnassignPrivateIPs:output_type -> eni.v1.UnassignIPsResponse
+	15, // 29: eni.v1.ENIService.AssignIPv6Addresses:output_type -> eni.v1.AssignIPsResponse
+	17, // 30: eni.v1.ENIService.UnassignIPv6Addresses:output_type -> eni.v1.UnassignIPsResponse
+	3,  // 31: eni.v1.ENIService.GetENI:output_type -> eni.v1.ENI
+	3,  // 32: eni.v1.ENIService.ListENIs:output_type -> eni.v1.ENI
+	21, // 33: eni.v1.ENIService.WatchENIs:output_type -> eni.v1.ENIEvent
+	4,  // 34: eni.v1.ENIService.DescribeSubnet:output_type -> eni.v1.Subnet
+	22, // [22:35] is the sub-list for method output_type
+	9,  // [9:22] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_eni_v1_eni_proto_init() }
+func file_eni_v1_eni_proto_init() {
+	if File_eni_v1_eni_proto != nil {
+		return
+	}
+	file_eni_v1_eni_proto_msgTypes[5].OneofWrappers = []any{}
+	file_eni_v1_eni_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_eni_v1_eni_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   24,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_eni_v1_eni_proto_goTypes,
+		DependencyIndexes: file_eni_v1_eni_proto_depIdxs,
+		EnumInfos:         file_eni_v1_eni_proto_enumTypes,
+		MessageInfos:      file_eni_v1_eni_proto_msgTypes,
+	}.Build()
+	File_eni_v1_eni_proto = out.File
+	file_eni_v1_eni_proto_rawDesc = nil
+	file_eni_v1_eni_proto_goTypes = nil
+	file_eni_v1_eni_proto_depIdxs = nil
+}
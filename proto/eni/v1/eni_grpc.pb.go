@@ -0,0 +1,596 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: eni/v1/eni.proto
+
+package eniv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ENIService_CreateENI_FullMethodName             = "/eni.v1.ENIService/CreateENI"
+	ENIService_AttachENI_FullMethodName             = "/eni.v1.ENIService/AttachENI"
+	ENIService_DetachENI_FullMethodName             = "/eni.v1.ENIService/DetachENI"
+	ENIService_DeleteENI_FullMethodName             = "/eni.v1.ENIService/DeleteENI"
+	ENIService_ModifyENI_FullMethodName             = "/eni.v1.ENIService/ModifyENI"
+	ENIService_AssignPrivateIPs_FullMethodName      = "/eni.v1.ENIService/AssignPrivateIPs"
+	ENIService_UnassignPrivateIPs_FullMethodName    = "/eni.v1.ENIService/UnassignPrivateIPs"
+	ENIService_AssignIPv6Addresses_FullMethodName   = "/eni.v1.ENIService/AssignIPv6Addresses"
+	ENIService_UnassignIPv6Addresses_FullMethodName = "/eni.v1.ENIService/UnassignIPv6Addresses"
+	ENIService_GetENI_FullMethodName                = "/eni.v1.ENIService/GetENI"
+	ENIService_ListENIs_FullMethodName              = "/eni.v1.ENIService/ListENIs"
+	ENIService_WatchENIs_FullMethodName             = "/eni.v1.ENIService/WatchENIs"
+	ENIService_DescribeSubnet_FullMethodName        = "/eni.v1.ENIService/DescribeSubnet"
+)
+
+// ENIServiceClient is the client API for ENIService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ENIService mirrors ENIManager so control planes can manage ENIs over gRPC.
+// Errors carry the AWS error code as the ErrorInfo reason.
+type ENIServiceClient interface {
+	CreateENI(ctx context.Context, in *CreateENIRequest, opts ...grpc.CallOption) (*ENI, error)
+	AttachENI(ctx context.Context, in *AttachENIRequest, opts ...grpc.CallOption) (*AttachENIResponse, error)
+	DetachENI(ctx context.Context, in *DetachENIRequest, opts ...grpc.CallOption) (*DetachENIResponse, error)
+	DeleteENI(ctx context.Context, in *DeleteENIRequest, opts ...grpc.CallOption) (*DeleteENIResponse, error)
+	ModifyENI(ctx context.Context, in *ModifyENIRequest, opts ...grpc.CallOption) (*ModifyENIResponse, error)
+	AssignPrivateIPs(ctx context.Context, in *AssignIPsRequest, opts ...grpc.CallOption) (*AssignIPsResponse, error)
+	UnassignPrivateIPs(ctx context.Context, in *UnassignIPsRequest, opts ...grpc.CallOption) (*UnassignIPsResponse, error)
+	AssignIPv6Addresses(ctx context.Context, in *AssignIPsRequest, opts ...grpc.CallOption) (*AssignIPsResponse, error)
+	UnassignIPv6Addresses(ctx context.Context, in *UnassignIPsRequest, opts ...grpc.CallOption) (*UnassignIPsResponse, error)
+	GetENI(ctx context.Context, in *GetENIRequest, opts ...grpc.CallOption) (*ENI, error)
+	// ListENIs streams every ENI matching the filters.
+	ListENIs(ctx context.Context, in *ListENIsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ENI], error)
+	// WatchENIs streams changes to the ENIs matching the filters until the
+	// client cancels.
+	WatchENIs(ctx context.Context, in *WatchENIsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ENIEvent], error)
+	DescribeSubnet(ctx context.Context, in *DescribeSubnetRequest, opts ...grpc.CallOption) (*Subnet, error)
+}
+
+type eNIServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewENIServiceClient(cc grpc.ClientConnInterface) ENIServiceClient {
+	return &eNIServiceClient{cc}
+}
+
+func (c *eNIServiceClient) CreateENI(ctx context.Context, in *CreateENIRequest, opts ...grpc.CallOption) (*ENI, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ENI)
+	err := c.cc.Invoke(ctx, ENIService_CreateENI_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eNIServiceClient) AttachENI(ctx context.Context, in *AttachENIRequest, opts ...grpc.CallOption) (*AttachENIResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AttachENIResponse)
+	err := c.cc.Invoke(ctx, ENIService_AttachENI_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eNIServiceClient) DetachENI(ctx context.Context, in *DetachENIRequest, opts ...grpc.CallOption) (*DetachENIResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DetachENIResponse)
+	err := c.cc.Invoke(ctx, ENIService_DetachENI_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eNIServiceClient) DeleteENI(ctx context.Context, in *DeleteENIRequest, opts ...grpc.CallOption) (*DeleteENIResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteENIResponse)
+	err := c.cc.Invoke(ctx, ENIService_DeleteENI_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eNIServiceClient) ModifyENI(ctx context.Context, in *ModifyENIRequest, opts ...grpc.CallOption) (*ModifyENIResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ModifyENIResponse)
+	err := c.cc.Invoke(ctx, ENIService_ModifyENI_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eNIServiceClient) AssignPrivateIPs(ctx context.Context, in *AssignIPsRequest, opts ...grpc.CallOption) (*AssignIPsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignIPsResponse)
+	err := c.cc.Invoke(ctx, ENIService_AssignPrivateIPs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eNIServiceClient) UnassignPrivateIPs(ctx context.Context, in *UnassignIPsRequest, opts ...grpc.CallOption) (*UnassignIPsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnassignIPsResponse)
+	err := c.cc.Invoke(ctx, ENIService_UnassignPrivateIPs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eNIServiceClient) AssignIPv6Addresses(ctx context.Context, in *AssignIPsRequest, opts ...grpc.CallOption) (*AssignIPsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AssignIPsResponse)
+	err := c.cc.Invoke(ctx, ENIService_AssignIPv6Addresses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eNIServiceClient) UnassignIPv6Addresses(ctx context.Context, in *UnassignIPsRequest, opts ...grpc.CallOption) (*UnassignIPsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnassignIPsResponse)
+	err := c.cc.Invoke(ctx, ENIService_UnassignIPv6Addresses_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eNIServiceClient) GetENI(ctx context.Context, in *GetENIRequest, opts ...grpc.CallOption) (*ENI, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ENI)
+	err := c.cc.Invoke(ctx, ENIService_GetENI_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *eNIServiceClient) ListENIs(ctx context.Context, in *ListENIsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ENI], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ENIService_ServiceDesc.Streams[0], ENIService_ListENIs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListENIsRequest, ENI]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ENIService_ListENIsClient = grpc.ServerStreamingClient[ENI]
+
+func (c *eNIServiceClient) WatchENIs(ctx context.Context, in *WatchENIsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ENIEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &ENIService_ServiceDesc.Streams[1], ENIService_WatchENIs_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchENIsRequest, ENIEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ENIService_WatchENIsClient = grpc.ServerStreamingClient[ENIEvent]
+
+func (c *eNIServiceClient) DescribeSubnet(ctx context.Context, in *DescribeSubnetRequest, opts ...grpc.CallOption) (*Subnet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Subnet)
+	err := c.cc.Invoke(ctx, ENIService_DescribeSubnet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ENIServiceServer is the server API for ENIService service.
+// All implementations must embed UnimplementedENIServiceServer
+// for forward compatibility.
+//
+// ENIService mirrors ENIManager so control planes can manage ENIs over gRPC.
+// Errors carry the AWS error code as the ErrorInfo reason.
+type ENIServiceServer interface {
+	CreateENI(context.Context, *CreateENIRequest) (*ENI, error)
+	AttachENI(context.Context, *AttachENIRequest) (*AttachENIResponse, error)
+	DetachENI(context.Context, *DetachENIRequest) (*DetachENIResponse, error)
+	DeleteENI(context.Context, *DeleteENIRequest) (*DeleteENIResponse, error)
+	ModifyENI(context.Context, *ModifyENIRequest) (*ModifyENIResponse, error)
+	AssignPrivateIPs(context.Context, *AssignIPsRequest) (*AssignIPsResponse, error)
+	UnassignPrivateIPs(context.Context, *UnassignIPsRequest) (*UnassignIPsResponse, error)
+	AssignIPv6Addresses(context.Context, *AssignIPsRequest) (*AssignIPsResponse, error)
+	UnassignIPv6Addresses(context.Context, *UnassignIPsRequest) (*UnassignIPsResponse, error)
+	GetENI(context.Context, *GetENIRequest) (*ENI, error)
+	// ListENIs streams every ENI matching the filters.
+	ListENIs(*ListENIsRequest, grpc.ServerStreamingServer[ENI]) error
+	// WatchENIs streams changes to the ENIs matching the filters until the
+	// client cancels.
+	WatchENIs(*WatchENIsRequest, grpc.ServerStreamingServer[ENIEvent]) error
+	DescribeSubnet(context.Context, *DescribeSubnetRequest) (*Subnet, error)
+	mustEmbedUnimplementedENIServiceServer()
+}
+
+// UnimplementedENIServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedENIServiceServer struct{}
+
+func (UnimplementedENIServiceServer) CreateENI(context.Context, *CreateENIRequest) (*ENI, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateENI not implemented")
+}
+func (UnimplementedENIServiceServer) AttachENI(context.Context, *AttachENIRequest) (*AttachENIResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AttachENI not implemented")
+}
+func (UnimplementedENIServiceServer) DetachENI(context.Context, *DetachENIRequest) (*DetachENIResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetachENI not implemented")
+}
+func (UnimplementedENIServiceServer) DeleteENI(context.Context, *DeleteENIRequest) (*DeleteENIResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteENI not implemented")
+}
+func (UnimplementedENIServiceServer) ModifyENI(context.Context, *ModifyENIRequest) (*ModifyENIResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ModifyENI not implemented")
+}
+func (UnimplementedENIServiceServer) AssignPrivateIPs(context.Context, *AssignIPsRequest) (*AssignIPsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignPrivateIPs not implemented")
+}
+func (UnimplementedENIServiceServer) UnassignPrivateIPs(context.Context, *UnassignIPsRequest) (*UnassignIPsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnassignPrivateIPs not implemented")
+}
+func (UnimplementedENIServiceServer) AssignIPv6Addresses(context.Context, *AssignIPsRequest) (*AssignIPsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AssignIPv6Addresses not implemented")
+}
+func (UnimplementedENIServiceServer) UnassignIPv6Addresses(context.Context, *UnassignIPsRequest) (*UnassignIPsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnassignIPv6Addresses not implemented")
+}
+func (UnimplementedENIServiceServer) GetENI(context.Context, *GetENIRequest) (*ENI, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetENI not implemented")
+}
+func (UnimplementedENIServiceServer) ListENIs(*ListENIsRequest, grpc.ServerStreamingServer[ENI]) error {
+	return status.Errorf(codes.Unimplemented, "method ListENIs not implemented")
+}
+func (UnimplementedENIServiceServer) WatchENIs(*WatchENIsRequest, grpc.ServerStreamingServer[ENIEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchENIs not implemented")
+}
+func (UnimplementedENIServiceServer) DescribeSubnet(context.Context, *DescribeSubnetRequest) (*Subnet, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DescribeSubnet not implemented")
+}
+func (UnimplementedENIServiceServer) mustEmbedUnimplementedENIServiceServer() {}
+func (UnimplementedENIServiceServer) testEmbeddedByValue()                    {}
+
+// UnsafeENIServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ENIServiceServer will
+// result in compilation errors.
+type UnsafeENIServiceServer interface {
+	mustEmbedUnimplementedENIServiceServer()
+}
+
+func RegisterENIServiceServer(s grpc.ServiceRegistrar, srv ENIServiceServer) {
+	// If the following call pancis, it indicates UnimplementedENIServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ENIService_ServiceDesc, srv)
+}
+
+func _ENIService_CreateENI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateENIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ENIServiceServer).CreateENI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ENIService_CreateENI_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ENIServiceServer).CreateENI(ctx, req.(*CreateENIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ENIService_AttachENI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AttachENIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ENIServiceServer).AttachENI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ENIService_AttachENI_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ENIServiceServer).AttachENI(ctx, req.(*AttachENIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ENIService_DetachENI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DetachENIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ENIServiceServer).DetachENI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ENIService_DetachENI_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ENIServiceServer).DetachENI(ctx, req.(*DetachENIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ENIService_DeleteENI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteENIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ENIServiceServer).DeleteENI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ENIService_DeleteENI_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ENIServiceServer).DeleteENI(ctx, req.(*DeleteENIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ENIService_ModifyENI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ModifyENIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ENIServiceServer).ModifyENI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ENIService_ModifyENI_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ENIServiceServer).ModifyENI(ctx, req.(*ModifyENIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ENIService_AssignPrivateIPs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignIPsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ENIServiceServer).AssignPrivateIPs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ENIService_AssignPrivateIPs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ENIServiceServer).AssignPrivateIPs(ctx, req.(*AssignIPsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ENIService_UnassignPrivateIPs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnassignIPsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ENIServiceServer).UnassignPrivateIPs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ENIService_UnassignPrivateIPs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ENIServiceServer).UnassignPrivateIPs(ctx, req.(*UnassignIPsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ENIService_AssignIPv6Addresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AssignIPsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ENIServiceServer).AssignIPv6Addresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ENIService_AssignIPv6Addresses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ENIServiceServer).AssignIPv6Addresses(ctx, req.(*AssignIPsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ENIService_UnassignIPv6Addresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnassignIPsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ENIServiceServer).UnassignIPv6Addresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ENIService_UnassignIPv6Addresses_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ENIServiceServer).UnassignIPv6Addresses(ctx, req.(*UnassignIPsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ENIService_GetENI_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetENIRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ENIServiceServer).GetENI(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ENIService_GetENI_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ENIServiceServer).GetENI(ctx, req.(*GetENIRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _ENIService_ListENIs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListENIsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ENIServiceServer).ListENIs(m, &grpc.GenericServerStream[ListENIsRequest, ENI]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ENIService_ListENIsServer = grpc.ServerStreamingServer[ENI]
+
+func _ENIService_WatchENIs_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchENIsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ENIServiceServer).WatchENIs(m, &grpc.GenericServerStream[WatchENIsRequest, ENIEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type ENIService_WatchENIsServer = grpc.ServerStreamingServer[ENIEvent]
+
+func _ENIService_DescribeSubnet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DescribeSubnetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ENIServiceServer).DescribeSubnet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ENIService_DescribeSubnet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ENIServiceServer).DescribeSubnet(ctx, req.(*DescribeSubnetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ENIService_ServiceDesc is the grpc.ServiceDesc for ENIService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ENIService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "eni.v1.ENIService",
+	HandlerType: (*ENIServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateENI",
+			Handler:    _ENIService_CreateENI_Handler,
+		},
+		{
+			MethodName: "AttachENI",
+			Handler:    _ENIService_AttachENI_Handler,
+		},
+		{
+			MethodName: "DetachENI",
+			Handler:    _ENIService_DetachENI_Handler,
+		},
+		{
+			MethodName: "DeleteENI",
+			Handler:    _ENIService_DeleteENI_Handler,
+		},
+		{
+			MethodName: "ModifyENI",
+			Handler:    _ENIService_ModifyENI_Handler,
+		},
+		{
+			MethodName: "AssignPrivateIPs",
+			Handler:    _ENIService_AssignPrivateIPs_Handler,
+		},
+		{
+			MethodName: "UnassignPrivateIPs",
+			Handler:    _ENIService_UnassignPrivateIPs_Handler,
+		},
+		{
+			MethodName: "AssignIPv6Addresses",
+			Handler:    _ENIService_AssignIPv6Addresses_Handler,
+		},
+		{
+			MethodName: "UnassignIPv6Addresses",
+			Handler:    _ENIService_UnassignIPv6Addresses_Handler,
+		},
+		{
+			MethodName: "GetENI",
+			Handler:    _ENIService_GetENI_Handler,
+		},
+		{
+			MethodName: "DescribeSubnet",
+			Handler:    _ENIService_DescribeSubnet_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListENIs",
+			Handler:       _ENIService_ListENIs_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchENIs",
+			Handler:       _ENIService_WatchENIs_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "eni/v1/eni.proto",
+}
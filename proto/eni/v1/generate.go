@@ -0,0 +1,5 @@
+// Package eniv1 holds the generated eni.v1 protobuf types and the
+// ENIService gRPC client and server stubs.
+package eniv1
+
+//go:generate sh -c "cd ../../.. && buf generate"
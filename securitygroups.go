@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"eni-project/internal/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// runSecurityGroups implements the sg subcommands: list, apply, create-test,
+// delete and eni
+func runSecurityGroups(args []string, eniManager *ec2.ENIManager, timeout time.Duration) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: sg list|apply|create-test|delete|eni")
+		exit(2)
+	}
+	command, args := args[0], args[1:]
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	switch command {
+	case "list":
+		fs := flag.NewFlagSet("sg list", flag.ExitOnError)
+		vpcID := fs.String("vpc", "", "only groups in this VPC")
+		name := fs.String("name", "", "only the group with this name")
+		fs.Parse(args)
+
+		var filters []types.Filter
+		if *vpcID != "" {
+			filters = append(filters, types.Filter{Name: aws.String("vpc-id"), Values: []string{*vpcID}})
+		}
+		if *name != "" {
+			filters = append(filters, types.Filter{Name: aws.String("group-name"), Values: []string{*name}})
+		}
+		output, err := eniManager.DescribeSecurityGroups(ctx, filters)
+		if err != nil {
+			fatal("Failed to list security groups", err)
+		}
+		printSecurityGroups(output.SecurityGroups)
+
+	case "apply":
+		fs := flag.NewFlagSet("sg apply", flag.ExitOnError)
+		fs.Parse(args)
+		if fs.NArg() != 1 {
+			fmt.Fprintln(os.Stderr, "usage: sg apply FILE")
+			exit(2)
+		}
+		specs, err := ec2.LoadSecurityGroupSpecs(fs.Arg(0))
+		if err != nil {
+			fatal("Failed to load security groups", err)
+		}
+		applySecurityGroups(ctx, eniManager, specs)
+
+	case "create-test":
+		fs := flag.NewFlagSet("sg create-test", flag.ExitOnError)
+		name := fs.String("name", "eni-test-sg", "group name")
+		vpcID := fs.String("vpc", "", "VPC to create the group in (required)")
+		vpcCIDR := fs.String("vpc-cidr", "", "VPC CIDR block allowed all traffic (required)")
+		sshCIDR := fs.String("ssh-cidr", "", "CIDR block allowed SSH, e.g. your public IP as /32")
+		fs.Parse(args)
+		if *vpcID == "" || *vpcCIDR == "" {
+			fmt.Fprintln(os.Stderr, "sg create-test: -vpc and -vpc-cidr are required")
+			exit(2)
+		}
+
+		spec := ec2.SecurityGroupSpec{
+			SecurityGroupConfig: ec2.SecurityGroupConfig{
+				Name:        *name,
+				Description: "Security group for ENI testing",
+				VPCID:       *vpcID,
+				Tags:        map[string]string{ec2.ManagedByTagKey: ec2.ManagedByTagValue},
+			},
+			Ingress: []ec2.SecurityGroupRule{{Protocol: "all", CIDR: *vpcCIDR}},
+		}
+		if *sshCIDR != "" {
+			spec.Ingress = append(spec.Ingress, ec2.SecurityGroupRule{Protocol: "tcp", FromPort: 22, ToPort: 22, CIDR: *sshCIDR})
+		}
+		applySecurityGroups(ctx, eniManager, []ec2.SecurityGroupSpec{spec})
+
+	case "delete":
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "usage: sg delete GROUP_ID")
+			exit(2)
+		}
+		if err := eniManager.DeleteSecurityGroup(ctx, args[0]); err != nil {
+			fatal("Failed to delete security group", err)
+		}
+		slog.Info("Deleted security group", "security_group_id", args[0])
+
+	case "eni":
+		fs := flag.NewFlagSet("sg eni", flag.ExitOnError)
+		eniID := fs.String("eni", "", "ENI to update (required)")
+		add := fs.String("add", "", "comma-separated security groups to add")
+		remove := fs.String("remove", "", "comma-separated security groups to remove")
+		fs.Parse(args)
+		if *eniID == "" {
+			fmt.Fprintln(os.Stderr, "sg eni: -eni is required")
+			exit(2)
+		}
+		groups, err := eniManager.UpdateENISecurityGroups(ctx, *eniID, splitList(*add), splitList(*remove))
+		if err != nil {
+			fatal("Failed to update ENI security groups", err)
+		}
+		slog.Info("ENI security groups", "eni_id", *eniID, "security_group_ids", strings.Join(groups, ","))
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown sg command %q (want list, apply, create-test, delete or eni)\n", command)
+		exit(2)
+	}
+}
+
+// applySecurityGroups applies each spec and prints the rules it changed
+func applySecurityGroups(ctx context.Context, eniManager *ec2.ENIManager, specs []ec2.SecurityGroupSpec) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	defer w.Flush()
+	fmt.Fprintln(w, "GROUP\tNAME\tCHANGE\tDIRECTION\tRULE")
+
+	for _, spec := range specs {
+		changes, err := eniManager.ApplySecurityGroup(ctx, spec)
+		if changes != nil {
+			if changes.Created {
+				fmt.Fprintf(w, "%s\t%s\tcreated\t\t\n", changes.GroupID, spec.Name)
+			}
+			for _, direction := range []ec2.RuleDirection{ec2.Ingress, ec2.Egress} {
+				for _, rule := range changes.Authorized[direction] {
+					fmt.Fprintf(w, "%s\t%s\tauthorized\t%s\t%s\n", changes.GroupID, spec.Name, direction, formatRule(rule))
+				}
+				for _, rule := range changes.Revoked[direction] {
+					fmt.Fprintf(w, "%s\t%s\trevoked\t%s\t%s\n", changes.GroupID, spec.Name, direction, formatRule(rule))
+				}
+			}
+		}
+		if err != nil {
+			w.Flush()
+			fatal("Failed to apply security group "+spec.Name, err)
+		}
+	}
+}
+
+func printSecurityGroups(groups []types.SecurityGroup) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "GROUP\tNAME\tVPC\tDIRECTION\tRULE")
+	for _, group := range groups {
+		id, name, vpc := aws.ToString(group.GroupId), aws.ToString(group.GroupName), aws.ToString(group.VpcId)
+		fmt.Fprintf(w, "%s\t%s\t%s\t\t\n", id, name, vpc)
+		for _, rule := range ec2.RulesFromPermissions(group.IpPermissions) {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", id, name, vpc, ec2.Ingress, formatRule(rule))
+		}
+		for _, rule := range ec2.RulesFromPermissions(group.IpPermissionsEgress) {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", id, name, vpc, ec2.Egress, formatRule(rule))
+		}
+	}
+	w.Flush()
+}
+
+// formatRule renders a rule as e.g. "tcp 22 203.0.113.7/32"
+func formatRule(rule ec2.SecurityGroupRule) string {
+	ports := ""
+	switch {
+	case rule.Protocol == "all":
+	case rule.FromPort == rule.ToPort:
+		ports = fmt.Sprintf(" %d", rule.FromPort)
+	default:
+		ports = fmt.Sprintf(" %d-%d", rule.FromPort, rule.ToPort)
+	}
+	source := rule.CIDR
+	if rule.SourceGroupID != "" {
+		source = rule.SourceGroupID
+	}
+	return rule.Protocol + ports + " " + source
+}
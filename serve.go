@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"eni-project/internal/api"
+	"eni-project/internal/ec2"
+	"eni-project/internal/rpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+)
+
+// runServe exposes eniManager over the REST API, and optionally gRPC, until
+// interrupted, then drains in-flight requests before returning.
+func runServe(args []string, eniManager *ec2.ENIManager, registry *prometheus.Registry) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	// Neither API authenticates callers, who act with this process's AWS
+	// credentials, so only local clients can reach them by default
+	listen := fs.String("listen", "127.0.0.1:8080", "address to serve the API on")
+	grpcListen := fs.String("grpc-listen", "", "address to also serve the gRPC ENIService on (disabled if empty)")
+	shutdownTimeout := fs.Duration("shutdown-timeout", 30*time.Second, "how long to wait for in-flight requests on shutdown")
+	fs.Parse(args)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	handler := api.NewServer(eniManager, slog.Default())
+	handler.Handle("GET /metrics", promhttp.HandlerFor(registry, promhttp.HandlerOpts{Registry: registry}))
+	server := &http.Server{
+		Addr:              *listen,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errc := make(chan error, 2)
+	go func() {
+		slog.Info("Serving API", "address", *listen)
+		errc <- server.ListenAndServe()
+	}()
+
+	var grpcServer *grpc.Server
+	if *grpcListen != "" {
+		lis, err := net.Listen("tcp", *grpcListen)
+		if err != nil {
+			fatal("Failed to listen for gRPC", err)
+		}
+		grpcServer = grpc.NewServer(grpc.UnaryInterceptor(rpc.CallerInterceptor))
+		rpc.NewServer(eniManager).Register(grpcServer)
+		go func() {
+			slog.Info("Serving gRPC", "address", *grpcListen)
+			errc <- grpcServer.Serve(lis)
+		}()
+	}
+
+	select {
+	case err := <-errc:
+		if !errors.Is(err, http.ErrServerClosed) {
+			fatal("Server failed", err)
+		}
+	case <-ctx.Done():
+		slog.Info("Shutting down API server", "timeout", *shutdownTimeout)
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+		defer cancel()
+		// Drain both servers at once so a slow gRPC stop doesn't eat the
+		// HTTP server's share of the deadline
+		var wg sync.WaitGroup
+		if grpcServer != nil {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				// Watch streams only end when clients cancel, so don't
+				// wait on them past the deadline
+				stopped := make(chan struct{})
+				go func() {
+					grpcServer.GracefulStop()
+					close(stopped)
+				}()
+				select {
+				case <-stopped:
+				case <-shutdownCtx.Done():
+					grpcServer.Stop()
+				}
+			}()
+		}
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			slog.Error("Failed to shut down API server cleanly", "error", err)
+		}
+		wg.Wait()
+	}
+}
+
+// runOpenAPI writes the API's OpenAPI document to stdout
+func runOpenAPI(eniManager *ec2.ENIManager) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(api.NewServer(eniManager, slog.Default()).OpenAPI()); err != nil {
+		fatal("Failed to write OpenAPI document", err)
+	}
+}
@@ -0,0 +1,473 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"eni-project/internal/ec2"
+	"gopkg.in/yaml.v3"
+)
+
+// settings are the CLI's effective settings. They are resolved in order of
+// increasing precedence from built-in defaults, the selected profile of the
+// config file, ENI_* environment variables and command-line flags.
+type settings struct {
+	Regions     []string `yaml:"regions,omitempty"`
+	AWSProfiles []string `yaml:"aws_profiles,omitempty"`
+	RoleARNs    []string `yaml:"role_arns,omitempty"`
+
+	// Defaults for the ENIs the demo creates and preflight checks
+	SubnetID         string            `yaml:"subnet_id,omitempty"`
+	SecurityGroupIDs []string          `yaml:"security_group_ids,omitempty"`
+	InstanceID       string            `yaml:"instance_id,omitempty"`
+	Tags             map[string]string `yaml:"tags,omitempty"`
+	PrivateIPCount   int32             `yaml:"private_ip_count"`
+	IPv6AddressCount int32             `yaml:"ipv6_address_count"`
+
+	// Timeout bounds each command's AWS calls; Wait is the pause the demo
+	// takes before cleanup and after detaching
+	Timeout time.Duration `yaml:"timeout"`
+	Wait    time.Duration `yaml:"wait"`
+
+	// BranchLimits adds or overrides trunk branch limits per instance type
+	BranchLimits map[string]int32 `yaml:"branch_limits,omitempty"`
+	// AttachStrategy spreads or packs batches of ENIs across network cards
+	AttachStrategy string `yaml:"attach_strategy,omitempty"`
+	// RateLimit throttles EC2 calls client-side, per account and region
+	RateLimit ec2.RateLimitConfig `yaml:"rate_limit"`
+
+	// SubnetHistory is the file subnet sample appends to and subnet
+	// forecast reads; SubnetAlerts are the thresholds forecast alerts on
+	SubnetHistory string               `yaml:"subnet_history,omitempty"`
+	SubnetAlerts  ec2.SubnetThresholds `yaml:"subnet_alerts,omitempty"`
+
+	LogFormat string `yaml:"log_format"`
+	LogLevel  string `yaml:"log_level"`
+	DryRun    string `yaml:"dry_run,omitempty"`
+	AuditLog  string `yaml:"audit_log,omitempty"`
+	Policy    string `yaml:"policy,omitempty"`
+	Hooks     string `yaml:"hooks,omitempty"`
+	Record    string `yaml:"record,omitempty"`
+}
+
+func defaultSettings() settings {
+	return settings{
+		SubnetID:         "subnet-0a7bd03887dc3cbd5",
+		SecurityGroupIDs: []string{"sg-0f9acdf364ab834f2"},
+		InstanceID:       "i-04890aa7cd8cf81f3",
+		Tags:             map[string]string{"Name": "example-eni", "Environment": "development"},
+		PrivateIPCount:   2,
+		Timeout:          5 * time.Minute,
+		Wait:             5 * time.Second,
+		RateLimit:        ec2.DefaultRateLimitConfig(),
+		LogFormat:        "text",
+		LogLevel:         "info",
+	}
+}
+
+// configFile is the format of the --config file
+type configFile struct {
+	// DefaultProfile is used when no profile is selected; "default" if unset
+	DefaultProfile string               `yaml:"default_profile"`
+	Profiles       map[string]yaml.Node `yaml:"profiles"`
+}
+
+// setting is one setting that can also be given as a flag and an
+// environment variable, both as a string
+type setting struct {
+	flag  string
+	env   string
+	usage string
+	set   func(s *settings, v string) error
+}
+
+var settingTable = []setting{
+	{"region", "ENI_REGION", "comma-separated AWS regions (default: the configured region)",
+		func(s *settings, v string) error { s.Regions = splitList(v); return nil }},
+	{"profile", "ENI_AWS_PROFILE", "comma-separated shared config profiles, one account each",
+		func(s *settings, v string) error { s.AWSProfiles = splitList(v); return nil }},
+	{"role-arn", "ENI_ROLE_ARN", "comma-separated IAM role ARNs to assume, one account each",
+		func(s *settings, v string) error { s.RoleARNs = splitList(v); return nil }},
+	{"subnet", "ENI_SUBNET_ID", "subnet the demo creates its ENI in",
+		func(s *settings, v string) error { s.SubnetID = v; return nil }},
+	{"security-groups", "ENI_SECURITY_GROUP_IDS", "comma-separated security groups for the demo ENI",
+		func(s *settings, v string) error { s.SecurityGroupIDs = splitList(v); return nil }},
+	{"instance", "ENI_INSTANCE_ID", "instance the demo attaches its ENI to",
+		func(s *settings, v string) error { s.InstanceID = v; return nil }},
+	{"tags", "ENI_TAGS", "comma-separated key=value tags for the demo ENI",
+		func(s *settings, v string) (err error) { s.Tags, err = parseTags(v); return err }},
+	{"private-ip-count", "ENI_PRIVATE_IP_COUNT", "secondary private IPs the demo ENI is created with",
+		func(s *settings, v string) error { return parseCount(&s.PrivateIPCount, v) }},
+	{"ipv6-address-count", "ENI_IPV6_ADDRESS_COUNT", "IPv6 addresses the demo ENI is created with",
+		func(s *settings, v string) error { return parseCount(&s.IPv6AddressCount, v) }},
+	{"timeout", "ENI_TIMEOUT", "how long a command may spend on AWS calls",
+		func(s *settings, v string) (err error) { s.Timeout, err = time.ParseDuration(v); return err }},
+	{"wait", "ENI_WAIT", "how long the demo waits before cleanup and after detaching",
+		func(s *settings, v string) (err error) { s.Wait, err = time.ParseDuration(v); return err }},
+	{"branch-limits", "ENI_BRANCH_LIMITS", "comma-separated instance-type=limit trunk branch limits",
+		func(s *settings, v string) (err error) { s.BranchLimits, err = parseLimits(v); return err }},
+	{"attach-strategy", "ENI_ATTACH_STRATEGY", "how cards attach spreads ENIs across network cards: spread or pack",
+		func(s *settings, v string) error { s.AttachStrategy = v; return nil }},
+	{"mutating-rate", "ENI_MUTATING_RATE", "EC2 mutating calls per second, per account and region",
+		func(s *settings, v string) (err error) {
+			s.RateLimit.MutatingRate, err = strconv.ParseFloat(v, 64)
+			return err
+		}},
+	{"mutating-burst", "ENI_MUTATING_BURST", "EC2 mutating calls allowed in a burst",
+		func(s *settings, v string) (err error) { s.RateLimit.MutatingBurst, err = strconv.Atoi(v); return err }},
+	{"describe-rate", "ENI_DESCRIBE_RATE", "EC2 describe calls per second, per account and region",
+		func(s *settings, v string) (err error) {
+			s.RateLimit.DescribeRate, err = strconv.ParseFloat(v, 64)
+			return err
+		}},
+	{"describe-burst", "ENI_DESCRIBE_BURST", "EC2 describe calls allowed in a burst",
+		func(s *settings, v string) (err error) { s.RateLimit.DescribeBurst, err = strconv.Atoi(v); return err }},
+	{"subnet-history", "ENI_SUBNET_HISTORY", "file of subnet address samples (default: in the user cache directory)",
+		func(s *settings, v string) error { s.SubnetHistory = v; return nil }},
+	{"alert-used-percent", "ENI_ALERT_USED_PERCENT", "subnet forecast alerts when more of a subnet is in use",
+		func(s *settings, v string) (err error) {
+			s.SubnetAlerts.MaxUsedPercent, err = strconv.ParseFloat(v, 64)
+			return err
+		}},
+	{"alert-min-available", "ENI_ALERT_MIN_AVAILABLE", "subnet forecast alerts when fewer addresses are left",
+		func(s *settings, v string) error { return parseCount(&s.SubnetAlerts.MinAvailable, v) }},
+	{"alert-time-left", "ENI_ALERT_TIME_LEFT", "subnet forecast alerts when a subnet runs out sooner",
+		func(s *settings, v string) (err error) {
+			s.SubnetAlerts.MinTimeLeft, err = time.ParseDuration(v)
+			return err
+		}},
+	{"log-format", "ENI_LOG_FORMAT", "log output format: json or text",
+		func(s *settings, v string) error { s.LogFormat = v; return nil }},
+	{"log-level", "ENI_LOG_LEVEL", "minimum log level: debug, info, warn or error",
+		func(s *settings, v string) error { s.LogLevel = v; return nil }},
+	{"dry-run", "ENI_DRY_RUN", "preview mutations instead of making them: aws (EC2 DryRun) or offline",
+		func(s *settings, v string) error { s.DryRun = v; return nil }},
+	{"audit-log", "ENI_AUDIT_LOG", "append a hash-chained audit record of every ENI mutation to this file",
+		func(s *settings, v string) error { s.AuditLog = v; return nil }},
+	{"policy", "ENI_POLICY", "YAML file of rules ENI requests must satisfy before reaching AWS",
+		func(s *settings, v string) error { s.Policy = v; return nil }},
+	{"hooks", "ENI_HOOKS", "YAML file of webhook and command hooks fired around ENI mutations",
+		func(s *settings, v string) error { s.Hooks = v; return nil }},
+	{"record", "ENI_RECORD", "save the EC2 calls made to this cassette file, with resource and account IDs scrubbed",
+		func(s *settings, v string) error { s.Record = v; return nil }},
+}
+
+// settingFlags collects the settings given on the command line so they can
+// be applied after the config file and environment
+type settingFlags struct {
+	configPath    string
+	configProfile string
+	values        []func(*settings) error
+}
+
+func registerSettingFlags(fs *flag.FlagSet) *settingFlags {
+	f := &settingFlags{}
+	fs.StringVar(&f.configPath, "config", "", "YAML config file of named profiles (env ENI_CONFIG, default: "+defaultConfigPath()+")")
+	fs.StringVar(&f.configProfile, "config-profile", "", "config file profile to use (env ENI_CONFIG_PROFILE)")
+	for _, st := range settingTable {
+		fs.Func(st.flag, st.usage+" (env "+st.env+")", func(v string) error {
+			f.values = append(f.values, func(s *settings) error {
+				if err := st.set(s, v); err != nil {
+					return fmt.Errorf("--%s: %w", st.flag, err)
+				}
+				return nil
+			})
+			return nil
+		})
+	}
+	return f
+}
+
+// defaultConfigPath is where the config file is read from if --config and
+// ENI_CONFIG are unset. It's fine for it not to exist.
+func defaultConfigPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "eni-manager", "config.yaml")
+}
+
+// resolvedSettings are the effective settings and where they came from
+type resolvedSettings struct {
+	settings
+	// Path is the config file read, empty if there was none
+	Path string
+	// Profile is the config file profile applied, empty if there was none
+	Profile string
+}
+
+// resolve merges defaults, the config file profile, the environment and the
+// flags given
+func (f *settingFlags) resolve() (resolvedSettings, error) {
+	r := resolvedSettings{settings: defaultSettings()}
+
+	path, explicit := firstNonEmpty(f.configPath, os.Getenv("ENI_CONFIG")), true
+	if path == "" {
+		path, explicit = defaultConfigPath(), false
+	}
+	file, err := readConfigFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist) && !explicit:
+	case err != nil:
+		return r, err
+	default:
+		r.Path = path
+		r.Profile = firstNonEmpty(f.configProfile, os.Getenv("ENI_CONFIG_PROFILE"), file.DefaultProfile, "default")
+		node, ok := file.Profiles[r.Profile]
+		switch {
+		case ok:
+			if err := decodeProfile(&node, &r.settings); err != nil {
+				return r, fmt.Errorf("%s: profile %s: %w", path, r.Profile, err)
+			}
+		case f.configProfile != "" || os.Getenv("ENI_CONFIG_PROFILE") != "" || file.DefaultProfile != "":
+			return r, fmt.Errorf("%s: no profile named %q", path, r.Profile)
+		default:
+			// The implicit "default" profile is optional
+			r.Profile = ""
+		}
+	}
+	if f.configProfile != "" && r.Path == "" {
+		return r, fmt.Errorf("--config-profile %s given but no config file found", f.configProfile)
+	}
+
+	for _, st := range settingTable {
+		if v, ok := os.LookupEnv(st.env); ok {
+			if err := st.set(&r.settings, v); err != nil {
+				return r, fmt.Errorf("%s: %w", st.env, err)
+			}
+		}
+	}
+	for _, apply := range f.values {
+		if err := apply(&r.settings); err != nil {
+			return r, err
+		}
+	}
+	return r, r.validate()
+}
+
+// readConfigFile reads the config file at path. Unknown keys are rejected so
+// typos don't silently fall back to defaults.
+func readConfigFile(path string) (*configFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var strict struct {
+		DefaultProfile string              `yaml:"default_profile"`
+		Profiles       map[string]settings `yaml:"profiles"`
+	}
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&strict); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+
+	// Profiles are kept undecoded so each can be overlaid on the defaults
+	var file configFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %w", path, err)
+	}
+	return &file, nil
+}
+
+// decodeProfile overlays a profile onto s. A profile's tags replace the
+// defaults rather than merging into them.
+func decodeProfile(node *yaml.Node, s *settings) error {
+	defaultTags := s.Tags
+	s.Tags = nil
+	if err := node.Decode(s); err != nil {
+		return err
+	}
+	if s.Tags == nil {
+		s.Tags = defaultTags
+	}
+	return nil
+}
+
+// validate checks the settings are usable before any AWS call is made
+func (s settings) validate() error {
+	var errs []error
+	check := func(ok bool, format string, args ...any) {
+		if !ok {
+			errs = append(errs, fmt.Errorf(format, args...))
+		}
+	}
+
+	if _, err := newLogger(io.Discard, s.LogFormat, s.LogLevel); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := ec2.ParseDryRunMode(s.DryRun); err != nil {
+		errs = append(errs, err)
+	}
+	if _, err := ec2.ParseAttachStrategy(s.AttachStrategy); err != nil {
+		errs = append(errs, err)
+	}
+	check(strings.HasPrefix(s.SubnetID, "subnet-"), "subnet_id %q: not a subnet ID", s.SubnetID)
+	check(strings.HasPrefix(s.InstanceID, "i-"), "instance_id %q: not an instance ID", s.InstanceID)
+	for _, id := range s.SecurityGroupIDs {
+		check(strings.HasPrefix(id, "sg-"), "security_group_ids: %q is not a security group ID", id)
+	}
+	for _, arn := range s.RoleARNs {
+		check(strings.HasPrefix(arn, "arn:"), "role_arns: %q is not an ARN", arn)
+	}
+	check(s.PrivateIPCount >= 0, "private_ip_count must not be negative")
+	check(s.IPv6AddressCount >= 0, "ipv6_address_count must not be negative")
+	check(s.Timeout > 0, "timeout must be positive")
+	check(s.Wait >= 0, "wait must not be negative")
+	check(s.SubnetAlerts.MaxUsedPercent >= 0 && s.SubnetAlerts.MaxUsedPercent <= 100,
+		"subnet_alerts.max_used_percent must be between 0 and 100")
+	check(s.SubnetAlerts.MinAvailable >= 0, "subnet_alerts.min_available must not be negative")
+	check(s.SubnetAlerts.MinTimeLeft >= 0, "subnet_alerts.min_time_left must not be negative")
+	// A zero rate or burst would block every call forever
+	check(s.RateLimit.MutatingRate > 0, "rate_limit.mutating_rate must be positive")
+	check(s.RateLimit.MutatingBurst > 0, "rate_limit.mutating_burst must be positive")
+	check(s.RateLimit.DescribeRate > 0, "rate_limit.describe_rate must be positive")
+	check(s.RateLimit.DescribeBurst > 0, "rate_limit.describe_burst must be positive")
+	for instanceType, limit := range s.BranchLimits {
+		check(limit > 0, "branch_limits: %s must be positive", instanceType)
+	}
+	return errors.Join(errs...)
+}
+
+// runConfig implements "config view", which prints the effective settings,
+// and "config validate", which checks every profile in the config file
+func runConfig(args []string, flags *settingFlags) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: config view|validate")
+		exit(2)
+	}
+
+	switch args[0] {
+	case "view":
+		r, err := flags.resolve()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			exit(1)
+		}
+		if r.Path != "" {
+			fmt.Printf("# file: %s\n", r.Path)
+		}
+		if r.Profile != "" {
+			fmt.Printf("# profile: %s\n", r.Profile)
+		}
+		out, err := yaml.Marshal(r.settings)
+		if err != nil {
+			fatal("unable to encode settings", err)
+		}
+		os.Stdout.Write(out)
+	case "validate":
+		if err := validateConfigFile(firstNonEmpty(flags.configPath, os.Getenv("ENI_CONFIG"), defaultConfigPath())); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			exit(1)
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown config command %q (want view or validate)\n", args[0])
+		exit(2)
+	}
+}
+
+// validateConfigFile checks that every profile in path decodes and, merged
+// onto the defaults, yields valid settings. Referenced policy and hooks files
+// are loaded too.
+func validateConfigFile(path string) error {
+	file, err := readConfigFile(path)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(file.Profiles))
+	for name := range file.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var errs []error
+	if file.DefaultProfile != "" && file.Profiles[file.DefaultProfile].Kind == 0 {
+		errs = append(errs, fmt.Errorf("default_profile %q is not defined", file.DefaultProfile))
+	}
+	for _, name := range names {
+		node := file.Profiles[name]
+		s := defaultSettings()
+		if err := decodeProfile(&node, &s); err != nil {
+			errs = append(errs, fmt.Errorf("profile %s: %w", name, err))
+			continue
+		}
+		if err := s.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("profile %s: %w", name, err))
+		}
+		if s.Policy != "" {
+			if _, err := ec2.LoadRules(s.Policy); err != nil {
+				errs = append(errs, fmt.Errorf("profile %s: policy: %w", name, err))
+			}
+		}
+		if s.Hooks != "" {
+			if _, err := loadHooks(s.Hooks); err != nil {
+				errs = append(errs, fmt.Errorf("profile %s: hooks: %w", name, err))
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("%s: %w", path, err)
+	}
+	fmt.Printf("%s: %d profiles OK\n", path, len(names))
+	return nil
+}
+
+// parseTags parses comma-separated key=value pairs
+func parseTags(s string) (map[string]string, error) {
+	tags := make(map[string]string)
+	for _, pair := range splitList(s) {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid entry %q (want key=value)", pair)
+		}
+		tags[key] = value
+	}
+	return tags, nil
+}
+
+// parseLimits parses comma-separated key=count pairs
+func parseLimits(s string) (map[string]int32, error) {
+	pairs, err := parseTags(s)
+	if err != nil {
+		return nil, err
+	}
+	limits := make(map[string]int32, len(pairs))
+	for key, value := range pairs {
+		var limit int32
+		if err := parseCount(&limit, value); err != nil {
+			return nil, fmt.Errorf("invalid limit for %s: %w", key, err)
+		}
+		limits[key] = limit
+	}
+	return limits, nil
+}
+
+func parseCount(dst *int32, s string) error {
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return err
+	}
+	*dst = int32(n)
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
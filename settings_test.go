@@ -0,0 +1,26 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"eni-project/internal/ec2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolve_RateLimit(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte("profiles:\n  default:\n    rate_limit:\n      mutating_rate: 5\n      describe_burst: 20\n"), 0o644))
+	t.Setenv("ENI_DESCRIBE_RATE", "4")
+
+	flags := &settingFlags{configPath: path}
+	r, err := flags.resolve()
+	assert.NoError(t, err)
+	// The profile overrides only the fields it sets
+	assert.Equal(t, ec2.RateLimitConfig{MutatingRate: 5, MutatingBurst: 50, DescribeRate: 4, DescribeBurst: 20}, r.RateLimit)
+
+	t.Setenv("ENI_MUTATING_BURST", "0")
+	_, err = flags.resolve()
+	assert.ErrorContains(t, err, "rate_limit.mutating_burst must be positive")
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"eni-project/internal/ec2"
+)
+
+// runSnapshot implements the snapshot subcommands: take, which writes the
+// ENI configuration of a subnet or instance to stdout, and restore, which
+// recreates the ENIs of a snapshot file with the same addresses
+func runSnapshot(args []string, eniManager *ec2.ENIManager, timeout time.Duration) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: snapshot take|restore")
+		exit(2)
+	}
+	command, args := args[0], args[1:]
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	switch command {
+	case "take":
+		fs := flag.NewFlagSet("snapshot take", flag.ExitOnError)
+		fs.Parse(args)
+		if fs.NArg() != 1 {
+			fmt.Fprintln(os.Stderr, "usage: snapshot take SUBNET_ID|INSTANCE_ID")
+			exit(2)
+		}
+
+		snapshot, err := eniManager.TakeSnapshot(ctx, fs.Arg(0))
+		if err != nil {
+			fatal("Failed to take snapshot", err)
+		}
+		for eniID, reason := range snapshot.Skipped {
+			slog.Warn("Skipped ENI", "eni", eniID, "reason", reason)
+		}
+		if err := ec2.WriteSnapshot(os.Stdout, snapshot); err != nil {
+			fatal("Failed to write snapshot", err)
+		}
+
+	case "restore":
+		fs := flag.NewFlagSet("snapshot restore", flag.ExitOnError)
+		asJSON := fs.Bool("json", false, "print the restore results as JSON")
+		fs.Parse(args)
+		if fs.NArg() != 1 {
+			fmt.Fprintln(os.Stderr, "usage: snapshot restore [-json] FILE")
+			exit(2)
+		}
+
+		snapshot, err := ec2.LoadSnapshot(fs.Arg(0))
+		if err != nil {
+			fatal("Failed to load snapshot", err)
+		}
+		results, restoreErr := eniManager.RestoreSnapshot(ctx, snapshot)
+		if *asJSON {
+			json.NewEncoder(os.Stdout).Encode(results)
+		} else {
+			printRestore(results)
+		}
+		if restoreErr != nil {
+			fatal("Failed to restore some ENIs", restoreErr)
+		}
+		for _, result := range results {
+			if len(result.Unreclaimed) > 0 {
+				exit(1)
+			}
+		}
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown snapshot command %q (want take or restore)\n", command)
+		exit(2)
+	}
+}
+
+func printRestore(results []ec2.RestoreResult) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "ORIGINAL\tENI\tATTACHMENT\tUNRECLAIMED\tERROR")
+	for _, r := range results {
+		var unreclaimed []string
+		for _, u := range r.Unreclaimed {
+			unreclaimed = append(unreclaimed, fmt.Sprintf("%s (%s)", u.Address, u.Reason))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.OriginalENIID, firstNonEmpty(r.ENIID, "-"),
+			firstNonEmpty(r.AttachmentID, "-"), firstNonEmpty(strings.Join(unreclaimed, ","), "-"), r.Error)
+	}
+	w.Flush()
+}
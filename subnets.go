@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"eni-project/internal/ec2"
+)
+
+// runSubnet implements the subnet subcommands: usage, which breaks down a
+// subnet's addresses by ENI; sample, which appends the available address
+// counts to the history file; and forecast, which projects exhaustion from
+// that history and alerts on the configured thresholds
+func runSubnet(args []string, eniManager *ec2.ENIManager, cfg settings) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: subnet usage|sample|forecast [SUBNET_ID...]")
+		exit(2)
+	}
+	command, args := args[0], args[1:]
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	historyPath := firstNonEmpty(cfg.SubnetHistory, defaultSubnetHistoryPath())
+	subnetIDs := func(fs *flag.FlagSet) []string {
+		if fs.NArg() > 0 {
+			return fs.Args()
+		}
+		if cfg.SubnetID == "" {
+			fmt.Fprintf(os.Stderr, "usage: %s SUBNET_ID... (or configure a subnet)\n", fs.Name())
+			exit(2)
+		}
+		return []string{cfg.SubnetID}
+	}
+
+	switch command {
+	case "usage":
+		flags := flag.NewFlagSet("subnet usage", flag.ExitOnError)
+		tagKey := flags.String("tag", "", "also break usage down by this ENI tag")
+		asJSON := flags.Bool("json", false, "print the usage as JSON")
+		flags.Parse(args)
+
+		for _, subnetID := range subnetIDs(flags) {
+			usage, err := eniManager.SubnetUsage(ctx, subnetID, *tagKey)
+			if err != nil {
+				fatal("Failed to read subnet usage", err)
+			}
+			if *asJSON {
+				json.NewEncoder(os.Stdout).Encode(usage)
+				continue
+			}
+			printSubnetUsage(usage, *tagKey)
+		}
+
+	case "sample":
+		flags := flag.NewFlagSet("subnet sample", flag.ExitOnError)
+		flags.Parse(args)
+
+		now := time.Now().UTC()
+		var samples []ec2.SubnetSample
+		for _, subnetID := range subnetIDs(flags) {
+			usage, err := eniManager.SubnetUsage(ctx, subnetID, "")
+			if err != nil {
+				fatal("Failed to read subnet usage", err)
+			}
+			samples = append(samples, usage.Sample(now))
+			slog.Info("Sampled subnet", "subnet_id", subnetID, "available", usage.Available, "total", usage.Total)
+		}
+		if err := os.MkdirAll(filepath.Dir(historyPath), 0o755); err != nil {
+			fatal("Failed to create history directory", err)
+		}
+		if err := ec2.AppendSubnetSamples(historyPath, samples...); err != nil {
+			fatal("Failed to write subnet history", err)
+		}
+
+	case "forecast":
+		flags := flag.NewFlagSet("subnet forecast", flag.ExitOnError)
+		flags.Parse(args)
+
+		now := time.Now().UTC()
+		alerted := false
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "SUBNET\tAVAILABLE\tUSED\tSAMPLES\tRATE/HOUR\tEXHAUSTED AT\tTIME LEFT")
+		for _, subnetID := range subnetIDs(flags) {
+			usage, err := eniManager.SubnetUsage(ctx, subnetID, "")
+			if err != nil {
+				fatal("Failed to read subnet usage", err)
+			}
+			samples, err := readSubnetHistory(historyPath, subnetID)
+			if err != nil {
+				fatal("Failed to read subnet history", err)
+			}
+			// The live count is the latest point of the trend
+			samples = append(samples, usage.Sample(now))
+
+			var forecast *ec2.SubnetForecast
+			if f, err := ec2.ForecastExhaustion(samples); err != nil {
+				slog.Warn("Unable to forecast subnet exhaustion", "subnet_id", subnetID, "error", err)
+				fmt.Fprintf(w, "%s\t%d\t%.1f%%\t%d\t-\t-\t-\n", subnetID, usage.Available, usage.UsedPercent(), len(samples))
+			} else {
+				forecast = &f
+				exhausted, left := "never", "-"
+				if timeLeft, ok := f.TimeLeft(now); ok {
+					exhausted, left = f.ExhaustedAt.Format(time.RFC3339), timeLeft.Round(time.Minute).String()
+				}
+				fmt.Fprintf(w, "%s\t%d\t%.1f%%\t%d\t%.1f\t%s\t%s\n", subnetID, usage.Available, usage.UsedPercent(),
+					f.Samples, f.RatePerHour, exhausted, left)
+			}
+
+			for _, alert := range cfg.SubnetAlerts.Alerts(usage, forecast, now) {
+				slog.Warn("Subnet alert", "subnet_id", subnetID, "alert", alert)
+				alerted = true
+			}
+		}
+		w.Flush()
+		if alerted {
+			exit(1)
+		}
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown subnet command %q (want usage, sample or forecast)\n", command)
+		exit(2)
+	}
+}
+
+func printSubnetUsage(usage *ec2.SubnetUsage, tagKey string) {
+	fmt.Printf("%s  %s  %s  %d/%d addresses used (%.1f%%), %d available, %d ENIs\n", usage.SubnetID, usage.CIDR,
+		usage.AvailabilityZone, usage.Used(), usage.Total, usage.UsedPercent(), usage.Available, usage.ENIs)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "BY\tVALUE\tADDRESSES")
+	breakdowns := []struct {
+		name   string
+		counts map[string]int32
+	}{
+		{"owner", usage.ByOwner},
+		{"interface-type", usage.ByInterfaceType},
+		{"tag:" + tagKey, usage.ByTag},
+	}
+	for _, b := range breakdowns {
+		values := make([]string, 0, len(b.counts))
+		for v := range b.counts {
+			values = append(values, v)
+		}
+		// Largest first, so the biggest consumers lead
+		sort.Slice(values, func(i, j int) bool {
+			if b.counts[values[i]] != b.counts[values[j]] {
+				return b.counts[values[i]] > b.counts[values[j]]
+			}
+			return values[i] < values[j]
+		})
+		for _, v := range values {
+			fmt.Fprintf(w, "%s\t%s\t%d\n", b.name, v, b.counts[v])
+		}
+	}
+	w.Flush()
+	fmt.Println()
+}
+
+// readSubnetHistory returns the samples of subnetID in the history file; a
+// missing file has none
+func readSubnetHistory(path, subnetID string) ([]ec2.SubnetSample, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return ec2.ReadSubnetSamples(f, subnetID)
+}
+
+func defaultSubnetHistoryPath() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "subnet-history.jsonl"
+	}
+	return filepath.Join(dir, "eni-manager", "subnet-history.jsonl")
+}
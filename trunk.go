@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"eni-project/internal/ec2"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+)
+
+// runTrunk implements the trunk subcommands: create, associate,
+// disassociate and list
+func runTrunk(args []string, eniManager *ec2.ENIManager, cfg settings) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: trunk create|associate|disassociate|list")
+		exit(2)
+	}
+	command, args := args[0], args[1:]
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	switch command {
+	case "create":
+		fs := flag.NewFlagSet("trunk create", flag.ExitOnError)
+		branch := fs.Bool("branch", false, "create a branch ENI instead of a trunk")
+		subnetID := fs.String("subnet", cfg.SubnetID, "subnet to create the ENI in")
+		securityGroups := fs.String("security-groups", strings.Join(cfg.SecurityGroupIDs, ","), "comma-separated security groups")
+		instanceID := fs.String("instance", "", "attach the trunk to this instance")
+		deviceIndex := fs.Int("device-index", 1, "device index to attach the trunk at")
+		fs.Parse(args)
+
+		interfaceType := types.NetworkInterfaceCreationTypeTrunk
+		if *branch {
+			interfaceType = types.NetworkInterfaceCreationTypeBranch
+		}
+		eni, err := eniManager.CreateENI(ctx, ec2.ENIConfig{
+			SubnetID:         *subnetID,
+			Description:      string(interfaceType) + " ENI",
+			SecurityGroupIDs: splitList(*securityGroups),
+			Tags:             map[string]string{ec2.ManagedByTagKey: ec2.ManagedByTagValue},
+			InterfaceType:    interfaceType,
+		})
+		if err != nil {
+			fatal("Failed to create "+string(interfaceType)+" ENI", err)
+		}
+		eniID := aws.ToString(eni.NetworkInterface.NetworkInterfaceId)
+		slog.Info("Created ENI", "eni_id", eniID, "interface_type", interfaceType)
+
+		if *instanceID != "" && !*branch {
+			attachmentID, err := eniManager.AttachENI(ctx, eniID, *instanceID, int32(*deviceIndex))
+			if err != nil {
+				fatal("Failed to attach trunk ENI", err)
+			}
+			slog.Info("Attached trunk ENI", "eni_id", eniID, "attachment_id", aws.ToString(attachmentID))
+		}
+		fmt.Println(eniID)
+
+	case "associate":
+		fs := flag.NewFlagSet("trunk associate", flag.ExitOnError)
+		trunkID := fs.String("trunk", "", "trunk ENI (required)")
+		branchID := fs.String("branch", "", "branch ENI (required)")
+		vlan := fs.Int("vlan", 0, "VLAN ID, 1-4094 (required)")
+		fs.Parse(args)
+		if *trunkID == "" || *branchID == "" || *vlan == 0 {
+			fmt.Fprintln(os.Stderr, "trunk associate: -trunk, -branch and -vlan are required")
+			exit(2)
+		}
+
+		associationID, err := eniManager.AssociateBranchENI(ctx, *trunkID, *branchID, int32(*vlan))
+		if err != nil {
+			fatal("Failed to associate branch ENI", err)
+		}
+		fmt.Println(associationID)
+
+	case "disassociate":
+		if len(args) != 1 {
+			fmt.Fprintln(os.Stderr, "usage: trunk disassociate ASSOCIATION_ID")
+			exit(2)
+		}
+		if err := eniManager.DisassociateBranchENI(ctx, args[0]); err != nil {
+			fatal("Failed to disassociate branch ENI", err)
+		}
+
+	case "list":
+		fs := flag.NewFlagSet("trunk list", flag.ExitOnError)
+		trunks := fs.String("trunk", "", "comma-separated trunk ENIs; also reports their branch capacity")
+		fs.Parse(args)
+
+		trunkIDs := splitList(*trunks)
+		associations, err := eniManager.DescribeTrunkAssociations(ctx, trunkIDs...)
+		if err != nil {
+			fatal("Failed to list trunk associations", err)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "TRUNK\tBRANCH\tVLAN\tASSOCIATION")
+		for _, a := range associations {
+			fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", aws.ToString(a.TrunkInterfaceId), aws.ToString(a.BranchInterfaceId),
+				aws.ToInt32(a.VlanId), aws.ToString(a.AssociationId))
+		}
+		w.Flush()
+
+		for _, trunkID := range trunkIDs {
+			capacity, err := eniManager.TrunkCapacity(ctx, trunkID)
+			if err != nil {
+				slog.Warn("Unable to determine branch capacity", "trunk_eni_id", trunkID, "error", err)
+				continue
+			}
+			slog.Info("Trunk branch capacity", "trunk_eni_id", trunkID, "instance_id", capacity.InstanceID,
+				"instance_type", capacity.InstanceType, "used", capacity.Used, "limit", capacity.Limit)
+		}
+
+	default:
+		fmt.Fprintf(os.Stderr, "unknown trunk command %q (want create, associate, disassociate or list)\n", command)
+		exit(2)
+	}
+}